@@ -22,6 +22,7 @@ import (
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
 )
 
 // Action type is used to perform specific baremetal action
@@ -44,6 +45,15 @@ const (
 
 	flagAll            = "all"
 	flagAllDescription = "specify this to target all hosts in the inventory"
+
+	flagMaxConcurrency            = "max-concurrency"
+	flagMaxConcurrencyDescription = "maximum number of hosts to perform baremetal action against at the same time"
+
+	flagFailFast            = "fail-fast"
+	flagFailFastDescription = "stop performing baremetal action against remaining hosts after first failure"
+
+	flagContinueOnError            = "continue-on-error"
+	flagContinueOnErrorDescription = "do not return error if baremetal action failed against some of the hosts"
 )
 
 var (
@@ -74,16 +84,31 @@ func NewBaremetalCommand(cfgFactory config.Factory) *cobra.Command {
 		Short: "Perform actions on baremetal hosts",
 	}
 
-	baremetalRootCmd.AddCommand(NewEjectMediaCommand(cfgFactory, options))
-	baremetalRootCmd.AddCommand(NewPowerOffCommand(cfgFactory, options))
-	baremetalRootCmd.AddCommand(NewPowerOnCommand(cfgFactory, options))
-	baremetalRootCmd.AddCommand(NewPowerStatusCommand(cfgFactory, options))
-	baremetalRootCmd.AddCommand(NewRebootCommand(cfgFactory, options))
-	baremetalRootCmd.AddCommand(NewRemoteDirectCommand(cfgFactory, options))
+	// every command gets its own copy of options, so that defaults of its flags are not
+	// overwritten by flags of other commands bound to the same options
+	baremetalRootCmd.AddCommand(NewBIOSCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewEjectMediaCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewFirmwareCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewInventoryCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewPowerOffCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewPowerOnCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewPowerCycleCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewPowerStatusCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewRebootCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewRemoteDirectCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewRotateCredentialsCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewSetBootDeviceCommand(cfgFactory, copyOptions(options)))
+	baremetalRootCmd.AddCommand(NewWaitPowerStateCommand(cfgFactory, copyOptions(options)))
 
 	return baremetalRootCmd
 }
 
+// copyOptions returns a copy of options to be bound to flags of a single command
+func copyOptions(options *inventory.CommandOptions) *inventory.CommandOptions {
+	optionsCopy := *options
+	return &optionsCopy
+}
+
 func initFlags(options *inventory.CommandOptions, cmd *cobra.Command) {
 	flags := cmd.Flags()
 	flags.StringVarP(&options.Labels, flagLabel, flagLabelShort, "", flagLabelDescription)
@@ -95,3 +120,21 @@ func initFlags(options *inventory.CommandOptions, cmd *cobra.Command) {
 func initAllFlag(options *inventory.CommandOptions, cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&options.All, flagAll, false, flagAllDescription)
 }
+
+func initBatchFlags(options *inventory.CommandOptions, cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.IntVar(&options.MaxConcurrency, flagMaxConcurrency, 1, flagMaxConcurrencyDescription)
+	flags.BoolVar(&options.FailFast, flagFailFast, false, flagFailFastDescription)
+	flags.BoolVar(&options.ContinueOnError, flagContinueOnError, false, flagContinueOnErrorDescription)
+}
+
+// runBMHAction performs baremetal action against selected hosts and prints per host summary
+func runBMHAction(cmd *cobra.Command, options *inventory.CommandOptions, op ifc.BaremetalOperation) error {
+	result, err := options.BMHAction(op)
+	if len(result.Hosts) != 0 {
+		if printErr := inventory.PrintBatchResult(cmd.OutOrStdout(), result); printErr != nil {
+			return printErr
+		}
+	}
+	return err
+}
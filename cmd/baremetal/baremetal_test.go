@@ -17,6 +17,9 @@ package baremetal_test
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"opendev.org/airship/airshipctl/cmd/baremetal"
 	"opendev.org/airship/airshipctl/pkg/inventory"
 	"opendev.org/airship/airshipctl/testutil"
@@ -29,11 +32,46 @@ func TestBaremetal(t *testing.T) {
 			CmdLine: "-h",
 			Cmd:     baremetal.NewBaremetalCommand(nil),
 		},
+		{
+			Name:    "baremetal-bios-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewBIOSCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-bios-get-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewBIOSGetCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-bios-set-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewBIOSSetCommand(nil, &inventory.CommandOptions{}),
+		},
 		{
 			Name:    "baremetal-ejectmedia-with-help",
 			CmdLine: "-h",
 			Cmd:     baremetal.NewEjectMediaCommand(nil, &inventory.CommandOptions{}),
 		},
+		{
+			Name:    "baremetal-firmware-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewFirmwareCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-firmware-list-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewFirmwareListCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-firmware-update-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewFirmwareUpdateCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-inventory-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewInventoryCommand(nil, &inventory.CommandOptions{}),
+		},
 		{
 			Name:    "baremetal-poweroff-with-help",
 			CmdLine: "-h",
@@ -44,6 +82,11 @@ func TestBaremetal(t *testing.T) {
 			CmdLine: "-h",
 			Cmd:     baremetal.NewPowerOnCommand(nil, &inventory.CommandOptions{}),
 		},
+		{
+			Name:    "baremetal-powercycle-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewPowerCycleCommand(nil, &inventory.CommandOptions{}),
+		},
 		{
 			Name:    "baremetal-powerstatus-with-help",
 			CmdLine: "-h",
@@ -59,9 +102,48 @@ func TestBaremetal(t *testing.T) {
 			CmdLine: "-h",
 			Cmd:     baremetal.NewRemoteDirectCommand(nil, &inventory.CommandOptions{}),
 		},
+		{
+			Name:    "baremetal-rotate-credentials-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewRotateCredentialsCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-setbootdevice-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewSetBootDeviceCommand(nil, &inventory.CommandOptions{}),
+		},
+		{
+			Name:    "baremetal-waitpowerstate-with-help",
+			CmdLine: "-h",
+			Cmd:     baremetal.NewWaitPowerStateCommand(nil, &inventory.CommandOptions{}),
+		},
 	}
 
 	for _, tt := range tests {
 		testutil.RunTest(t, tt)
 	}
 }
+
+func TestBaremetalFlagDefaults(t *testing.T) {
+	tests := []struct {
+		args     []string
+		flag     string
+		expected string
+	}{
+		{args: []string{"inventory"}, flag: "output", expected: inventory.OutputFormatYAML},
+		{args: []string{"inventory"}, flag: "max-concurrency", expected: "1"},
+		{args: []string{"powerstatus"}, flag: "output", expected: inventory.OutputFormatTable},
+		{args: []string{"powerstatus"}, flag: "max-concurrency", expected: "10"},
+		{args: []string{"bios", "set"}, flag: "max-concurrency", expected: "1"},
+		{args: []string{"firmware", "update"}, flag: "max-concurrency", expected: "1"},
+	}
+
+	cmd := baremetal.NewBaremetalCommand(nil)
+	for _, tt := range tests {
+		subCmd, _, err := cmd.Find(tt.args)
+		require.NoError(t, err)
+		flag := subCmd.Flags().Lookup(tt.flag)
+		require.NotNil(t, flag)
+		assert.Equal(t, tt.expected, flag.Value.String(), "flag %s of command %v", tt.flag, tt.args)
+	}
+}
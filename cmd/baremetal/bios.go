@@ -0,0 +1,130 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+)
+
+const (
+	flagDriftOnly            = "drift-only"
+	flagDriftOnlyDescription = "show only attributes that differ from desired values"
+
+	flagSkipReboot            = "skip-reboot"
+	flagSkipRebootDescription = "do not reboot hosts, BIOS settings are applied on the next reboot of the host"
+)
+
+var (
+	biosLong = fmt.Sprintf(`
+Manage BIOS settings of baremetal hosts. Desired BIOS attributes are defined
+in %s documents of the inventory, a host references the document by
+setting '%s' label to its name
+`, document.BIOSSettingsKind, document.BIOSSettingsLabel)
+
+	biosGetLong = fmt.Sprintf(`
+Show BIOS attributes of baremetal hosts. Current and desired values of the
+attributes managed by the referenced BIOS settings are shown, all current
+attributes are shown for hosts that don't reference BIOS settings
+%s
+`, selectorsDescription)
+
+	biosGetExample = `
+Show BIOS attributes of host with name rdm9r3s3
+# airshipctl baremetal bios get --name rdm9r3s3
+
+Show attributes that differ from desired values on all hosts defined in inventory
+# airshipctl baremetal bios get --all --drift-only
+`
+
+	biosSetLong = fmt.Sprintf(`
+Apply BIOS settings referenced by baremetal hosts. Attributes that differ from
+desired values are logged and scheduled to be changed, then hosts are rebooted
+to apply them unless --%s flag is specified. Hosts that don't reference BIOS
+settings are skipped if --%s flag is specified, otherwise the command fails
+against them
+%s
+`, flagSkipReboot, flagAll, selectorsDescription)
+
+	biosSetExample = `
+Apply BIOS settings to hosts with a label 'foo=bar', 5 hosts at a time
+# airshipctl baremetal bios set --labels "foo=bar" --max-concurrency 5
+
+Schedule BIOS settings of host with name rdm9r3s3 to be applied on the next reboot
+# airshipctl baremetal bios set --name rdm9r3s3 --skip-reboot
+`
+)
+
+// NewBIOSCommand provides a command group to manage BIOS settings of baremetal hosts.
+func NewBIOSCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bios",
+		Short: "Manage BIOS settings of baremetal hosts",
+		Long:  biosLong[1:],
+	}
+
+	cmd.AddCommand(NewBIOSGetCommand(cfgFactory, copyOptions(options)))
+	cmd.AddCommand(NewBIOSSetCommand(cfgFactory, copyOptions(options)))
+
+	return cmd
+}
+
+// NewBIOSGetCommand provides a command to show BIOS attributes of baremetal hosts.
+func NewBIOSGetCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	var driftOnly bool
+	cmd := &cobra.Command{
+		Use:     "get",
+		Short:   "Show BIOS attributes of baremetal hosts",
+		Long:    biosGetLong[1:],
+		Example: biosGetExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.PrintBIOSSettings(cmd.OutOrStdout(), driftOnly)
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+	cmd.Flags().BoolVar(&driftOnly, flagDriftOnly, false, flagDriftOnlyDescription)
+
+	return cmd
+}
+
+// NewBIOSSetCommand provides a command to apply BIOS settings referenced by baremetal hosts.
+func NewBIOSSetCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "set",
+		Short:   "Apply BIOS settings referenced by baremetal hosts",
+		Long:    biosSetLong[1:],
+		Example: biosSetExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBMHAction(cmd, options, ifc.BaremetalOperationApplyBIOSSettings)
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
+	cmd.Flags().BoolVar(&options.SkipReboot, flagSkipReboot, false, flagSkipRebootDescription)
+
+	return cmd
+}
@@ -44,12 +44,13 @@ func NewEjectMediaCommand(cfgFactory config.Factory, options *inventory.CommandO
 		Example: ejectMediaExample[1:],
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationEjectVirtualMedia)
+			return runBMHAction(cmd, options, ifc.BaremetalOperationEjectVirtualMedia)
 		},
 	}
 
 	initFlags(options, cmd)
 	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
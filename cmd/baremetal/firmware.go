@@ -0,0 +1,118 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	flagImageURL            = "image-url"
+	flagImageURLDescription = "URL of the firmware image, the image is downloaded by BMC of the host"
+)
+
+var (
+	firmwareListLong = fmt.Sprintf(`
+List firmware components installed on baremetal hosts along with their versions
+%s
+`, selectorsDescription)
+
+	firmwareListExample = `
+List firmware of host with name rdm9r3s3
+# airshipctl baremetal firmware list --name rdm9r3s3
+
+List firmware of all hosts defined in inventory
+# airshipctl baremetal firmware list --all
+`
+
+	firmwareUpdateLong = fmt.Sprintf(`
+Update firmware of baremetal hosts from the image. BMC of every host downloads
+the image and installs it, the command waits until the update is finished.
+Some components apply new firmware only after the host is rebooted
+%s
+`, selectorsDescription)
+
+	firmwareUpdateExample = `
+Update firmware of host with name rdm9r3s3
+# airshipctl baremetal firmware update --name rdm9r3s3 --image-url http://images.example.com/bmc.bin
+
+Update firmware of hosts with a label 'foo=bar', 5 hosts at a time, without failing on the first error
+# airshipctl baremetal firmware update --labels "foo=bar" --image-url http://images.example.com/bmc.bin \
+  --max-concurrency 5 --continue-on-error
+`
+)
+
+// NewFirmwareCommand provides a command group to manage firmware of baremetal hosts.
+func NewFirmwareCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "firmware",
+		Short: "Manage firmware of baremetal hosts",
+	}
+
+	cmd.AddCommand(NewFirmwareListCommand(cfgFactory, copyOptions(options)))
+	cmd.AddCommand(NewFirmwareUpdateCommand(cfgFactory, copyOptions(options)))
+
+	return cmd
+}
+
+// NewFirmwareListCommand provides a command to list firmware of baremetal hosts.
+func NewFirmwareListCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List firmware of baremetal hosts",
+		Long:    firmwareListLong[1:],
+		Example: firmwareListExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.PrintFirmwareInventory(cmd.OutOrStdout())
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+
+	return cmd
+}
+
+// NewFirmwareUpdateCommand provides a command to update firmware of baremetal hosts.
+func NewFirmwareUpdateCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "update",
+		Short:   "Update firmware of baremetal hosts",
+		Long:    firmwareUpdateLong[1:],
+		Example: firmwareUpdateExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBMHAction(cmd, options, ifc.BaremetalOperationUpdateFirmware)
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
+	cmd.Flags().StringVar(&options.FirmwareImageURL, flagImageURL, "", flagImageURLDescription)
+	if err := cmd.MarkFlagRequired(flagImageURL); err != nil {
+		log.Fatalf("marking image-url flag required failed: %v", err)
+	}
+
+	return cmd
+}
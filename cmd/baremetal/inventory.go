@@ -0,0 +1,88 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+)
+
+const (
+	flagOutput            = "output"
+	flagOutputShort       = "o"
+	flagOutputDescription = "output format, one of 'yaml' or 'json'"
+
+	flagHardwareFormat            = "format"
+	flagHardwareFormatDescription = "report format, one of 'report', 'hardware-profile' or 'bmh'"
+)
+
+var (
+	inventoryCommand = "inventory"
+
+	inventoryLong = fmt.Sprintf(`
+Collect hardware inventory of baremetal hosts from their BMCs. The inventory
+includes CPU model and count, RAM size, network interfaces and disks of the host.
+
+By default normalized hardware report of every host is printed, --%[1]s flag
+allows to print HardwareClassification profile matching the hardware of every
+host ('hardware-profile') or BareMetalHost fragment with hardware details
+annotation ('bmh') that makes metal3 skip inspection of the host
+%[2]s
+`, flagHardwareFormat, selectorsDescription)
+
+	inventoryExample = `
+Print hardware report of host with name rdm9r3s3
+# airshipctl baremetal inventory --name rdm9r3s3
+
+Print hardware report of all hosts defined in inventory in json format
+# airshipctl baremetal inventory --all -o json
+
+Generate hardware classification profiles for hosts with a label 'foo=bar'
+# airshipctl baremetal inventory --labels "foo=bar" --format hardware-profile
+
+Generate BareMetalHost fragments with hardware details of all hosts
+# airshipctl baremetal inventory --all --format bmh --max-concurrency 10
+`
+)
+
+// NewInventoryCommand provides a command to collect hardware inventory of baremetal hosts.
+func NewInventoryCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     inventoryCommand,
+		Short:   "Collect hardware inventory of baremetal hosts",
+		Long:    inventoryLong[1:],
+		Example: inventoryExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return options.HardwareInventory(cmd.OutOrStdout())
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.MaxConcurrency, flagMaxConcurrency, 1, flagMaxConcurrencyDescription)
+	flags.StringVarP(&options.OutputFormat, flagOutput, flagOutputShort, inventory.OutputFormatYAML,
+		flagOutputDescription)
+	flags.StringVar(&options.HardwareFormat, flagHardwareFormat, inventory.HardwareFormatReport,
+		flagHardwareFormatDescription)
+
+	return cmd
+}
@@ -44,12 +44,13 @@ func NewPowerOffCommand(cfgFactory config.Factory, options *inventory.CommandOpt
 		Example: powerOffExample[1:],
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationPowerOff)
+			return runBMHAction(cmd, options, ifc.BaremetalOperationPowerOff)
 		},
 	}
 
 	initFlags(options, cmd)
 	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
@@ -44,12 +44,13 @@ func NewPowerOnCommand(cfgFactory config.Factory, options *inventory.CommandOpti
 		Example: powerOnExample[1:],
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationPowerOn)
+			return runBMHAction(cmd, options, ifc.BaremetalOperationPowerOn)
 		},
 	}
 
 	initFlags(options, cmd)
 	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
@@ -15,24 +15,62 @@
 package baremetal
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/inventory"
 )
 
-// NewPowerStatusCommand provides a command to retrieve the power status of a baremetal host.
+const (
+	flagPowerStatusOutputDescription = "output format, one of 'table', 'yaml' or 'json'"
+
+	powerStatusMaxConcurrency = 10
+)
+
+var (
+	powerStatusCommand = "powerstatus"
+
+	powerStatusLong = fmt.Sprintf(`
+Retrieve the power status of baremetal hosts. BMCs of the hosts are queried
+concurrently, hosts that can't be reached are reported with unknown power
+state and the error
+%s
+`, selectorsDescription)
+
+	powerStatusExample = `
+Retrieve the power status of host with name rdm9r3s3
+# airshipctl baremetal powerstatus --name rdm9r3s3
+
+Retrieve the power status of all hosts defined in inventory in yaml format
+# airshipctl baremetal powerstatus --all -o yaml
+
+Retrieve the power status of hosts with a label 'foo=bar', 20 hosts at a time
+# airshipctl baremetal powerstatus --labels "foo=bar" --max-concurrency 20
+`
+)
+
+// NewPowerStatusCommand provides a command to retrieve the power status of baremetal hosts.
 func NewPowerStatusCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "powerstatus",
-		Short: "Retrieve the power status of a baremetal host",
-		Args:  cobra.NoArgs,
+		Use:     powerStatusCommand,
+		Short:   "Retrieve the power status of baremetal hosts",
+		Long:    powerStatusLong[1:],
+		Example: powerStatusExample[1:],
+		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return options.PowerStatus(cmd.OutOrStdout())
 		},
 	}
 
 	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+
+	flags := cmd.Flags()
+	flags.IntVar(&options.MaxConcurrency, flagMaxConcurrency, powerStatusMaxConcurrency, flagMaxConcurrencyDescription)
+	flags.StringVarP(&options.OutputFormat, flagOutput, flagOutputShort, inventory.OutputFormatTable,
+		flagPowerStatusOutputDescription)
 
 	return cmd
 }
@@ -44,12 +44,13 @@ func NewRebootCommand(cfgFactory config.Factory, options *inventory.CommandOptio
 		Example: rebootExample[1:],
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return options.BMHAction(ifc.BaremetalOperationReboot)
+			return runBMHAction(cmd, options, ifc.BaremetalOperationReboot)
 		},
 	}
 
 	initFlags(options, cmd)
 	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
 
 	return cmd
 }
@@ -19,20 +19,46 @@ import (
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/remote/isoserver"
+)
+
+const (
+	remoteDirectLong = `
+Bootstrap the ephemeral host by attaching ISO image as virtual media and rebooting the host from it.
+ISO image can be specified either by URL reachable from the BMC or by path to a local file. Local
+file is served by built-in HTTP server which is stopped once the host finished reading the image.
+`
+
+	remoteDirectExample = `
+Bootstrap the ephemeral host from ISO image available over HTTP
+# airshipctl baremetal remotedirect --name ephemeral --iso-url http://10.23.24.1:8099/ephemeral.iso
+
+Bootstrap the ephemeral host from local ISO file served on specific address
+# airshipctl baremetal remotedirect --name ephemeral --iso-file ./ephemeral.iso --iso-server-address 10.23.24.1:8099
+`
 )
 
 // NewRemoteDirectCommand provides a command with the capability to perform remote direct operations.
 func NewRemoteDirectCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "remotedirect",
-		Short: "Bootstrap the ephemeral host",
+		Use:     "remotedirect",
+		Short:   "Bootstrap the ephemeral host",
+		Long:    remoteDirectLong[1:],
+		Example: remoteDirectExample[1:],
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return options.RemoteDirect()
 		},
 	}
 	initFlags(options, cmd)
 
-	cmd.Flags().StringVar(&options.IsoURL, "iso-url", "", "specify iso url for host to boot from")
+	flags := cmd.Flags()
+	flags.StringVar(&options.IsoURL, "iso-url", "", "specify iso url for host to boot from")
+	flags.StringVar(&options.IsoFile, "iso-file", "",
+		"specify path to local iso file to be served to the host by built-in HTTP server")
+	flags.StringVar(&options.IsoServerAddress, "iso-server-address", isoserver.DefaultListenAddress,
+		"address for built-in HTTP server to listen on, must be reachable from the BMC")
+	flags.DurationVar(&options.IsoServerIdleTimeout, "iso-server-idle-timeout", inventory.DefaultIsoServerIdleTimeout,
+		"time the host must not request iso file to consider the host booted and stop HTTP server")
 
 	return cmd
 }
@@ -0,0 +1,99 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+)
+
+const (
+	flagEncrypter = "encrypter"
+
+	flagPasswordLength            = "password-length"
+	flagPasswordLengthDescription = "length of generated BMC passwords, IPMI supports passwords up to 20 characters"
+
+	flagPrintOnFailure            = "print-on-failure"
+	flagPrintOnFailureDescription = "print rotated credentials Secrets unencrypted if they can't be written " +
+		"to the inventory"
+)
+
+var (
+	flagEncrypterDescription = fmt.Sprintf("name of the GenericContainer used to encrypt updated credentials "+
+		"Secrets, Secrets encrypted in the inventory are encrypted by '%s' GenericContainer if not set",
+		inventory.DefaultEncrypter)
+
+	rotateCredentialsLong = fmt.Sprintf(`
+Rotate BMC credentials of baremetal hosts. A new password is generated for
+every credentials Secret referenced by the hosts and changed through Redfish
+AccountService on every host referencing the Secret, including hosts not
+matched by the flags. Before any password is changed, the hosts are checked
+to support passwords of the length set by --%s and the Secrets are
+checked to be writable to the inventory. The new password is verified by
+logging in to the BMC, if it can't be changed on some of the hosts sharing
+the Secret, the password is reverted on the others. Updated Secrets are
+written back to the inventory documents, encrypted by the GenericContainer
+set by --%s. Secrets encrypted with SOPS in the inventory are never
+written unencrypted, they are encrypted by '%s' GenericContainer if
+--%s flag is not specified. Only the password field of the Secrets
+is updated, Secrets produced by a generator or changed by a patch can't
+be rotated. If the Secrets can't be written once the passwords are
+changed, they are printed unencrypted only if --%s is set
+%s
+`, flagPasswordLength, flagEncrypter, inventory.DefaultEncrypter, flagEncrypter, flagPrintOnFailure,
+		selectorsDescription)
+
+	rotateCredentialsExample = `
+Rotate credentials of host with name rdm9r3s3
+# airshipctl baremetal rotate-credentials --name rdm9r3s3
+
+Rotate credentials of all hosts defined in inventory and encrypt the Secrets with SOPS
+# airshipctl baremetal rotate-credentials --all --encrypter encrypter
+`
+)
+
+// NewRotateCredentialsCommand provides a command to rotate BMC credentials of baremetal hosts.
+func NewRotateCredentialsCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "rotate-credentials",
+		Short:   "Rotate BMC credentials of baremetal hosts",
+		Long:    rotateCredentialsLong[1:],
+		Example: rotateCredentialsExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			result, err := options.RotateCredentials(cmd.OutOrStdout())
+			if len(result.Hosts) != 0 {
+				if printErr := inventory.PrintBatchResult(cmd.OutOrStdout(), result); printErr != nil {
+					return printErr
+				}
+			}
+			return err
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
+	cmd.Flags().StringVar(&options.Encrypter, flagEncrypter, "", flagEncrypterDescription)
+	cmd.Flags().IntVar(&options.PasswordLength, flagPasswordLength, inventory.DefaultPasswordLength,
+		flagPasswordLengthDescription)
+	cmd.Flags().BoolVar(&options.PrintOnFailure, flagPrintOnFailure, false, flagPrintOnFailureDescription)
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+)
+
+const (
+	flagBootDevice            = "device"
+	flagBootDeviceDescription = "device to boot from, one of 'pxe', 'disk' or 'cd'"
+
+	flagPersistent            = "persistent"
+	flagPersistentDescription = "boot from the device on all future boots, by default the device is used only once"
+)
+
+var (
+	setBootDeviceCommand = "setbootdevice"
+
+	setBootDeviceLong = fmt.Sprintf(`
+Set boot device of baremetal hosts. By default the device is used only for the
+next boot of the host, use --%s flag to use it for all future boots
+%s
+`, flagPersistent, selectorsDescription)
+
+	setBootDeviceExample = `
+Boot host with name rdm9r3s3 from network exactly once
+# airshipctl baremetal setbootdevice --name rdm9r3s3 --device pxe
+
+Boot all hosts defined in inventory from local disk
+# airshipctl baremetal setbootdevice --all --device disk --persistent
+`
+)
+
+// NewSetBootDeviceCommand provides a command with the capability to set boot device of baremetal hosts.
+func NewSetBootDeviceCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     setBootDeviceCommand,
+		Short:   "Set boot device of a hosts",
+		Long:    setBootDeviceLong[1:],
+		Example: setBootDeviceExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBMHAction(cmd, options, ifc.BaremetalOperationSetBootDevice)
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
+
+	flags := cmd.Flags()
+	flags.StringVar(&options.BootDevice, flagBootDevice, "", flagBootDeviceDescription)
+	flags.BoolVar(&options.Persistent, flagPersistent, false, flagPersistentDescription)
+
+	return cmd
+}
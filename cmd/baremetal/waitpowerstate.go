@@ -0,0 +1,69 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+)
+
+const (
+	flagPowerState            = "state"
+	flagPowerStateDescription = "power state to wait for, one of 'on' or 'off'"
+)
+
+var (
+	waitPowerStateCommand = "waitpowerstate"
+
+	waitPowerStateLong = fmt.Sprintf(`
+Wait for baremetal hosts to reach the power state
+%s
+`, selectorsDescription)
+
+	waitPowerStateExample = `
+Wait for host with name rdm9r3s3 to power off
+# airshipctl baremetal waitpowerstate --name rdm9r3s3 --state off
+
+Wait up to 5 minutes for all hosts defined in inventory to power on
+# airshipctl baremetal waitpowerstate --all --state on --timeout 5m
+`
+)
+
+// NewWaitPowerStateCommand provides a command to wait for baremetal hosts to reach the power state.
+func NewWaitPowerStateCommand(cfgFactory config.Factory, options *inventory.CommandOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     waitPowerStateCommand,
+		Short:   "Wait for a hosts to reach the power state",
+		Long:    waitPowerStateLong[1:],
+		Example: waitPowerStateExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBMHAction(cmd, options, ifc.BaremetalOperationWaitForPowerState)
+		},
+	}
+
+	initFlags(options, cmd)
+	initAllFlag(options, cmd)
+	initBatchFlags(options, cmd)
+
+	cmd.Flags().StringVar(&options.PowerState, flagPowerState, "", flagPowerStateDescription)
+
+	return cmd
+}
@@ -43,6 +43,7 @@ func NewClusterCommand(cfgFactory config.Factory) *cobra.Command {
 	clusterRootCmd.AddCommand(checkexpiration.NewCheckCommand(cfgFactory))
 	clusterRootCmd.AddCommand(NewGetKubeconfigCommand(cfgFactory))
 	clusterRootCmd.AddCommand(NewListCommand(cfgFactory))
+	clusterRootCmd.AddCommand(NewInventoryCommand(cfgFactory))
 
 	return clusterRootCmd
 }
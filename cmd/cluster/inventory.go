@@ -0,0 +1,146 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cluster
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/cluster"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/k8s/client"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	inventoryLong = `
+Inventory objects keep track of the resources applied to the cluster by
+KubernetesApply phases, they are used to prune resources removed from
+the phase and to report the status of the phase.
+`
+
+	inventoryListExample = `
+# List inventories of all phases applied to the cluster
+airshipctl cluster inventory list --kubeconfig ~/.airship/kubeconfig
+
+# List inventories stored in airshipit namespace in yaml format
+airshipctl cluster inventory list -n airshipit -o yaml --kubeconfig ~/.airship/kubeconfig
+`
+
+	inventoryShowExample = `
+# Show objects owned by initinfra phase
+airshipctl cluster inventory show initinfra --kubeconfig ~/.airship/kubeconfig
+`
+
+	inventoryMigrateExample = `
+# Move inventory of initinfra phase to target-infra namespace
+airshipctl cluster inventory migrate initinfra --to-namespace target-infra --kubeconfig ~/.airship/kubeconfig
+`
+
+	inventoryKubeconfigFlag = "kubeconfig"
+)
+
+// NewInventoryCommand creates a command for inspecting inventory objects in the cluster
+func NewInventoryCommand(cfgFactory config.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Inspect and manage inventories of applied phases",
+		Long:  inventoryLong[1:],
+	}
+	cmd.AddCommand(NewInventoryListCommand(cfgFactory))
+	cmd.AddCommand(NewInventoryShowCommand(cfgFactory))
+	cmd.AddCommand(NewInventoryMigrateCommand(cfgFactory))
+	return cmd
+}
+
+// NewInventoryListCommand creates a command which lists inventory objects in the cluster
+func NewInventoryListCommand(cfgFactory config.Factory) *cobra.Command {
+	c := &cluster.InventoryListCommand{
+		Options: cluster.InventoryOptions{CfgFactory: cfgFactory, ClientFactory: client.DefaultClient},
+	}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "List inventories of the phases applied to the cluster",
+		Example: inventoryListExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return c.RunE(cmd.OutOrStdout())
+		},
+	}
+	addInventoryFlags(cmd, &c.Options)
+	return cmd
+}
+
+// NewInventoryShowCommand creates a command which shows objects owned by the inventory
+func NewInventoryShowCommand(cfgFactory config.Factory) *cobra.Command {
+	c := &cluster.InventoryShowCommand{
+		Options: cluster.InventoryOptions{CfgFactory: cfgFactory, ClientFactory: client.DefaultClient},
+	}
+	cmd := &cobra.Command{
+		Use:     "show INVENTORY_ID",
+		Short:   "Show objects owned by the inventory",
+		Example: inventoryShowExample[1:],
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.ID = args[0]
+			return c.RunE(cmd.OutOrStdout())
+		},
+	}
+	addInventoryFlags(cmd, &c.Options)
+	return cmd
+}
+
+// NewInventoryMigrateCommand creates a command which moves inventory object to a new location
+func NewInventoryMigrateCommand(cfgFactory config.Factory) *cobra.Command {
+	c := &cluster.InventoryMigrateCommand{
+		Options: cluster.InventoryOptions{CfgFactory: cfgFactory, ClientFactory: client.DefaultClient},
+	}
+	cmd := &cobra.Command{
+		Use:     "migrate INVENTORY_ID",
+		Short:   "Move inventory object to a new namespace or name",
+		Example: inventoryMigrateExample[1:],
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c.ID = args[0]
+			return c.RunE(cmd.OutOrStdout())
+		},
+	}
+	flags := cmd.Flags()
+	flags.StringVarP(&c.Options.Namespace, "namespace", "n", "",
+		"namespace of the inventory object, all namespaces are searched if not set")
+	flags.StringVar(&c.Options.Kubeconfig, inventoryKubeconfigFlag, "",
+		"Path to kubeconfig associated with cluster being managed")
+	flags.StringVar(&c.To.Namespace, "to-namespace", "", "new namespace of the inventory object")
+	flags.StringVar(&c.To.Name, "to-name", "", "new name of the inventory object")
+	flags.StringVar(&c.To.ID, "to-id", "", "new inventory ID, keeps current ID if not set")
+	markInventoryKubeconfigRequired(cmd)
+	return cmd
+}
+
+func addInventoryFlags(cmd *cobra.Command, o *cluster.InventoryOptions) {
+	flags := cmd.Flags()
+	flags.StringVarP(&o.Namespace, "namespace", "n", "",
+		"namespace of the inventory objects, all namespaces are searched if not set")
+	flags.StringVarP(&o.Format, "output", "o", cluster.InventoryOutputTable,
+		"'table' and 'yaml' are available output formats")
+	flags.StringVar(&o.Kubeconfig, inventoryKubeconfigFlag, "",
+		"Path to kubeconfig associated with cluster being managed")
+	markInventoryKubeconfigRequired(cmd)
+}
+
+func markInventoryKubeconfigRequired(cmd *cobra.Command) {
+	if err := cmd.MarkFlagRequired(inventoryKubeconfigFlag); err != nil {
+		log.Fatalf("marking kubeconfig flag required failed: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package image
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+)
+
+const (
+	imageLong = `
+This command provides capabilities for managing container images
+used by the phases, e.g. loading them in air-gapped sites
+`
+)
+
+// NewImageCommand creates a command for managing container images
+func NewImageCommand(cfgFactory config.Factory) *cobra.Command {
+	imageRootCmd := &cobra.Command{
+		Use:   "image",
+		Short: "Manage container images",
+		Long:  imageLong[1:],
+	}
+
+	imageRootCmd.AddCommand(NewLoadCommand(cfgFactory))
+
+	return imageRootCmd
+}
@@ -0,0 +1,67 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package image
+
+import (
+	"github.com/spf13/cobra"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/container"
+	"opendev.org/airship/airshipctl/pkg/image"
+)
+
+const (
+	loadLong = `
+Load container images from the tarballs listed in ImageArchive documents
+of the site into local container runtime. Phases using the images with
+"Never" image pull policy can be run afterwards without access to image
+registries.
+`
+
+	loadExample = `
+# Load images of all ImageArchive documents
+airshipctl image load
+
+# Load images of bootstrap-images ImageArchive document
+airshipctl image load --name bootstrap-images
+`
+)
+
+// NewLoadCommand creates a command which loads image tarballs into local container runtime
+func NewLoadCommand(cfgFactory config.Factory) *cobra.Command {
+	l := &image.LoadCommand{
+		Factory:       cfgFactory,
+		LoaderFactory: container.NewImageLoader,
+	}
+	loadCmd := &cobra.Command{
+		Use:     "load",
+		Short:   "Load image tarballs into local container runtime",
+		Long:    loadLong[1:],
+		Example: loadExample[1:],
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l.Writer = cmd.OutOrStdout()
+			return l.RunE()
+		},
+	}
+
+	flags := loadCmd.Flags()
+	flags.StringVar(
+		&l.Name,
+		"name",
+		"",
+		"name of ImageArchive document to load, all documents are loaded if not specified")
+	return loadCmd
+}
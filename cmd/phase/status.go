@@ -44,6 +44,7 @@ func NewStatusCommand(cfgFactory config.Factory) *cobra.Command {
 		Example: statusExample,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			ph.Options.PhaseID.Name = args[0]
+			ph.Writer = cmd.OutOrStdout()
 			return ph.RunE()
 		},
 	}
@@ -35,16 +35,61 @@ type BaremetalManagerSpec struct {
 	OperationOptions BaremetalOperationOptions `json:"operationOptions"`
 	// Timeout in seconds
 	Timeout int `json:"timeout"`
+	// MaxConcurrency is the maximum number of hosts the operation is performed against at the same time
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// FailFast stops performing the operation against remaining hosts after first failure
+	FailFast bool `json:"failFast,omitempty"`
+	// ContinueOnError makes the phase succeed even if operation failed against some of the hosts
+	ContinueOnError bool `json:"continueOnError,omitempty"`
 }
 
 // BaremetalOperationOptions hold operation options
 type BaremetalOperationOptions struct {
-	RemoteDirect RemoteDirectOptions `json:"remoteDirect"`
+	RemoteDirect      RemoteDirectOptions      `json:"remoteDirect"`
+	SetBootDevice     SetBootDeviceOptions     `json:"setBootDevice,omitempty"`
+	WaitForPowerState WaitForPowerStateOptions `json:"waitForPowerState,omitempty"`
+	ApplyBIOSSettings ApplyBIOSSettingsOptions `json:"applyBIOSSettings,omitempty"`
+	UpdateFirmware    UpdateFirmwareOptions    `json:"updateFirmware,omitempty"`
+}
+
+// SetBootDeviceOptions holds configuration for set boot device operation
+type SetBootDeviceOptions struct {
+	// Device to boot from, one of pxe, disk or cd
+	Device string `json:"device"`
+	// Persistent keeps the boot device for all future boots, otherwise host boots from it only once
+	Persistent bool `json:"persistent,omitempty"`
+}
+
+// WaitForPowerStateOptions holds configuration for wait for power state operation
+type WaitForPowerStateOptions struct {
+	// State to wait for, one of on or off
+	State string `json:"state"`
+}
+
+// ApplyBIOSSettingsOptions holds configuration for apply BIOS settings operation
+type ApplyBIOSSettingsOptions struct {
+	// SkipReboot leaves BIOS settings pending until the next reboot of the host,
+	// otherwise the host is rebooted to apply them
+	SkipReboot bool `json:"skipReboot,omitempty"`
+}
+
+// UpdateFirmwareOptions holds configuration for update firmware operation
+type UpdateFirmwareOptions struct {
+	// ImageURL is URL of the firmware image, the image is downloaded by BMC of the host
+	ImageURL string `json:"imageURL"`
 }
 
 // RemoteDirectOptions holds configuration for remote direct operation
 type RemoteDirectOptions struct {
 	ISOURL string `json:"isoURL"`
+	// ISOFile is path to local ISO file, which is served to the host by built-in HTTP server
+	// instead of ISOURL
+	ISOFile string `json:"isoFile,omitempty"`
+	// ISOServerAddress is address for built-in HTTP server to listen on, must be reachable from the BMC
+	ISOServerAddress string `json:"isoServerAddress,omitempty"`
+	// ISOServerIdleTimeout is time in seconds the host must not request ISO file to consider
+	// the host booted and stop built-in HTTP server
+	ISOServerIdleTimeout int `json:"isoServerIdleTimeout,omitempty"`
 }
 
 // BaremetalHostSelector allows to select a host by label selector, by name and namespace
@@ -68,6 +113,16 @@ const (
 	BaremetalOperationRemoteDirect BaremetalOperation = "remote-direct"
 	// BaremetalOperationEjectVirtualMedia eject virtual media
 	BaremetalOperationEjectVirtualMedia BaremetalOperation = "eject-virtual-media"
+	// BaremetalOperationPowerCycle power cycle
+	BaremetalOperationPowerCycle BaremetalOperation = "power-cycle"
+	// BaremetalOperationSetBootDevice set boot device, one time or persistent
+	BaremetalOperationSetBootDevice BaremetalOperation = "set-boot-device"
+	// BaremetalOperationWaitForPowerState wait for hosts to reach power state
+	BaremetalOperationWaitForPowerState BaremetalOperation = "wait-for-power-state"
+	// BaremetalOperationApplyBIOSSettings apply BIOS settings referenced by hosts
+	BaremetalOperationApplyBIOSSettings BaremetalOperation = "apply-bios-settings"
+	// BaremetalOperationUpdateFirmware update firmware of hosts from the image
+	BaremetalOperationUpdateFirmware BaremetalOperation = "update-firmware"
 )
 
 // DefaultBaremetalManager returns BaremetalManager executor document with default values
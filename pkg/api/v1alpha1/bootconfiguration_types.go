@@ -32,16 +32,23 @@ type BootConfiguration struct {
 
 // EphemeralCluster structure contains the data for the ephemeral cluster
 type EphemeralCluster struct {
+	// BootstrapCommand is the action performed with ephemeral cluster, "create", "delete" or "help"
 	BootstrapCommand string `json:"bootstrapCommand,omitempty"`
 	ConfigFilename   string `json:"configFilename,omitempty"`
+	// WriteKubeconfig writes kubeconfig generated by "create" to the path of the filesystem
+	// kubeconfig source of the phase cluster in the ClusterMap, "delete" removes the file
+	WriteKubeconfig bool `json:"writeKubeconfig,omitempty"`
 }
 
 // BootstrapContainer structure contains the data for the bootstrap container
 type BootstrapContainer struct {
+	// ContainerRuntime is "docker" or "podman"
 	ContainerRuntime string `json:"containerRuntime,omitempty"`
 	Image            string `json:"image,omitempty"`
 	Volume           string `json:"volume,omitempty"`
 	Kubeconfig       string `json:"saveKubeconfigFileName,omitempty"`
+	// ImagePullPolicy defines when the image is pulled, default is "IfNotPresent"
+	ImagePullPolicy ImagePullPolicy `json:"imagePullPolicy,omitempty"`
 }
 
 // DefaultBootConfiguration can be used to safely unmarshal BootConfiguration object without nil pointers
@@ -35,6 +35,8 @@ const (
 	KubeConfigEnvKeyContext = "KCTL_CONTEXT"
 	// KubeConfigEnv uses as a kubeconfig env variable
 	KubeConfigEnv = KubeConfigEnvKey + "=" + KubeConfigPath
+	// DefaultEnvFromDecrypter is a name of GenericContainer decrypting documents referenced by envFrom
+	DefaultEnvFromDecrypter = "decrypter"
 
 	// ValidatorPreventCleanup is an env variable that prevents validator to clean up its working directory after finish
 	ValidatorPreventCleanup = "VALIDATOR_PREVENT_CLEANUP"
@@ -90,19 +92,69 @@ type GenericContainerSpec struct {
 	// Image is the container image to run
 	Image string `json:"image,omitempty" yaml:"image,omitempty"`
 
+	// ImagePullPolicy defines when the image is pulled, applies to airship type containers
+	ImagePullPolicy ImagePullPolicy `json:"imagePullPolicy,omitempty"`
+
 	// EnvVars is a slice of env string that will be exposed to container
 	// ["MY_VAR=my-value, "MY_VAR1=my-value1"]
 	// if passed in format ["MY_ENV"] this env variable will be exported the container
 	EnvVars []string `json:"envVars,omitempty"`
 
+	// EnvFrom injects data of Secret and ConfigMap documents of the phase config bundle into
+	// env variables of airship type container. The values are resolved right before the run and
+	// passed to the container runtime only, they are never written to the disk or to the phase documents
+	EnvFrom []EnvFromSource `json:"envFrom,omitempty"`
+
+	// EnvFromDecrypter is a name of krm type GenericContainer of the phase config bundle used to decrypt
+	// SOPS encrypted documents referenced by envFrom, defaults to "decrypter"
+	EnvFromDecrypter string `json:"envFromDecrypter,omitempty"`
+
 	// Mounts are the storage or directories to mount into the container
 	StorageMounts []StorageMount `json:"mounts,omitempty" yaml:"mounts,omitempty"`
 }
 
+// EnvFromSource selects a Secret or ConfigMap document of the phase config bundle,
+// whose data is exposed to the container
+type EnvFromSource struct {
+	// Kind of the document, "Secret" or "ConfigMap"
+	Kind string `json:"kind"`
+
+	// Name of the document
+	Name string `json:"name,omitempty"`
+
+	// Namespace of the document
+	Namespace string `json:"namespace,omitempty"`
+
+	// LabelSelector of the document, e.g. `app=ironic,tier=bmo`
+	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// Key of the document data to inject, all keys are injected if not set
+	Key string `json:"key,omitempty"`
+
+	// EnvVar is the name the key is injected as, defaults to the key
+	EnvVar string `json:"envVar,omitempty"`
+
+	// Prefix is prepended to the names when all keys of the document are injected
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// ImagePullPolicy defines when container image is pulled
+type ImagePullPolicy string
+
+const (
+	// PullAlways pulls the image every time the container is run
+	PullAlways ImagePullPolicy = "Always"
+	// PullIfNotPresent pulls the image only if it is not present locally, this is the default
+	PullIfNotPresent ImagePullPolicy = "IfNotPresent"
+	// PullNever never pulls the image, it must be present locally, e.g. loaded with
+	// `airshipctl image load` in air-gapped sites
+	PullNever ImagePullPolicy = "Never"
+)
+
 // AirshipContainerSpec airship container settings
 type AirshipContainerSpec struct {
 
-	// ContainerRuntime currently supported and default runtime is "docker"
+	// ContainerRuntime is "docker" or "podman", default runtime is "docker"
 	ContainerRuntime string `json:"containerRuntime,omitempty"`
 
 	// Cmd to run inside the container, `["/my-command", "arg"]`
@@ -110,11 +162,67 @@ type AirshipContainerSpec struct {
 
 	// Privileged identifies if the container is to be run in a Privileged mode
 	Privileged bool `json:"privileged,omitempty"`
+
+	// Resources limits compute resources available to the container
+	Resources *ContainerResources `json:"resources,omitempty"`
+
+	// User the command is run as inside the container, `uid`, `uid:gid` or user name,
+	// user defined in the image is used if not set
+	User string `json:"user,omitempty"`
+
+	// WorkingDir is a working directory of the command inside the container
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// Timeout in seconds, the container is killed if the command is not finished in time.
+	// Zero means no timeout
+	Timeout int `json:"timeout,omitempty"`
+
+	// KeepOnFailure leaves the container that has failed or has been killed on timeout on the host,
+	// so that it can be inspected. Otherwise the container is removed once it's finished
+	KeepOnFailure bool `json:"keepOnFailure,omitempty"`
 }
 
-// KRMContainerSpec defines a spec for running a function as a container
-// empty for now since it has no extra fields from AirshipContainerSpec
-type KRMContainerSpec struct{}
+// ContainerResources defines compute resource limits of the container
+type ContainerResources struct {
+	// Memory limit in kubernetes quantity format, e.g. `512Mi` or `2G`
+	Memory string `json:"memory,omitempty"`
+
+	// CPU limit in kubernetes quantity format, e.g. `500m` or `2`
+	CPU string `json:"cpu,omitempty"`
+}
+
+// KRMUser defines the user KRM function is run as
+type KRMUser string
+
+const (
+	// KRMUserCurrent runs the function as the user running airshipctl, this is the default
+	KRMUserCurrent KRMUser = "current"
+	// KRMUserNobody runs the function as nobody user
+	KRMUserNobody KRMUser = "nobody"
+)
+
+// KRMContainerSpec defines a spec for running a function as a container or an executable,
+// in the same way as kpt does
+type KRMContainerSpec struct {
+	// ExecPath is a path to the executable implementing the function, it is used instead of
+	// the image. Relative path is expanded against site root
+	ExecPath string `json:"execPath,omitempty"`
+
+	// ResultsDir is a directory where structured results of the function are written,
+	// relative path is expanded against site root
+	ResultsDir string `json:"resultsDir,omitempty"`
+
+	// User the function is run as, supported values are "current" and "nobody",
+	// defaults to "current"
+	User KRMUser `json:"user,omitempty"`
+
+	// WorkingDir is a directory the executable function is run in, relative path is
+	// expanded against site root. Container functions are run in working dir of the image
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// ErrorOnEmptyResult fails the function run if it returns no resources
+	ErrorOnEmptyResult bool `json:"errorOnEmptyResult,omitempty"`
+}
 
 // StorageMount represents a container's mounted storage option(s)
 // copy from https://github.com/kubernetes-sigs/kustomize to avoid imports in this package
@@ -53,6 +53,7 @@ func init() {
 		&BootConfiguration{},
 		&GenericContainer{},
 		&BaremetalManager{},
+		&ImageArchive{},
 	)
 	_ = AddToScheme(Scheme) //nolint:errcheck
 }
@@ -0,0 +1,40 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+
+// ImageArchive lists container image tarballs that are loaded into local container runtime by
+// `airshipctl image load`, so that phases can be run in sites without access to image registries
+type ImageArchive struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// ContainerRuntime the images are loaded into, "docker" or "podman", default is "docker"
+	ContainerRuntime string `json:"containerRuntime,omitempty"`
+
+	// Archives are paths to image tarballs created by `docker save` or `podman save`,
+	// relative paths are expanded against site root
+	Archives []string `json:"archives,omitempty"`
+}
+
+// DefaultImageArchive can be used to safely unmarshal ImageArchive object without nil pointers
+func DefaultImageArchive() *ImageArchive {
+	return &ImageArchive{}
+}
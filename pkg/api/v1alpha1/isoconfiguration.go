@@ -25,7 +25,7 @@ type IsoContainer struct {
 	Volume string `json:"volume,omitempty"`
 	// ISO generator container image URL
 	Image string `json:"image,omitempty"`
-	// Container Runtime Interface driver
+	// Container Runtime Interface driver, "docker" or "podman"
 	ContainerRuntime string `json:"containerRuntime,omitempty"`
 }
 
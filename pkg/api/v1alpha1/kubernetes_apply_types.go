@@ -30,17 +30,89 @@ type KubernetesApply struct {
 
 // ApplyConfig provides instructions on how to apply resources to kubernetes cluster
 type ApplyConfig struct {
-	WaitOptions  ApplyWaitOptions  `json:"waitOptions,omitempty"`
-	PruneOptions ApplyPruneOptions `json:"pruneOptions,omitempty"`
+	WaitOptions       ApplyWaitOptions       `json:"waitOptions,omitempty"`
+	PruneOptions      ApplyPruneOptions      `json:"pruneOptions,omitempty"`
+	ServerSideOptions ApplyServerSideOptions `json:"serverSideOptions,omitempty"`
+	InventoryOptions  ApplyInventoryOptions  `json:"inventoryOptions,omitempty"`
 }
 
 // ApplyWaitOptions provides instructions how to wait for kubernetes resources
 type ApplyWaitOptions struct {
 	// Timeout in seconds
 	Timeout int `json:"timeout,omitempty"`
+	// Waves sets wait timeouts for particular apply waves, waves that are not listed use Timeout
+	Waves []ApplyWaveOptions `json:"waves,omitempty"`
+	// HealthChecks define custom status expressions for the kinds which definitions are not
+	// annotated with airshipit.org/status-check, such as Deployments or third party resources
+	HealthChecks []ApplyHealthCheck `json:"healthChecks,omitempty"`
+}
+
+// ApplyHealthCheck defines how status of the resources of particular kind is computed while waiting
+type ApplyHealthCheck struct {
+	Group   string `json:"group,omitempty"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	// StatusChecks have the same format as airshipit.org/status-check annotation, resource becomes
+	// Current when the condition of Current status matches it
+	StatusChecks []ApplyStatusCheck `json:"statusChecks"`
+}
+
+// ApplyStatusCheck maps a resource status to JSONPath filter expression
+type ApplyStatusCheck struct {
+	Status    string `json:"status"`
+	Condition string `json:"condition"`
+}
+
+// ApplyWaveOptions provides instructions how to wait for resources of a single apply wave.
+// Resources are assigned to waves by airshipit.org/apply-wave annotation, waves are applied
+// in ascending order and each wave must become Current before next one is applied
+type ApplyWaveOptions struct {
+	// Wave is the value of airshipit.org/apply-wave annotation
+	Wave int `json:"wave"`
+	// Timeout in seconds
+	Timeout int `json:"timeout,omitempty"`
 }
 
 // ApplyPruneOptions provides instructions how to prune for kubernetes resources
 type ApplyPruneOptions struct {
 	Prune bool `json:"prune,omitempty"`
+	// MaxPruneCount aborts the phase before anything is applied if more objects are going to be
+	// pruned, 0 means no limit. Objects annotated with airshipit.org/prune-protect: "true" are never pruned
+	MaxPruneCount int `json:"maxPruneCount,omitempty"`
+}
+
+// ApplyServerSideOptions provides instructions how to use server-side apply for kubernetes resources
+type ApplyServerSideOptions struct {
+	// ServerSideApply switches applier from client-side apply to server-side apply
+	ServerSideApply bool `json:"serverSideApply,omitempty"`
+	// FieldManager is the name of the manager that owns the applied fields,
+	// defaults to airshipctl if not set
+	FieldManager string `json:"fieldManager,omitempty"`
+	// ForceConflicts makes airshipctl take ownership of fields that are owned by other managers
+	ForceConflicts bool `json:"forceConflicts,omitempty"`
+}
+
+// InventoryIDStrategy defines how inventory ID is derived
+type InventoryIDStrategy string
+
+const (
+	// InventoryIDStrategyPhase uses phase name as inventory ID
+	InventoryIDStrategyPhase InventoryIDStrategy = "phase"
+	// InventoryIDStrategyNamespacedName uses namespace and name of inventory object as inventory ID
+	InventoryIDStrategyNamespacedName InventoryIDStrategy = "namespacedName"
+	// InventoryIDStrategyStatic uses ID specified in inventory options
+	InventoryIDStrategyStatic InventoryIDStrategy = "static"
+)
+
+// ApplyInventoryOptions provides instructions where to store inventory object, which keeps track
+// of the resources applied by the phase
+type ApplyInventoryOptions struct {
+	// Name of the inventory object, defaults to airshipit-<inventory ID>
+	Name string `json:"name,omitempty"`
+	// Namespace of the inventory object, defaults to airshipit
+	Namespace string `json:"namespace,omitempty"`
+	// IDStrategy defines how inventory ID is derived, defaults to phase
+	IDStrategy InventoryIDStrategy `json:"idStrategy,omitempty"`
+	// ID is the inventory ID used with static strategy
+	ID string `json:"id,omitempty"`
 }
@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/kustomize/api/types"
 )
 
 // +kubebuilder:object:root=true
@@ -33,6 +34,44 @@ type Phase struct {
 type PhaseConfig struct {
 	ExecutorRef        *corev1.ObjectReference `json:"executorRef"`
 	DocumentEntryPoint string                  `json:"documentEntryPoint"`
+	// Outputs are values produced by the phase, they are consumed by the next phases of the plan
+	Outputs []PhaseOutput `json:"outputs,omitempty"`
+	// Inputs are outputs of the previous phases of the plan consumed by the phase
+	Inputs []PhaseInput `json:"inputs,omitempty"`
+}
+
+// PhaseOutput defines a named value produced by the phase, one of Document or File must be set
+type PhaseOutput struct {
+	// Name of the output, unique within the phase
+	Name string `json:"name"`
+	// Document takes the value from a document produced by the phase, i.e. written by GenericContainer
+	// executor to its sinkOutputDir
+	Document *PhaseOutputDocument `json:"document,omitempty"`
+	// File takes the value from the content of the file, path is relative to site root,
+	// e.g. a file written by generic container to its sinkOutputDir
+	File string `json:"file,omitempty"`
+}
+
+// PhaseOutputDocument selects a document and a field of the document
+type PhaseOutputDocument struct {
+	// Selector of the document, exactly one document must match
+	Selector types.Selector `json:"selector"`
+	// JSONPath of the field, e.g. `{.data.token}`
+	JSONPath string `json:"jsonPath"`
+}
+
+// PhaseInput references an output of the previous phase of the plan. The value is exposed
+// to the phase as env variable and/or as a key of the ConfigMap document added to executor bundle
+type PhaseInput struct {
+	// Phase is the name of the phase producing the output
+	Phase string `json:"phase"`
+	// Output is the name of the output
+	Output string `json:"output"`
+	// EnvVar is the name of env variable added to the env of generic container run by the phase
+	EnvVar string `json:"envVar,omitempty"`
+	// DocumentKey is the key of the ConfigMap document named `<phase name>-inputs`, which is added
+	// to the executor bundle of the phase
+	DocumentKey string `json:"documentKey,omitempty"`
 }
 
 // DefaultPhase can be used to safely unmarshal phase object without nil pointers
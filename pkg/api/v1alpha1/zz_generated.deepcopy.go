@@ -31,6 +31,11 @@ func (in *AirshipContainerSpec) DeepCopyInto(out *AirshipContainerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ContainerResources)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AirshipContainerSpec.
@@ -43,11 +48,28 @@ func (in *AirshipContainerSpec) DeepCopy() *AirshipContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyBIOSSettingsOptions) DeepCopyInto(out *ApplyBIOSSettingsOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyBIOSSettingsOptions.
+func (in *ApplyBIOSSettingsOptions) DeepCopy() *ApplyBIOSSettingsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyBIOSSettingsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplyConfig) DeepCopyInto(out *ApplyConfig) {
 	*out = *in
-	out.WaitOptions = in.WaitOptions
+	in.WaitOptions.DeepCopyInto(&out.WaitOptions)
 	out.PruneOptions = in.PruneOptions
+	out.ServerSideOptions = in.ServerSideOptions
+	out.InventoryOptions = in.InventoryOptions
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyConfig.
@@ -60,6 +82,41 @@ func (in *ApplyConfig) DeepCopy() *ApplyConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyHealthCheck) DeepCopyInto(out *ApplyHealthCheck) {
+	*out = *in
+	if in.StatusChecks != nil {
+		in, out := &in.StatusChecks, &out.StatusChecks
+		*out = make([]ApplyStatusCheck, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyHealthCheck.
+func (in *ApplyHealthCheck) DeepCopy() *ApplyHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyInventoryOptions) DeepCopyInto(out *ApplyInventoryOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyInventoryOptions.
+func (in *ApplyInventoryOptions) DeepCopy() *ApplyInventoryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyInventoryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplyPruneOptions) DeepCopyInto(out *ApplyPruneOptions) {
 	*out = *in
@@ -75,9 +132,51 @@ func (in *ApplyPruneOptions) DeepCopy() *ApplyPruneOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyServerSideOptions) DeepCopyInto(out *ApplyServerSideOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyServerSideOptions.
+func (in *ApplyServerSideOptions) DeepCopy() *ApplyServerSideOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyServerSideOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyStatusCheck) DeepCopyInto(out *ApplyStatusCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyStatusCheck.
+func (in *ApplyStatusCheck) DeepCopy() *ApplyStatusCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyStatusCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplyWaitOptions) DeepCopyInto(out *ApplyWaitOptions) {
 	*out = *in
+	if in.Waves != nil {
+		in, out := &in.Waves, &out.Waves
+		*out = make([]ApplyWaveOptions, len(*in))
+		copy(*out, *in)
+	}
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = make([]ApplyHealthCheck, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyWaitOptions.
@@ -90,6 +189,21 @@ func (in *ApplyWaitOptions) DeepCopy() *ApplyWaitOptions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplyWaveOptions) DeepCopyInto(out *ApplyWaveOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplyWaveOptions.
+func (in *ApplyWaveOptions) DeepCopy() *ApplyWaveOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplyWaveOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BaremetalHostSelector) DeepCopyInto(out *BaremetalHostSelector) {
 	*out = *in
@@ -152,6 +266,10 @@ func (in *BaremetalManagerSpec) DeepCopy() *BaremetalManagerSpec {
 func (in *BaremetalOperationOptions) DeepCopyInto(out *BaremetalOperationOptions) {
 	*out = *in
 	out.RemoteDirect = in.RemoteDirect
+	out.SetBootDevice = in.SetBootDevice
+	out.WaitForPowerState = in.WaitForPowerState
+	out.ApplyBIOSSettings = in.ApplyBIOSSettings
+	out.UpdateFirmware = in.UpdateFirmware
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BaremetalOperationOptions.
@@ -326,6 +444,36 @@ func (in *Clusterctl) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerResources) DeepCopyInto(out *ContainerResources) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerResources.
+func (in *ContainerResources) DeepCopy() *ContainerResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvFromSource) DeepCopyInto(out *EnvFromSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvFromSource.
+func (in *EnvFromSource) DeepCopy() *EnvFromSource {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvFromSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EphemeralCluster) DeepCopyInto(out *EphemeralCluster) {
 	*out = *in
@@ -382,6 +530,11 @@ func (in *GenericContainerSpec) DeepCopyInto(out *GenericContainerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]EnvFromSource, len(*in))
+		copy(*out, *in)
+	}
 	if in.StorageMounts != nil {
 		in, out := &in.StorageMounts, &out.StorageMounts
 		*out = make([]StorageMount, len(*in))
@@ -399,6 +552,36 @@ func (in *GenericContainerSpec) DeepCopy() *GenericContainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageArchive) DeepCopyInto(out *ImageArchive) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Archives != nil {
+		in, out := &in.Archives, &out.Archives
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageArchive.
+func (in *ImageArchive) DeepCopy() *ImageArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageArchive) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImageMeta) DeepCopyInto(out *ImageMeta) {
 	*out = *in
@@ -626,7 +809,7 @@ func (in *KubernetesApply) DeepCopyInto(out *KubernetesApply) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Config = in.Config
+	in.Config.DeepCopyInto(&out.Config)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesApply.
@@ -711,6 +894,18 @@ func (in *PhaseConfig) DeepCopyInto(out *PhaseConfig) {
 		*out = new(v1.ObjectReference)
 		**out = **in
 	}
+	if in.Outputs != nil {
+		in, out := &in.Outputs, &out.Outputs
+		*out = make([]PhaseOutput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Inputs != nil {
+		in, out := &in.Inputs, &out.Inputs
+		*out = make([]PhaseInput, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseConfig.
@@ -723,6 +918,57 @@ func (in *PhaseConfig) DeepCopy() *PhaseConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseInput) DeepCopyInto(out *PhaseInput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseInput.
+func (in *PhaseInput) DeepCopy() *PhaseInput {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseOutput) DeepCopyInto(out *PhaseOutput) {
+	*out = *in
+	if in.Document != nil {
+		in, out := &in.Document, &out.Document
+		*out = new(PhaseOutputDocument)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseOutput.
+func (in *PhaseOutput) DeepCopy() *PhaseOutput {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseOutput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseOutputDocument) DeepCopyInto(out *PhaseOutputDocument) {
+	*out = *in
+	out.Selector = in.Selector
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseOutputDocument.
+func (in *PhaseOutputDocument) DeepCopy() *PhaseOutputDocument {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseOutputDocument)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PhasePlan) DeepCopyInto(out *PhasePlan) {
 	*out = *in
@@ -823,6 +1069,21 @@ func (in *ReplacementTransformer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SetBootDeviceOptions) DeepCopyInto(out *SetBootDeviceOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SetBootDeviceOptions.
+func (in *SetBootDeviceOptions) DeepCopy() *SetBootDeviceOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(SetBootDeviceOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageMount) DeepCopyInto(out *StorageMount) {
 	*out = *in
@@ -855,3 +1116,33 @@ func (in *Templater) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateFirmwareOptions) DeepCopyInto(out *UpdateFirmwareOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateFirmwareOptions.
+func (in *UpdateFirmwareOptions) DeepCopy() *UpdateFirmwareOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateFirmwareOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WaitForPowerStateOptions) DeepCopyInto(out *WaitForPowerStateOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WaitForPowerStateOptions.
+func (in *WaitForPowerStateOptions) DeepCopy() *WaitForPowerStateOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(WaitForPowerStateOptions)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -64,6 +64,9 @@ type BootstrapContainerOptions struct {
 	Cfg       *v1alpha1.BootConfiguration
 	Sleep     func(d time.Duration)
 
+	// ContainerName is a name of the bootstrap container, container runtime generates random name if it's empty
+	ContainerName string
+
 	// optional fields for verbose output
 	Debug bool
 }
@@ -197,7 +200,11 @@ func (options *BootstrapContainerOptions) CreateBootstrapContainer() error {
 		fmt.Sprintf("%s=%s", envBootstrapVolume, containerVolMount),
 	}
 
-	err := options.Container.RunCommand(container.RunCommandOptions{EnvVars: envVars, Binds: vols})
+	err := options.Container.RunCommand(container.RunCommandOptions{
+		Name:    options.ContainerName,
+		EnvVars: envVars,
+		Binds:   vols,
+	})
 	if err != nil {
 		return err
 	}
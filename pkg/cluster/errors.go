@@ -35,3 +35,12 @@ type ErrResourceNotFound struct {
 func (err ErrResourceNotFound) Error() string {
 	return fmt.Sprintf("could not find a status for resource %q", err.Resource)
 }
+
+// ErrInvalidInventoryOutputFormat is returned when unknown output format is requested for inventory command
+type ErrInvalidInventoryOutputFormat struct {
+	RequestedFormat string
+}
+
+func (err ErrInvalidInventoryOutputFormat) Error() string {
+	return fmt.Sprintf("invalid output format %q, supported formats are table and yaml", err.RequestedFormat)
+}
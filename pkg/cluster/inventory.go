@@ -0,0 +1,137 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"io"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	k8sapplier "opendev.org/airship/airshipctl/pkg/k8s/applier"
+	"opendev.org/airship/airshipctl/pkg/k8s/client"
+	"opendev.org/airship/airshipctl/pkg/util"
+	"opendev.org/airship/airshipctl/pkg/util/yaml"
+)
+
+const (
+	// InventoryOutputTable prints inventories as a table
+	InventoryOutputTable = "table"
+	// InventoryOutputYAML prints inventories as yaml
+	InventoryOutputYAML = "yaml"
+)
+
+// InventoryOptions holds options common for inventory commands
+type InventoryOptions struct {
+	Kubeconfig string
+	Namespace  string
+	Format     string
+
+	CfgFactory    config.Factory
+	ClientFactory client.Factory
+}
+
+// InventoryListCommand lists inventory objects of the phases applied to the cluster
+type InventoryListCommand struct {
+	Options InventoryOptions
+}
+
+// InventoryShowCommand shows objects owned by the inventory
+type InventoryShowCommand struct {
+	Options InventoryOptions
+	ID      string
+}
+
+// InventoryMigrateCommand moves inventory object to a new location
+type InventoryMigrateCommand struct {
+	Options InventoryOptions
+	ID      string
+	To      k8sapplier.Inventory
+}
+
+// RunE lists inventories in the cluster
+func (c *InventoryListCommand) RunE(w io.Writer) error {
+	invClient, err := c.Options.inventoryClient()
+	if err != nil {
+		return err
+	}
+	inventories, err := invClient.List(c.Options.Namespace)
+	if err != nil {
+		return err
+	}
+	switch c.Options.Format {
+	case InventoryOutputYAML:
+		return yaml.WriteOut(w, inventories)
+	case InventoryOutputTable, "":
+		tw := util.NewTabWriter(w)
+		fmt.Fprintf(tw, "NAMESPACE\tNAME\tID\tOBJECTS\n")
+		for _, inv := range inventories {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%d\n", inv.Namespace, inv.Name, inv.ID, len(inv.Objects))
+		}
+		return tw.Flush()
+	default:
+		return ErrInvalidInventoryOutputFormat{RequestedFormat: c.Options.Format}
+	}
+}
+
+// RunE prints objects owned by the inventory
+func (c *InventoryShowCommand) RunE(w io.Writer) error {
+	invClient, err := c.Options.inventoryClient()
+	if err != nil {
+		return err
+	}
+	inv, err := invClient.Get(c.ID, c.Options.Namespace)
+	if err != nil {
+		return err
+	}
+	switch c.Options.Format {
+	case InventoryOutputYAML:
+		return yaml.WriteOut(w, inv)
+	case InventoryOutputTable, "":
+		tw := util.NewTabWriter(w)
+		fmt.Fprintf(tw, "NAMESPACE\tNAME\tGROUP\tKIND\n")
+		for _, obj := range inv.Objects {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", obj.Namespace, obj.Name, obj.GroupKind.Group, obj.GroupKind.Kind)
+		}
+		return tw.Flush()
+	default:
+		return ErrInvalidInventoryOutputFormat{RequestedFormat: c.Options.Format}
+	}
+}
+
+// RunE moves inventory object to the new namespace and name
+func (c *InventoryMigrateCommand) RunE(w io.Writer) error {
+	invClient, err := c.Options.inventoryClient()
+	if err != nil {
+		return err
+	}
+	inv, err := invClient.Migrate(c.ID, c.Options.Namespace, c.To)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "Inventory %s is stored in %s/%s\n", inv.ID, inv.Namespace, inv.Name)
+	return err
+}
+
+func (o InventoryOptions) inventoryClient() (*k8sapplier.InventoryClient, error) {
+	airshipconfig, err := o.CfgFactory()
+	if err != nil {
+		return nil, err
+	}
+	kclient, err := o.ClientFactory(airshipconfig.LoadedConfigPath(), o.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return k8sapplier.NewInventoryClient(kclient.ClientSet()), nil
+}
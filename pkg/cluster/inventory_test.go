@@ -0,0 +1,126 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package cluster_test
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+
+	"opendev.org/airship/airshipctl/pkg/cluster"
+	"opendev.org/airship/airshipctl/pkg/config"
+	k8sapplier "opendev.org/airship/airshipctl/pkg/k8s/applier"
+	"opendev.org/airship/airshipctl/pkg/k8s/client"
+	"opendev.org/airship/airshipctl/pkg/k8s/client/fake"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+func inventoryOptions(t *testing.T, kclient client.Interface, format string) cluster.InventoryOptions {
+	return cluster.InventoryOptions{
+		Format: format,
+		CfgFactory: func() (*config.Config, error) {
+			cfg, _ := testutil.InitConfig(t)
+			return cfg, nil
+		},
+		ClientFactory: func(_ string, _ string) (client.Interface, error) {
+			return kclient, nil
+		},
+	}
+}
+
+func inventoryConfigMap(name, namespace, id string, data map[string]string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{clicommon.InventoryLabel: id},
+		},
+		Data: data,
+	}
+}
+
+func TestInventoryList(t *testing.T) {
+	kclient := fake.NewClient(fake.WithTypedObjects(
+		inventoryConfigMap("airshipit-initinfra-4bf1e4a", "airshipit", "initinfra", map[string]string{
+			"test_test-rc__ReplicationController": "",
+			"default_capi-system_apps_Deployment": "",
+		}),
+		inventoryConfigMap("airshipit-workers-1a2b3c4", "target-infra", "workers", map[string]string{}),
+	))
+	cmd := cluster.InventoryListCommand{Options: inventoryOptions(t, kclient, cluster.InventoryOutputTable)}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, cmd.RunE(buf))
+	space := regexp.MustCompile(`\s+`)
+	assert.Equal(t, "NAMESPACE NAME ID OBJECTS "+
+		"airshipit airshipit-initinfra-4bf1e4a initinfra 2 "+
+		"target-infra airshipit-workers-1a2b3c4 workers 0 ", space.ReplaceAllString(buf.String(), " "))
+
+	cmd.Options.Format = "json"
+	assert.Equal(t, cluster.ErrInvalidInventoryOutputFormat{RequestedFormat: "json"}, cmd.RunE(buf))
+}
+
+func TestInventoryShow(t *testing.T) {
+	kclient := fake.NewClient(fake.WithTypedObjects(
+		inventoryConfigMap("airshipit-initinfra-4bf1e4a", "airshipit", "initinfra", map[string]string{
+			"test_test-rc__ReplicationController": "",
+			"default_capi-system_apps_Deployment": "",
+		}),
+	))
+	cmd := cluster.InventoryShowCommand{
+		Options: inventoryOptions(t, kclient, cluster.InventoryOutputTable),
+		ID:      "initinfra",
+	}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, cmd.RunE(buf))
+	space := regexp.MustCompile(`\s+`)
+	assert.Equal(t, "NAMESPACE NAME GROUP KIND "+
+		"default capi-system apps Deployment "+
+		"test test-rc ReplicationController ", space.ReplaceAllString(buf.String(), " "))
+
+	cmd.ID = "workers"
+	assert.Equal(t, k8sapplier.ErrInventoryNotFound{ID: "workers"}, cmd.RunE(buf))
+}
+
+func TestInventoryMigrate(t *testing.T) {
+	data := map[string]string{"test_test-rc__ReplicationController": ""}
+	kclient := fake.NewClient(fake.WithTypedObjects(
+		inventoryConfigMap("airshipit-initinfra-4bf1e4a", "airshipit", "initinfra", data),
+	))
+	cmd := cluster.InventoryMigrateCommand{
+		Options: inventoryOptions(t, kclient, ""),
+		ID:      "initinfra",
+		To: k8sapplier.Inventory{
+			Namespace: "target-infra",
+			Name:      "initinfra-inventory",
+		},
+	}
+	buf := bytes.NewBuffer(nil)
+	require.NoError(t, cmd.RunE(buf))
+	assert.Equal(t, "Inventory initinfra is stored in target-infra/initinfra-inventory\n", buf.String())
+
+	cms, err := kclient.ClientSet().CoreV1().ConfigMaps("").List(metav1.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, cms.Items, 1)
+	assert.Equal(t, "target-infra", cms.Items[0].Namespace)
+	assert.Equal(t, "initinfra-inventory", cms.Items[0].Name)
+	assert.Equal(t, "initinfra", cms.Items[0].Labels[clicommon.InventoryLabel])
+	assert.Equal(t, data, cms.Items[0].Data)
+}
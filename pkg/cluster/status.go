@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -96,6 +97,7 @@ type StatusMap struct {
 	GkMapping  []schema.GroupKind
 	mapping    map[schema.GroupVersionResource]map[status.Status]Expression
 	restMapper *meta.DefaultRESTMapper
+	versions   map[schema.GroupKind][]string
 }
 
 // NewStatusMap creates a cluster-wide StatusMap. It iterates over all
@@ -108,6 +110,7 @@ func NewStatusMap(client client.Interface) (*StatusMap, error) {
 		client:     client,
 		mapping:    make(map[schema.GroupVersionResource]map[status.Status]Expression),
 		restMapper: meta.NewDefaultRESTMapper([]schema.GroupVersion{}),
+		versions:   make(map[schema.GroupKind][]string),
 	}
 	client.ApiextensionsClientSet()
 	crds, err := statusMap.client.ApiextensionsClientSet().
@@ -130,7 +133,7 @@ func NewStatusMap(client client.Interface) (*StatusMap, error) {
 // ReadStatus returns object status
 func (sm *StatusMap) ReadStatus(ctx context.Context, resource object.ObjMetadata) *event.ResourceStatus {
 	gk := resource.GroupKind
-	gvr, err := sm.restMapper.RESTMapping(gk, "v1")
+	gvr, err := sm.restMapper.RESTMapping(gk, sm.versions[gk]...)
 	if err != nil {
 		return handleResourceStatusError(resource, err)
 	}
@@ -251,16 +254,42 @@ func (sm *StatusMap) addCRD(crd apiextensions.CustomResourceDefinition) error {
 
 	gvrs := getGVRs(crd)
 	for _, gvr := range gvrs {
-		sm.GkMapping = append(sm.GkMapping, crd.GroupVersionKind().GroupKind())
 		gvk := gvr.GroupVersion().WithKind(crd.Spec.Names.Kind)
 		gvrSingular := gvr.GroupVersion().WithResource(crd.Spec.Names.Singular)
-		sm.mapping[gvr] = statusChecks
-		sm.restMapper.AddSpecific(gvk, gvr, gvrSingular, meta.RESTScopeNamespace)
+		sm.addMapping(gvk, gvr, gvrSingular, meta.RESTScopeNamespace, statusChecks)
 	}
 
 	return nil
 }
 
+// AddStatusChecks adds status expressions for the resource described by the REST mapping,
+// expressions already known for the resource are replaced. This allows to check status of
+// the resources whose definitions are not annotated with airshipit.org/status-check
+func (sm *StatusMap) AddStatusChecks(mapping *meta.RESTMapping, statusChecks map[status.Status]Expression) {
+	gvrSingular := mapping.Resource.GroupVersion().WithResource(strings.ToLower(mapping.GroupVersionKind.Kind))
+	sm.addMapping(mapping.GroupVersionKind, mapping.Resource, gvrSingular, mapping.Scope, statusChecks)
+}
+
+// addMapping registers status expressions for the resource, the version added last is preferred
+// when status is read by group and kind only
+func (sm *StatusMap) addMapping(gvk schema.GroupVersionKind, gvr, gvrSingular schema.GroupVersionResource,
+	scope meta.RESTScope, statusChecks map[status.Status]Expression) {
+	gk := gvk.GroupKind()
+	known, ok := sm.versions[gk]
+	if !ok {
+		sm.GkMapping = append(sm.GkMapping, gk)
+	}
+	versions := []string{gvk.Version}
+	for _, version := range known {
+		if version != gvk.Version {
+			versions = append(versions, version)
+		}
+	}
+	sm.versions[gk] = versions
+	sm.mapping[gvr] = statusChecks
+	sm.restMapper.AddSpecific(gvk, gvr, gvrSingular, scope)
+}
+
 // getGVRs constructs a slice of schema.GroupVersionResource for
 // CustomResources defined by the CustomResourceDefinition.
 func getGVRs(crd apiextensions.CustomResourceDefinition) []schema.GroupVersionResource {
@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -224,6 +225,43 @@ func TestReadStatus(t *testing.T) {
 	assert.Equal(t, "Pending", result.Status.String())
 }
 
+func TestAddStatusChecks(t *testing.T) {
+	deployment := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "test-deployment",
+				"namespace": "default",
+			},
+			"status": map[string]interface{}{
+				"readyReplicas": int64(3),
+			},
+		},
+	}
+	c := fake.NewClient(fake.WithCRDs(makeResourceCRD(annotationValidStatusCheck())),
+		fake.WithDynamicObjects(deployment))
+	statusMap, err := cluster.NewStatusMap(c)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.GroupKind{{Group: "example.com", Kind: "Resource"}}, statusMap.GkMapping)
+
+	gvk := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	statusMap.AddStatusChecks(&meta.RESTMapping{
+		Resource:         schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		GroupVersionKind: gvk,
+		Scope:            meta.RESTScopeNamespace,
+	}, map[status.Status]cluster.Expression{
+		status.CurrentStatus:    {Condition: "@.status.readyReplicas==3"},
+		status.InProgressStatus: {Condition: "@.status.readyReplicas<3"},
+	})
+	assert.Contains(t, statusMap.GkMapping, gvk.GroupKind())
+
+	resource := object.ObjMetadata{Namespace: "default", Name: "test-deployment", GroupKind: gvk.GroupKind()}
+	result := statusMap.ReadStatus(context.Background(), resource)
+	require.NoError(t, result.Error)
+	assert.Equal(t, status.CurrentStatus, result.Status)
+}
+
 func makeResource(name, state string) *unstructured.Unstructured {
 	return &unstructured.Unstructured{
 		Object: map[string]interface{}{
@@ -18,8 +18,11 @@ import (
 	"fmt"
 	"strings"
 
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	redfishhpe "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	redfishsupermicro "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
 )
 
 // ErrIncompatibleAuthOptions is returned when incompatible
@@ -236,8 +239,9 @@ type ErrUnknownManagementType struct {
 }
 
 func (e ErrUnknownManagementType) Error() string {
-	return fmt.Sprintf("Unknown management type '%s'. Known types include '%s' and '%s'.", e.Type,
-		redfish.ClientType, redfishdell.ClientType)
+	return fmt.Sprintf("Unknown management type '%s'. Known types include '%s', '%s', '%s', '%s' and '%s'.",
+		e.Type, redfish.ClientType, redfishdell.ClientType, redfishhpe.ClientType, redfishsupermicro.ClientType,
+		ipmi.ClientType)
 }
 
 // ErrMissingManifestName is returned when manifest name is empty
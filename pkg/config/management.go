@@ -17,8 +17,11 @@ package config
 import (
 	"sigs.k8s.io/yaml"
 
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	redfishhpe "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	redfishsupermicro "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
 )
 
 const (
@@ -76,6 +79,12 @@ func (m *ManagementConfiguration) Validate() error {
 		m.Type = redfish.ClientType
 	case redfishdell.ClientType:
 		m.Type = redfishdell.ClientType
+	case redfishhpe.ClientType:
+		m.Type = redfishhpe.ClientType
+	case redfishsupermicro.ClientType:
+		m.Type = redfishsupermicro.ClientType
+	case ipmi.ClientType:
+		m.Type = ipmi.ClientType
 	default:
 		return ErrUnknownManagementType{Type: m.Type}
 	}
@@ -21,7 +21,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	redfishhpe "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	redfishsupermicro "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
 )
 
 func TestNewManagementConfiguration(t *testing.T) {
@@ -61,6 +64,30 @@ func TestValidateRedfishDell(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestValidateRedfishHPE(t *testing.T) {
+	cfg := config.NewManagementConfiguration()
+	cfg.Type = redfishhpe.ClientType
+
+	err := cfg.Validate()
+	assert.NoError(t, err)
+}
+
+func TestValidateRedfishSupermicro(t *testing.T) {
+	cfg := config.NewManagementConfiguration()
+	cfg.Type = redfishsupermicro.ClientType
+
+	err := cfg.Validate()
+	assert.NoError(t, err)
+}
+
+func TestValidateIPMI(t *testing.T) {
+	cfg := config.NewManagementConfiguration()
+	cfg.Type = ipmi.ClientType
+
+	err := cfg.Validate()
+	assert.NoError(t, err)
+}
+
 func TestValidateInvalidManagementType(t *testing.T) {
 	cfg := config.NewManagementConfiguration()
 	cfg.Type = "invalid"
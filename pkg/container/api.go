@@ -19,13 +19,17 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	// TODO this small library needs to be moved to airshipctl and extended
 	// with splitting streams into Stderr and Stdout
 	"github.com/ahmetb/dlog"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"sigs.k8s.io/kustomize/kyaml/fn/runtime/runtimeutil"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/runfn"
@@ -37,6 +41,9 @@ import (
 	"opendev.org/airship/airshipctl/pkg/util"
 )
 
+// timeoutLogLines is a number of the last container log lines included into timeout error
+const timeoutLogLines = 20
+
 // ClientV1Alpha1 provides airship generic container API
 // TODO add generic mock for this client
 type ClientV1Alpha1 interface {
@@ -61,7 +68,8 @@ type clientV1Alpha1 struct {
 	containerFunc containerFunc
 }
 
-type containerFunc func(ctx context.Context, driver string, url string) (Container, error)
+type containerFunc func(ctx context.Context, driver string, url string,
+	pullPolicy v1alpha1.ImagePullPolicy) (Container, error)
 
 // NewClientV1Alpha1 constructor for ClientV1Alpha1
 func NewClientV1Alpha1(
@@ -100,15 +108,21 @@ func (c *clientV1Alpha1) runAirship() error {
 		c.conf.Spec.Airship.ContainerRuntime = ContainerDriverDocker
 	}
 
+	resources, err := containerResources(c.conf.Spec.Airship.Resources)
+	if err != nil {
+		return err
+	}
+
 	var cont Container
 	if c.containerFunc == nil {
 		c.containerFunc = NewContainer
 	}
 
-	cont, err := c.containerFunc(
+	cont, err = c.containerFunc(
 		context.Background(),
 		c.conf.Spec.Airship.ContainerRuntime,
-		c.conf.Spec.Image)
+		c.conf.Spec.Image,
+		c.conf.Spec.ImagePullPolicy)
 	if err != nil {
 		return err
 	}
@@ -150,14 +164,28 @@ func (c *clientV1Alpha1) runAirship() error {
 	log.Printf("Starting container with image: '%s', cmd: '%s'",
 		c.conf.Spec.Image,
 		c.conf.Spec.Airship.Cmd)
-	err = cont.RunCommand(RunCommandOptions{
+	return c.runContainer(cont, RunCommandOptions{
 		Privileged:  c.conf.Spec.Airship.Privileged,
 		Cmd:         c.conf.Spec.Airship.Cmd,
 		Mounts:      convertDockerMount(c.conf.Spec.StorageMounts),
 		EnvVars:     envs,
 		Input:       decoratedInput,
 		HostNetwork: c.conf.Spec.HostNetwork,
+		User:        c.conf.Spec.Airship.User,
+		WorkingDir:  c.conf.Spec.Airship.WorkingDir,
+		Resources:   resources,
 	})
+}
+
+// runContainer runs the command in the container and writes its output to the sink, the container is
+// removed when it's finished unless it has failed and is kept for troubleshooting
+func (c *clientV1Alpha1) runContainer(cont Container, opts RunCommandOptions) (err error) {
+	err = cont.RunCommand(opts)
+	if cont.GetID() != "" {
+		defer func() {
+			c.removeContainer(cont, err)
+		}()
+	}
 	if err != nil {
 		return err
 	}
@@ -172,7 +200,7 @@ func (c *clientV1Alpha1) runAirship() error {
 		cErr <- writeLogs(cont)
 	}()
 
-	err = cont.WaitUntilFinished()
+	err = c.waitContainer(cont)
 	if err != nil {
 		<-cErr
 		return err
@@ -194,43 +222,229 @@ func (c *clientV1Alpha1) runAirship() error {
 	return writeSink(c.resultsDir, parsedOut, c.output)
 }
 
+// waitContainer waits until the container is finished, if timeout is specified and the container
+// doesn't finish in time, it is killed and its logs are returned as a part of the error
+func (c *clientV1Alpha1) waitContainer(cont Container) error {
+	timeout := time.Duration(c.conf.Spec.Airship.Timeout) * time.Second
+	if timeout <= 0 {
+		return cont.WaitUntilFinished()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cont.WaitUntilFinished()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+	}
+
+	log.Printf("Container %s is not finished in %v, killing it", cont.GetID(), timeout)
+	if err := cont.KillContainer(); err != nil {
+		return err
+	}
+	return ErrContainerTimeout{
+		ID:      cont.GetID(),
+		Timeout: timeout,
+		Logs:    containerLogsTail(cont, timeoutLogLines),
+	}
+}
+
+// removeContainer removes finished container, failed container is kept if keepOnFailure is set
+func (c *clientV1Alpha1) removeContainer(cont Container, runErr error) {
+	if runErr != nil && c.conf.Spec.Airship.KeepOnFailure {
+		log.Printf("Container %s is kept for troubleshooting", cont.GetID())
+		return
+	}
+	if err := cont.RmContainer(); err != nil {
+		log.Printf("Failed to remove container %s: %v", cont.GetID(), err)
+	}
+}
+
+// containerResources converts resource limits of the container spec to runtime options
+func containerResources(res *v1alpha1.ContainerResources) (Resources, error) {
+	result := Resources{}
+	if res == nil {
+		return result, nil
+	}
+	if res.Memory != "" {
+		q, err := resource.ParseQuantity(res.Memory)
+		if err != nil {
+			return Resources{}, ErrInvalidResourceLimit{Resource: "memory", Value: res.Memory, Err: err}
+		}
+		result.Memory = q.Value()
+	}
+	if res.CPU != "" {
+		q, err := resource.ParseQuantity(res.CPU)
+		if err != nil {
+			return Resources{}, ErrInvalidResourceLimit{Resource: "cpu", Value: res.CPU, Err: err}
+		}
+		// one milli CPU is 10^6 nano CPUs
+		result.NanoCPUs = q.MilliValue() * 1000000
+	}
+	return result, nil
+}
+
+// containerLogsTail returns last lines of stderr of the container, stdout isn't included
+// since it carries the documents produced by the container, which may contain secrets
+func containerLogsTail(cont Container, lines int) string {
+	rc, err := cont.GetContainerLogs(GetLogOptions{Stderr: true})
+	if err != nil {
+		log.Debugf("Failed to get logs of container %s: %v", cont.GetID(), err)
+		return ""
+	}
+	defer rc.Close()
+	out, err := ioutil.ReadAll(dlog.NewReader(rc))
+	if err != nil {
+		log.Debugf("Failed to read logs of container %s: %v", cont.GetID(), err)
+	}
+	logLines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	if len(logLines) > lines {
+		logLines = logLines[len(logLines)-lines:]
+	}
+	return strings.Join(logLines, "\n")
+}
+
 func (c *clientV1Alpha1) runKRM() error {
+	fns, err := c.krmRunFns()
+	if err != nil {
+		return err
+	}
+	if c.conf.Spec.KRM.WorkingDir != "" {
+		// kyaml starts exec functions in working directory of airshipctl process,
+		// so the function is run by the filter setting working directory of the child process only
+		return c.runKRMExec(fns)
+	}
+	return fns.Execute()
+}
+
+// krmRunFns maps GenericContainer spec onto kyaml runfn.RunFns
+func (c *clientV1Alpha1) krmRunFns() (*runfn.RunFns, error) {
+	krm := c.conf.Spec.KRM
+	if err := validateKRMSpec(c.conf.Spec); err != nil {
+		return nil, err
+	}
 	mounts := convertKRMMount(c.conf.Spec.StorageMounts)
 	fns := &runfn.RunFns{
 		Network:               c.conf.Spec.HostNetwork,
-		AsCurrentUser:         true,
+		AsCurrentUser:         krm.User != v1alpha1.KRMUserNobody,
 		Path:                  c.resultsDir,
 		Input:                 c.input,
 		Output:                c.output,
 		StorageMounts:         mounts,
-		ContinueOnEmptyResult: true,
+		ContinueOnEmptyResult: !krm.ErrorOnEmptyResult,
+	}
+	if krm.ResultsDir != "" {
+		fns.ResultsDir = c.expandPath(krm.ResultsDir)
 	}
 	function, err := kyaml.Parse(c.conf.Config)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Transform GenericContainer.Spec to annotation,
 	// because we need to specify runFns config in annotation
-	spec, err := yaml.Marshal(runtimeutil.FunctionSpec{
-		Container: runtimeutil.ContainerSpec{
+	fnSpec := runtimeutil.FunctionSpec{}
+	if krm.ExecPath != "" {
+		fns.EnableExec = true
+		fnSpec.Exec = runtimeutil.ExecSpec{Path: c.expandPath(krm.ExecPath)}
+	} else {
+		fnSpec.Container = runtimeutil.ContainerSpec{
 			Image:         c.conf.Spec.Image,
 			Network:       c.conf.Spec.HostNetwork,
 			Env:           c.conf.Spec.EnvVars,
 			StorageMounts: mounts,
-		},
-	})
+		}
+	}
+	spec, err := yaml.Marshal(fnSpec)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	annotation := kyaml.SetAnnotation(runtimeutil.FunctionAnnotationKey, string(spec))
 	_, err = annotation.Filter(function)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	fns.Functions = []*kyaml.RNode{function}
+	return fns, nil
+}
 
-	return fns.Execute()
+// runKRMExec runs exec function of fns in the working directory set in the spec, reading and writing
+// resources the same way runfn does
+func (c *clientV1Alpha1) runKRMExec(fns *runfn.RunFns) error {
+	filter := &execFilter{
+		path: c.expandPath(c.conf.Spec.KRM.ExecPath),
+		dir:  c.expandPath(c.conf.Spec.KRM.WorkingDir),
+	}
+	filter.FunctionConfig = fns.Functions[0]
+	if fns.ResultsDir != "" {
+		filter.ResultsFile = filepath.Join(fns.ResultsDir, "results-0.yaml")
+	}
+
+	pkg := &kio.LocalPackageReadWriter{PackagePath: fns.Path}
+	inputs := []kio.Reader{pkg}
+	if fns.Input != nil {
+		inputs = []kio.Reader{&kio.ByteReader{Reader: fns.Input}}
+	}
+	outputs := []kio.Writer{pkg}
+	if fns.Output != nil {
+		outputs = []kio.Writer{kio.ByteWriter{Writer: fns.Output}}
+	}
+	return kio.Pipeline{
+		Inputs:                inputs,
+		Filters:               []kio.Filter{filter},
+		Outputs:               outputs,
+		ContinueOnEmptyResult: fns.ContinueOnEmptyResult,
+	}.Execute()
+}
+
+// execFilter runs exec function in the given directory
+type execFilter struct {
+	path string
+	dir  string
+	runtimeutil.FunctionFilter
+}
+
+// Filter implements kio.Filter
+func (f *execFilter) Filter(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+	f.FunctionFilter.Run = f.run
+	return f.FunctionFilter.Filter(nodes)
+}
+
+func (f *execFilter) run(reader io.Reader, writer io.Writer) error {
+	cmd := exec.Command(f.path) //nolint:gosec
+	cmd.Dir = f.dir
+	cmd.Stdin = reader
+	cmd.Stdout = writer
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// expandPath converts relative path into absolute one using site root
+func (c *clientV1Alpha1) expandPath(path string) string {
+	path = util.ExpandTilde(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(c.targetPath, path)
+	}
+	return path
+}
+
+func validateKRMSpec(spec v1alpha1.GenericContainerSpec) error {
+	krm := spec.KRM
+	switch krm.User {
+	case "", v1alpha1.KRMUserCurrent, v1alpha1.KRMUserNobody:
+	default:
+		return ErrInvalidKRMSpec{Reason: fmt.Sprintf("user %q is not supported", krm.User)}
+	}
+	if krm.ExecPath != "" && spec.Image != "" {
+		return ErrInvalidKRMSpec{Reason: "image and execPath are mutually exclusive"}
+	}
+	if krm.ExecPath == "" && krm.WorkingDir != "" {
+		return ErrInvalidKRMSpec{Reason: "workingDir is supported only for exec functions"}
+	}
+	return nil
 }
 
 func writeLogs(cont Container) error {
@@ -20,13 +20,19 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/kustomize/kyaml/fn/runtime/runtimeutil"
+	"sigs.k8s.io/kustomize/kyaml/runfn"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/document"
@@ -115,7 +121,7 @@ func TestGenericContainer(t *testing.T) {
 				Config: `kind: ConfigMap`,
 			},
 			expectedErr: "no such file or directory",
-			execFunc: func(ctx context.Context, driver, url string) (Container, error) {
+			execFunc: func(context.Context, string, string, v1alpha1.ImagePullPolicy) (Container, error) {
 				return getDockerContainerMock(mockDockerClient{
 					containerAttach: func() (types.HijackedResponse, error) {
 						conn := types.HijackedResponse{
@@ -158,7 +164,7 @@ func TestGenericContainer(t *testing.T) {
 				},
 				Config: `kind: ConfigMap`,
 			},
-			execFunc: func(ctx context.Context, driver, url string) (Container, error) {
+			execFunc: func(context.Context, string, string, v1alpha1.ImagePullPolicy) (Container, error) {
 				return getDockerContainerMock(mockDockerClient{
 					containerAttach: func() (types.HijackedResponse, error) {
 						conn := types.HijackedResponse{
@@ -203,7 +209,7 @@ func TestGenericContainer(t *testing.T) {
 				},
 				Config: `kind: ConfigMap`,
 			},
-			execFunc: func(ctx context.Context, driver, url string) (Container, error) {
+			execFunc: func(context.Context, string, string, v1alpha1.ImagePullPolicy) (Container, error) {
 				return getDockerContainerMock(mockDockerClient{
 					containerAttach: func() (types.HijackedResponse, error) {
 						conn := types.HijackedResponse{
@@ -240,7 +246,7 @@ func TestGenericContainer(t *testing.T) {
 				},
 				Config: `kind: ConfigMap`,
 			},
-			execFunc: func(ctx context.Context, driver, url string) (Container, error) {
+			execFunc: func(context.Context, string, string, v1alpha1.ImagePullPolicy) (Container, error) {
 				return getDockerContainerMock(mockDockerClient{
 					containerAttach: func() (types.HijackedResponse, error) {
 						conn := types.HijackedResponse{
@@ -286,6 +292,268 @@ func TestGenericContainer(t *testing.T) {
 	}
 }
 
+func TestKRMRunFns(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        v1alpha1.GenericContainerSpec
+		expectedErr error
+		check       func(t *testing.T, fns *runfn.RunFns)
+	}{
+		{
+			name: "container function defaults",
+			spec: v1alpha1.GenericContainerSpec{
+				Type:  v1alpha1.GenericContainerTypeKrm,
+				Image: "quay.io/test/function:latest",
+			},
+			check: func(t *testing.T, fns *runfn.RunFns) {
+				assert.True(t, fns.AsCurrentUser)
+				assert.True(t, fns.ContinueOnEmptyResult)
+				assert.False(t, fns.EnableExec)
+				assert.Empty(t, fns.ResultsDir)
+				fnSpec := runtimeutil.GetFunctionSpec(fns.Functions[0])
+				require.NotNil(t, fnSpec)
+				assert.Equal(t, "quay.io/test/function:latest", fnSpec.Container.Image)
+			},
+		},
+		{
+			name: "exec function",
+			spec: v1alpha1.GenericContainerSpec{
+				Type: v1alpha1.GenericContainerTypeKrm,
+				KRM: v1alpha1.KRMContainerSpec{
+					ExecPath:           "functions/replacement",
+					ResultsDir:         "results",
+					User:               v1alpha1.KRMUserNobody,
+					WorkingDir:         "functions",
+					ErrorOnEmptyResult: true,
+				},
+			},
+			check: func(t *testing.T, fns *runfn.RunFns) {
+				assert.False(t, fns.AsCurrentUser)
+				assert.False(t, fns.ContinueOnEmptyResult)
+				assert.True(t, fns.EnableExec)
+				assert.Equal(t, "/target-path/results", fns.ResultsDir)
+				fnSpec := runtimeutil.GetFunctionSpec(fns.Functions[0])
+				require.NotNil(t, fnSpec)
+				assert.Equal(t, "/target-path/functions/replacement", fnSpec.Exec.Path)
+				assert.Empty(t, fnSpec.Container.Image)
+			},
+		},
+		{
+			name: "error image and exec path",
+			spec: v1alpha1.GenericContainerSpec{
+				Type:  v1alpha1.GenericContainerTypeKrm,
+				Image: "quay.io/test/function:latest",
+				KRM:   v1alpha1.KRMContainerSpec{ExecPath: "/bin/function"},
+			},
+			expectedErr: ErrInvalidKRMSpec{Reason: "image and execPath are mutually exclusive"},
+		},
+		{
+			name: "error working dir for container function",
+			spec: v1alpha1.GenericContainerSpec{
+				Type:  v1alpha1.GenericContainerTypeKrm,
+				Image: "quay.io/test/function:latest",
+				KRM:   v1alpha1.KRMContainerSpec{WorkingDir: "functions"},
+			},
+			expectedErr: ErrInvalidKRMSpec{Reason: "workingDir is supported only for exec functions"},
+		},
+		{
+			name: "error unknown user",
+			spec: v1alpha1.GenericContainerSpec{
+				Type:  v1alpha1.GenericContainerTypeKrm,
+				Image: "quay.io/test/function:latest",
+				KRM:   v1alpha1.KRMContainerSpec{User: "root"},
+			},
+			expectedErr: ErrInvalidKRMSpec{Reason: `user "root" is not supported`},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c := &clientV1Alpha1{
+				conf:       &v1alpha1.GenericContainer{Spec: tt.spec, Config: `kind: ConfigMap`},
+				targetPath: "/target-path",
+			}
+			fns, err := c.krmRunFns()
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, fns)
+		})
+	}
+}
+
+func TestExecFilter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "airshipctl-krm-dir-'")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+
+	pwd, err := exec.LookPath("pwd")
+	require.NoError(t, err)
+	out := &bytes.Buffer{}
+	f := &execFilter{path: pwd, dir: dir}
+	require.NoError(t, f.run(strings.NewReader(""), out))
+	expected, err := filepath.EvalSymlinks(dir)
+	require.NoError(t, err)
+	assert.Equal(t, expected, strings.TrimSpace(out.String()))
+
+	actualCwd, err := os.Getwd()
+	require.NoError(t, err)
+	assert.Equal(t, cwd, actualCwd)
+}
+
+func TestContainerResources(t *testing.T) {
+	tests := []struct {
+		name        string
+		resources   *v1alpha1.ContainerResources
+		expected    Resources
+		expectedErr string
+	}{
+		{
+			name: "no limits",
+		},
+		{
+			name: "memory and cpu limits",
+			resources: &v1alpha1.ContainerResources{
+				Memory: "512Mi",
+				CPU:    "1500m",
+			},
+			expected: Resources{
+				Memory:   512 * 1024 * 1024,
+				NanoCPUs: 1500000000,
+			},
+		},
+		{
+			name:        "invalid memory limit",
+			resources:   &v1alpha1.ContainerResources{Memory: "a lot"},
+			expectedErr: "invalid memory limit 'a lot'",
+		},
+		{
+			name:        "invalid cpu limit",
+			resources:   &v1alpha1.ContainerResources{CPU: "two"},
+			expectedErr: "invalid cpu limit 'two'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := containerResources(tt.resources)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, res)
+		})
+	}
+}
+
+func TestAirshipContainerRemove(t *testing.T) {
+	tests := []struct {
+		name          string
+		keepOnFailure bool
+		exitCode      int64
+		timeout       bool
+		expectedErr   error
+		expectRemoved bool
+	}{
+		{
+			name:          "finished container is removed",
+			expectRemoved: true,
+		},
+		{
+			name:          "finished container is removed with keep on failure",
+			keepOnFailure: true,
+			expectRemoved: true,
+		},
+		{
+			name:          "failed container is removed",
+			exitCode:      1,
+			expectedErr:   ErrRunContainerCommand{Cmd: "docker logs testID"},
+			expectRemoved: true,
+		},
+		{
+			name:          "failed container is kept",
+			keepOnFailure: true,
+			exitCode:      1,
+			expectedErr:   ErrRunContainerCommand{Cmd: "docker logs testID"},
+		},
+		{
+			name:          "timed out container is removed",
+			timeout:       true,
+			expectedErr:   ErrContainerTimeout{ID: "testID", Timeout: time.Second},
+			expectRemoved: true,
+		},
+		{
+			name:          "timed out container is kept",
+			keepOnFailure: true,
+			timeout:       true,
+			expectedErr:   ErrContainerTimeout{ID: "testID", Timeout: time.Second},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			killed, removed := false, false
+			client := &clientV1Alpha1{
+				input:  bundlePathToInput(t, "testdata/single"),
+				output: ioutil.Discard,
+				conf: &v1alpha1.GenericContainer{
+					Spec: v1alpha1.GenericContainerSpec{
+						Type:  v1alpha1.GenericContainerTypeAirship,
+						Image: "some image",
+						Airship: v1alpha1.AirshipContainerSpec{
+							Cmd:           []string{"testCmd"},
+							Timeout:       1,
+							KeepOnFailure: tt.keepOnFailure,
+						},
+					},
+					Config: `kind: ConfigMap`,
+				},
+				containerFunc: func(context.Context, string, string, v1alpha1.ImagePullPolicy) (Container, error) {
+					return getDockerContainerMock(mockDockerClient{
+						containerAttach: func() (types.HijackedResponse, error) {
+							return types.HijackedResponse{Conn: mockConn{WData: make([]byte, 0)}}, nil
+						},
+						containerWait: func() (<-chan container.ContainerWaitOKBody, <-chan error) {
+							if tt.timeout {
+								// container never finishes
+								return make(chan container.ContainerWaitOKBody), make(chan error)
+							}
+							resC := make(chan container.ContainerWaitOKBody, 1)
+							resC <- container.ContainerWaitOKBody{StatusCode: tt.exitCode}
+							return resC, make(chan error)
+						},
+						containerLogs: func() (io.ReadCloser, error) {
+							return ioutil.NopCloser(strings.NewReader("")), nil
+						},
+						containerKill: func() error {
+							killed = true
+							return nil
+						},
+						containerRemove: func() error {
+							removed = true
+							return nil
+						},
+					}), nil
+				},
+			}
+
+			err := client.Run()
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.timeout, killed)
+			assert.Equal(t, tt.expectRemoved, removed)
+		})
+	}
+}
+
 // Dummy test to keep up with coverage.
 func TestNewClientV1alpha1(t *testing.T) {
 	client := NewClientV1Alpha1("", nil, nil, v1alpha1.DefaultGenericContainer(), "")
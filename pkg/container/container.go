@@ -17,11 +17,15 @@ package container
 import (
 	"context"
 	"io"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 )
 
 const (
 	// ContainerDriverDocker indicates that docker driver should be used in container constructor
 	ContainerDriverDocker = "docker"
+	// ContainerDriverPodman indicates that podman driver should be used in container constructor
+	ContainerDriverPodman = "podman"
 )
 
 // Status type provides container status
@@ -44,12 +48,16 @@ type Container interface {
 	GetContainerLogs(GetLogOptions) (io.ReadCloser, error)
 	InspectContainer() (State, error)
 	WaitUntilFinished() error
+	KillContainer() error
 	RmContainer() error
 	GetID() string
 }
 
 // RunCommandOptions options for RunCommand
 type RunCommandOptions struct {
+	// Name of the container, container runtime generates random name if it's empty
+	Name string
+
 	Privileged  bool
 	HostNetwork bool
 
@@ -59,6 +67,18 @@ type RunCommandOptions struct {
 
 	Mounts []Mount
 	Input  io.Reader
+
+	User       string
+	WorkingDir string
+	Resources  Resources
+}
+
+// Resources describes compute resource limits of the container, zero value means no limit
+type Resources struct {
+	// Memory limit in bytes
+	Memory int64
+	// NanoCPUs CPU limit in units of 10^-9 CPUs
+	NanoCPUs int64
 }
 
 // Mount describes mount settings
@@ -79,19 +99,45 @@ type GetLogOptions struct {
 
 // NewContainer returns instance of Container interface implemented by particular driver
 // Returned instance type (i.e. implementation) depends on driver specified via function
-// arguments (e.g. "docker").
+// arguments (e.g. "docker"). Container image is pulled according to the pull policy.
 // Supported drivers:
 //   * docker
-func NewContainer(ctx context.Context, driver string, url string) (Container, error) {
+//   * podman
+func NewContainer(ctx context.Context, driver string, url string,
+	pullPolicy v1alpha1.ImagePullPolicy) (Container, error) {
+	cli, err := NewRuntimeClient(ctx, driver)
+	if err != nil {
+		return nil, err
+	}
+	if driver == ContainerDriverPodman {
+		return NewPodmanContainer(ctx, url, cli, pullPolicy)
+	}
+	return NewDockerContainer(ctx, url, cli, pullPolicy)
+}
+
+// GetContainer returns instance of Container interface for the existing container with the given ID or name,
+// so that the container created by another airshipctl process can be inspected or removed
+func GetContainer(ctx context.Context, driver string, id string) (Container, error) {
+	cli, err := NewRuntimeClient(ctx, driver)
+	if err != nil {
+		return nil, err
+	}
+	cnt := &DockerContainer{id: id, dockerClient: cli, ctx: ctx}
+	if driver == ContainerDriverPodman {
+		return &PodmanContainer{DockerContainer: cnt}, nil
+	}
+	return cnt, nil
+}
+
+// NewRuntimeClient returns docker API client connected to the container runtime of the driver
+func NewRuntimeClient(ctx context.Context, driver string) (DockerClient, error) {
 	switch driver {
 	case "":
 		return nil, ErrNoContainerDriver{}
 	case ContainerDriverDocker:
-		cli, err := NewDockerClient(ctx)
-		if err != nil {
-			return nil, err
-		}
-		return NewDockerContainer(ctx, url, cli)
+		return NewDockerClient(ctx)
+	case ContainerDriverPodman:
+		return NewPodmanClient(ctx, "")
 	default:
 		return nil, ErrContainerDrvNotSupported{Driver: driver}
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/docker/docker/client"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
 
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/log"
 )
 
@@ -84,12 +85,24 @@ type DockerClient interface {
 		string,
 		types.ContainerLogsOptions,
 	) (io.ReadCloser, error)
+	// ContainerKill terminates the container process but does not remove the container from the docker host.
+	ContainerKill(
+		context.Context,
+		string,
+		string,
+	) error
 	// ContainerRemove kills and removes a container from the docker host.
 	ContainerRemove(
 		context.Context,
 		string,
 		types.ContainerRemoveOptions,
 	) error
+	// ImageLoad loads an image in the docker host from the client host.
+	ImageLoad(
+		context.Context,
+		io.Reader,
+		bool,
+	) (types.ImageLoadResponse, error)
 	// ContainerInspect returns the container state
 	ContainerInspect(
 		ctx context.Context,
@@ -102,6 +115,7 @@ type DockerContainer struct {
 	tag          string
 	imageURL     string
 	id           string
+	pullPolicy   v1alpha1.ImagePullPolicy
 	dockerClient DockerClient
 	ctx          context.Context
 }
@@ -118,12 +132,13 @@ func NewDockerClient(ctx context.Context) (DockerClient, error) {
 }
 
 // NewDockerContainer returns instance of DockerContainer object wrapper.
-// Function gets container image url, pointer to execution context and
-// DockerClient instance.
+// Function gets container image url, pointer to execution context,
+// DockerClient instance and image pull policy.
 //
 // url format: <image_path>:<tag>. If tag is not specified "latest" is used
 // as default value
-func NewDockerContainer(ctx context.Context, url string, cli DockerClient) (*DockerContainer, error) {
+func NewDockerContainer(ctx context.Context, url string, cli DockerClient,
+	pullPolicy v1alpha1.ImagePullPolicy) (*DockerContainer, error) {
 	t := "latest"
 	nameTag := strings.Split(url, ":")
 	if len(nameTag) == 2 {
@@ -134,6 +149,7 @@ func NewDockerContainer(ctx context.Context, url string, cli DockerClient) (*Doc
 		tag:          t,
 		imageURL:     url,
 		id:           "",
+		pullPolicy:   pullPolicy,
 		dockerClient: cli,
 		ctx:          ctx,
 	}
@@ -198,11 +214,17 @@ func (c *DockerContainer) getConfig(opts RunCommandOptions) (container.Config, c
 		AttachStderr: true,
 		AttachStdout: true,
 		Env:          opts.EnvVars,
+		User:         opts.User,
+		WorkingDir:   opts.WorkingDir,
 	}
 	hCfg := container.HostConfig{
 		Binds:      opts.Binds,
 		Mounts:     mounts,
 		Privileged: opts.Privileged,
+		Resources: container.Resources{
+			Memory:   opts.Resources.Memory,
+			NanoCPUs: opts.Resources.NanoCPUs,
+		},
 	}
 	if opts.HostNetwork {
 		hCfg.NetworkMode = "host"
@@ -239,15 +261,23 @@ func (c *DockerContainer) GetID() string {
 	return c.id
 }
 
-// ImagePull downloads image for container
+// ImagePull downloads image for container according to the image pull policy
 func (c *DockerContainer) ImagePull() error {
-	// skip image download if already downloaded
-	// ImageInspectWithRaw returns err when image not found local and
-	//     in this case it will proceed for ImagePull.
-	_, _, err := c.dockerClient.ImageInspectWithRaw(c.ctx, c.imageURL)
-	if err == nil {
-		log.Debug("Image Already exists, skip download")
-		return nil
+	switch c.pullPolicy {
+	case v1alpha1.PullAlways:
+	case v1alpha1.PullIfNotPresent, "":
+		// skip image download if already downloaded
+		// ImageInspectWithRaw returns err when image not found local and
+		//     in this case it will proceed for ImagePull.
+		_, _, err := c.dockerClient.ImageInspectWithRaw(c.ctx, c.imageURL)
+		if err == nil {
+			log.Debug("Image Already exists, skip download")
+			return nil
+		}
+	case v1alpha1.PullNever:
+		return checkImagePresent(c.ctx, c.dockerClient, c.imageURL)
+	default:
+		return ErrUnknownPullPolicy{Policy: c.pullPolicy}
 	}
 	resp, err := c.dockerClient.ImagePull(c.ctx, c.imageURL, types.ImagePullOptions{})
 	if err != nil {
@@ -274,7 +304,7 @@ func (c *DockerContainer) RunCommand(opts RunCommandOptions) (err error) {
 		&hostConfig,
 		nil,
 		nil,
-		"",
+		opts.Name,
 	)
 	if err != nil {
 		return err
@@ -328,6 +358,17 @@ func (c *DockerContainer) GetContainerLogs(opts GetLogOptions) (io.ReadCloser, e
 	})
 }
 
+// KillContainer kills the container process, the container itself is kept on the docker host.
+func (c *DockerContainer) KillContainer() error {
+	return c.dockerClient.ContainerKill(c.ctx, c.id, "SIGKILL")
+}
+
+// IsErrNotFound returns true if the error is returned by container runtime because the container
+// or the image doesn't exist
+func IsErrNotFound(err error) bool {
+	return client.IsErrNotFound(err)
+}
+
 // RmContainer kills and removes a container from the docker host.
 func (c *DockerContainer) RmContainer() error {
 	return c.dockerClient.ContainerRemove(
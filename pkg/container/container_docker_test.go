@@ -31,7 +31,10 @@ import (
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/errdefs"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 )
 
 type mockConn struct {
@@ -59,6 +62,9 @@ type mockDockerClient struct {
 	containerWait       func() (<-chan container.ContainerWaitOKBody, <-chan error)
 	containerLogs       func() (io.ReadCloser, error)
 	containerInspect    func() (types.ContainerJSON, error)
+	containerKill       func() error
+	containerRemove     func() error
+	imageLoad           func() (types.ImageLoadResponse, error)
 }
 
 func (mdc *mockDockerClient) ImageInspectWithRaw(context.Context, string) (types.ImageInspect, []byte, error) {
@@ -119,7 +125,21 @@ func (mdc *mockDockerClient) ContainerLogs(context.Context, string, types.Contai
 	return ioutil.NopCloser(strings.NewReader("")), nil
 }
 
+func (mdc *mockDockerClient) ImageLoad(context.Context, io.Reader, bool) (types.ImageLoadResponse, error) {
+	return mdc.imageLoad()
+}
+
+func (mdc *mockDockerClient) ContainerKill(context.Context, string, string) error {
+	if mdc.containerKill != nil {
+		return mdc.containerKill()
+	}
+	return nil
+}
+
 func (mdc *mockDockerClient) ContainerRemove(context.Context, string, types.ContainerRemoveOptions) error {
+	if mdc.containerRemove != nil {
+		return mdc.containerRemove()
+	}
 	return nil
 }
 
@@ -234,15 +254,18 @@ func TestGetImageId(t *testing.T) {
 
 func TestImagePull(t *testing.T) {
 	testError := fmt.Errorf("image pull rror")
+	notFoundError := errdefs.NotFound(fmt.Errorf("no such image"))
+	imagePulled := func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(strings.NewReader("test")), nil
+	}
 	tests := []struct {
+		pullPolicy       v1alpha1.ImagePullPolicy
 		mockDockerClient mockDockerClient
 		expectedErr      error
 	}{
 		{
 			mockDockerClient: mockDockerClient{
-				imagePull: func() (io.ReadCloser, error) {
-					return ioutil.NopCloser(strings.NewReader("test")), nil
-				},
+				imagePull: imagePulled,
 				imageInspectWithRaw: func() (types.ImageInspect, []byte, error) {
 					return types.ImageInspect{}, nil, testError
 				},
@@ -260,9 +283,48 @@ func TestImagePull(t *testing.T) {
 			},
 			expectedErr: testError,
 		},
+		{
+			// image is pulled even if it is present
+			pullPolicy: v1alpha1.PullAlways,
+			mockDockerClient: mockDockerClient{
+				imagePull: func() (io.ReadCloser, error) {
+					return nil, testError
+				},
+				imageInspectWithRaw: func() (types.ImageInspect, []byte, error) {
+					return types.ImageInspect{}, nil, nil
+				},
+			},
+			expectedErr: testError,
+		},
+		{
+			pullPolicy: v1alpha1.PullNever,
+			mockDockerClient: mockDockerClient{
+				imageInspectWithRaw: func() (types.ImageInspect, []byte, error) {
+					return types.ImageInspect{}, nil, nil
+				},
+			},
+			expectedErr: nil,
+		},
+		{
+			pullPolicy: v1alpha1.PullNever,
+			mockDockerClient: mockDockerClient{
+				imagePull: imagePulled,
+				imageInspectWithRaw: func() (types.ImageInspect, []byte, error) {
+					return types.ImageInspect{}, nil, notFoundError
+				},
+			},
+			expectedErr: ErrImageNotPresent{Image: "testImage"},
+		},
+		{
+			pullPolicy:       "Sometimes",
+			mockDockerClient: mockDockerClient{},
+			expectedErr:      ErrUnknownPullPolicy{Policy: "Sometimes"},
+		},
 	}
 	for _, tt := range tests {
 		cnt := getDockerContainerMock(tt.mockDockerClient)
+		cnt.imageURL = "testImage"
+		cnt.pullPolicy = tt.pullPolicy
 		actualErr := cnt.ImagePull()
 
 		assert.Equal(t, tt.expectedErr, actualErr)
@@ -579,7 +641,7 @@ func TestNewDockerContainer(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		actualRes, actualErr := NewDockerContainer((tt.ctx), tt.url, &(tt.cli))
+		actualRes, actualErr := NewDockerContainer((tt.ctx), tt.url, &(tt.cli), v1alpha1.PullIfNotPresent)
 
 		assert.Equal(t, tt.expectedErr, actualErr)
 
@@ -615,6 +677,35 @@ func TestRmContainer(t *testing.T) {
 	}
 }
 
+func TestKillContainer(t *testing.T) {
+	killErr := fmt.Errorf("kill error")
+	cnt := getDockerContainerMock(mockDockerClient{
+		containerKill: func() error {
+			return killErr
+		},
+	})
+	assert.Equal(t, killErr, cnt.KillContainer())
+}
+
+func TestGetConfig(t *testing.T) {
+	cnt := getDockerContainerMock(mockDockerClient{})
+	cnt.imageURL = "testImage"
+	cCfg, hCfg, err := cnt.getConfig(RunCommandOptions{
+		Cmd:        []string{"testCmd"},
+		User:       "1000:1000",
+		WorkingDir: "/workdir",
+		Resources: Resources{
+			Memory:   512 * 1024 * 1024,
+			NanoCPUs: 500000000,
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1000:1000", cCfg.User)
+	assert.Equal(t, "/workdir", cCfg.WorkingDir)
+	assert.Equal(t, int64(512*1024*1024), hCfg.Resources.Memory)
+	assert.Equal(t, int64(500000000), hCfg.Resources.NanoCPUs)
+}
+
 func TestInspectContainer(t *testing.T) {
 	tests := []struct {
 		cli           mockDockerClient
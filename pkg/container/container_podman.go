@@ -0,0 +1,88 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/docker/docker/client"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+)
+
+const (
+	// PodmanHostEnv environment variable overrides the address of podman API service
+	PodmanHostEnv = "CONTAINER_HOST"
+
+	podmanRootSocket     = "/run/podman/podman.sock"
+	podmanRootlessSocket = "podman/podman.sock"
+)
+
+// PodmanContainer podman container object wrapper.
+// Podman API service provides docker compatible API, so docker implementation of
+// Container interface is reused with the client connected to podman socket
+type PodmanContainer struct {
+	*DockerContainer
+}
+
+// NewPodmanClient returns instance of DockerClient connected to docker compatible API of podman.
+// If host is empty, CONTAINER_HOST environment variable is used, otherwise the default socket of
+// root or rootless podman service is used depending on the user running airshipctl
+func NewPodmanClient(ctx context.Context, host string) (DockerClient, error) {
+	if host == "" {
+		host = podmanHost()
+	}
+	cli, err := client.NewClientWithOpts(client.WithHost(host))
+	if err != nil {
+		return nil, err
+	}
+	cli.NegotiateAPIVersion(ctx)
+	return cli, nil
+}
+
+// NewPodmanContainer returns instance of PodmanContainer object wrapper.
+// Function gets container image url, pointer to execution context,
+// DockerClient instance connected to podman API service and image pull policy.
+func NewPodmanContainer(ctx context.Context, url string, cli DockerClient,
+	pullPolicy v1alpha1.ImagePullPolicy) (*PodmanContainer, error) {
+	cnt, err := NewDockerContainer(ctx, url, cli, pullPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return &PodmanContainer{DockerContainer: cnt}, nil
+}
+
+// WaitUntilFinished waits unit container command is finished, return an error if failed
+func (c *PodmanContainer) WaitUntilFinished() error {
+	err := c.DockerContainer.WaitUntilFinished()
+	if errors.As(err, &ErrRunContainerCommand{}) {
+		return ErrRunContainerCommand{Cmd: fmt.Sprintf("podman logs %s", c.GetID())}
+	}
+	return err
+}
+
+func podmanHost() string {
+	if host := os.Getenv(PodmanHostEnv); host != "" {
+		return host
+	}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" && os.Geteuid() != 0 {
+		return "unix://" + filepath.Join(runtimeDir, podmanRootlessSocket)
+	}
+	return "unix://" + podmanRootSocket
+}
@@ -0,0 +1,133 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+)
+
+// fakePodmanAPI serves minimal subset of docker compatible API of podman service
+type fakePodmanAPI struct {
+	exitCode int
+	logs     string
+
+	mu       sync.Mutex
+	requests []string
+}
+
+var apiVersionPrefix = regexp.MustCompile(`^/v[0-9.]+`)
+
+func (f *fakePodmanAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := apiVersionPrefix.ReplaceAllString(r.URL.Path, "")
+	f.mu.Lock()
+	f.requests = append(f.requests, r.Method+" "+path)
+	f.mu.Unlock()
+
+	w.Header().Set("Api-Version", "1.40")
+	switch {
+	case path == "/_ping":
+		fmt.Fprint(w, "OK")
+	case r.Method == http.MethodGet && path == "/images/json":
+		fmt.Fprint(w, `[{"Id": "sha256:podman-image"}]`)
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "/images/") && strings.HasSuffix(path, "/json"):
+		fmt.Fprint(w, `{"Id": "sha256:podman-image", "Config": {"Cmd": ["default-cmd"]}}`)
+	case r.Method == http.MethodPost && path == "/containers/create":
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"Id": "podman-id", "Warnings": []}`)
+	case r.Method == http.MethodPost && path == "/containers/podman-id/start":
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodPost && path == "/containers/podman-id/wait":
+		fmt.Fprintf(w, `{"StatusCode": %d}`, f.exitCode)
+	case r.Method == http.MethodGet && path == "/containers/podman-id/json":
+		fmt.Fprintf(w, `{"Id": "podman-id", "State": {"Status": "exited", "ExitCode": %d}}`, f.exitCode)
+	case r.Method == http.MethodGet && path == "/containers/podman-id/logs":
+		// logs are multiplexed in the same way as docker does it: stream type, 3 zero bytes and payload size
+		header := []byte{1, 0, 0, 0, 0, 0, 0, 0}
+		binary.BigEndian.PutUint32(header[4:], uint32(len(f.logs)))
+		_, _ = w.Write(append(header, []byte(f.logs)...))
+	case r.Method == http.MethodDelete && path == "/containers/podman-id":
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"message": "unexpected request %s %s"}`, r.Method, path)
+	}
+}
+
+func newFakePodmanContainer(t *testing.T, srv *httptest.Server) *PodmanContainer {
+	t.Helper()
+	ctx := context.Background()
+	cli, err := NewPodmanClient(ctx, "tcp://"+srv.Listener.Addr().String())
+	require.NoError(t, err)
+	cnt, err := NewPodmanContainer(ctx, "quay.io/airshipit/test:latest", cli, v1alpha1.PullIfNotPresent)
+	require.NoError(t, err)
+	return cnt
+}
+
+func TestPodmanContainer(t *testing.T) {
+	api := &fakePodmanAPI{logs: "foo: bar\n"}
+	srv := httptest.NewServer(api)
+	defer srv.Close()
+	cnt := newFakePodmanContainer(t, srv)
+
+	require.NoError(t, cnt.RunCommand(RunCommandOptions{Cmd: []string{"test-cmd"}}))
+	assert.Equal(t, "podman-id", cnt.GetID())
+	require.NoError(t, cnt.WaitUntilFinished())
+
+	state, err := cnt.InspectContainer()
+	require.NoError(t, err)
+	assert.Equal(t, State{ExitCode: 0, Status: ExitedContainerStatus}, state)
+
+	rc, err := cnt.GetContainerLogs(GetLogOptions{Stdout: true})
+	require.NoError(t, err)
+	defer rc.Close()
+	logs, err := ioutil.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Contains(t, string(logs), "foo: bar")
+
+	require.NoError(t, cnt.RmContainer())
+	assert.Contains(t, api.requests, "POST /containers/create")
+	assert.Contains(t, api.requests, "DELETE /containers/podman-id")
+}
+
+func TestPodmanContainerFailed(t *testing.T) {
+	srv := httptest.NewServer(&fakePodmanAPI{exitCode: 1})
+	defer srv.Close()
+	cnt := newFakePodmanContainer(t, srv)
+
+	require.NoError(t, cnt.RunCommand(RunCommandOptions{}))
+	err := cnt.WaitUntilFinished()
+	assert.Equal(t, ErrRunContainerCommand{Cmd: "podman logs podman-id"}, err)
+}
+
+func TestPodmanHost(t *testing.T) {
+	defer os.Setenv(PodmanHostEnv, os.Getenv(PodmanHostEnv))
+	require.NoError(t, os.Setenv(PodmanHostEnv, "tcp://podman.local:8888"))
+	assert.Equal(t, "tcp://podman.local:8888", podmanHost())
+}
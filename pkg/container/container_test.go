@@ -19,6 +19,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 )
 
 func TestNewContainer(t *testing.T) {
@@ -27,13 +29,13 @@ func TestNewContainer(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("not-supported-container", func(t *testing.T) {
-		cnt, err := NewContainer(ctx, "test_drv", "")
+		cnt, err := NewContainer(ctx, "test_drv", "", v1alpha1.PullIfNotPresent)
 		assert.Equal(nil, cnt)
 		assert.Equal(ErrContainerDrvNotSupported{Driver: "test_drv"}, err)
 	})
 
 	t.Run("empty-container", func(t *testing.T) {
-		cnt, err := NewContainer(ctx, "", "")
+		cnt, err := NewContainer(ctx, "", "", v1alpha1.PullIfNotPresent)
 		assert.Equal(nil, cnt)
 		assert.Equal(ErrNoContainerDriver{}, err)
 	})
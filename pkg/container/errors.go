@@ -16,6 +16,9 @@ package container
 
 import (
 	"fmt"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 )
 
 // ErrEmptyImageList returned if no image defined in filter found
@@ -52,3 +55,54 @@ type ErrNoContainerDriver struct {
 func (e ErrNoContainerDriver) Error() string {
 	return fmt.Sprintf("container runtime is not defined in airshipctl config")
 }
+
+// ErrInvalidKRMSpec returned if KRM function spec of generic container is invalid
+type ErrInvalidKRMSpec struct {
+	Reason string
+}
+
+func (e ErrInvalidKRMSpec) Error() string {
+	return fmt.Sprintf("invalid krm function spec: %s", e.Reason)
+}
+
+// ErrInvalidResourceLimit returned if resource limit of the container can't be parsed
+type ErrInvalidResourceLimit struct {
+	Resource string
+	Value    string
+	Err      error
+}
+
+func (e ErrInvalidResourceLimit) Error() string {
+	return fmt.Sprintf("invalid %s limit '%s': %v", e.Resource, e.Value, e.Err)
+}
+
+// ErrContainerTimeout returned if container command is not finished in time
+type ErrContainerTimeout struct {
+	ID      string
+	Timeout time.Duration
+	Logs    string
+}
+
+func (e ErrContainerTimeout) Error() string {
+	return fmt.Sprintf("container %s is killed after %v timeout, last logs:\n%s", e.ID, e.Timeout, e.Logs)
+}
+
+// ErrImageNotPresent returned if image is not present locally and it can't be pulled due to pull policy
+type ErrImageNotPresent struct {
+	Image string
+}
+
+func (e ErrImageNotPresent) Error() string {
+	return fmt.Sprintf("image %s is not present locally and image pull policy is %s, "+
+		"load it with 'airshipctl image load' or change the pull policy", e.Image, v1alpha1.PullNever)
+}
+
+// ErrUnknownPullPolicy returned if image pull policy is not supported
+type ErrUnknownPullPolicy struct {
+	Policy v1alpha1.ImagePullPolicy
+}
+
+func (e ErrUnknownPullPolicy) Error() string {
+	return fmt.Sprintf("unknown image pull policy '%s', supported policies are %s, %s and %s",
+		e.Policy, v1alpha1.PullAlways, v1alpha1.PullIfNotPresent, v1alpha1.PullNever)
+}
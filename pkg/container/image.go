@@ -0,0 +1,88 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+)
+
+// ImageLoader loads image tarballs into container runtime
+type ImageLoader interface {
+	ImageLoad(context.Context, io.Reader, bool) (types.ImageLoadResponse, error)
+}
+
+// ImageLoaderFactory returns ImageLoader of the container runtime of the driver
+type ImageLoaderFactory func(ctx context.Context, driver string) (ImageLoader, error)
+
+// NewImageLoader returns ImageLoader of the container runtime of the driver
+func NewImageLoader(ctx context.Context, driver string) (ImageLoader, error) {
+	return NewRuntimeClient(ctx, driver)
+}
+
+// CheckImage makes sure that the container can be started with the given image pull policy,
+// i.e. the image must be present in local storage of container runtime if the policy is Never
+func CheckImage(ctx context.Context, driver string, url string, pullPolicy v1alpha1.ImagePullPolicy) error {
+	switch pullPolicy {
+	case v1alpha1.PullAlways, v1alpha1.PullIfNotPresent, "":
+		return nil
+	case v1alpha1.PullNever:
+		cli, err := NewRuntimeClient(ctx, driver)
+		if err != nil {
+			return err
+		}
+		return checkImagePresent(ctx, cli, url)
+	default:
+		return ErrUnknownPullPolicy{Policy: pullPolicy}
+	}
+}
+
+// LoadImageArchive loads image tarball created by `docker save` or `podman save` into container runtime,
+// progress reported by the runtime is written to out
+func LoadImageArchive(ctx context.Context, loader ImageLoader, path string, out io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	resp, err := loader.ImageLoad(ctx, f, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if !resp.JSON {
+		_, err = io.Copy(out, resp.Body)
+		return err
+	}
+	// runtime reports both progress and errors as a stream of json messages
+	return jsonmessage.DisplayJSONMessagesStream(resp.Body, out, 0, false, nil)
+}
+
+func checkImagePresent(ctx context.Context, cli DockerClient, url string) error {
+	_, _, err := cli.ImageInspectWithRaw(ctx, url)
+	if client.IsErrNotFound(err) {
+		return ErrImageNotPresent{Image: url}
+	}
+	return err
+}
@@ -0,0 +1,107 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package container
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/testutil"
+)
+
+func TestLoadImageArchive(t *testing.T) {
+	dir, cleanup := testutil.TempDir(t, "airshipctl-image-load-")
+	defer cleanup(t)
+	archive := filepath.Join(dir, "image.tar")
+	require.NoError(t, ioutil.WriteFile(archive, []byte("image tarball"), 0600))
+
+	tests := []struct {
+		name           string
+		path           string
+		response       string
+		json           bool
+		expectedOutput string
+		expectedErr    string
+	}{
+		{
+			name:           "image loaded",
+			path:           archive,
+			response:       `{"stream": "Loaded image: quay.io/airshipit/test:latest\n"}`,
+			json:           true,
+			expectedOutput: "Loaded image: quay.io/airshipit/test:latest",
+		},
+		{
+			name:           "plain text response",
+			path:           archive,
+			response:       "Loaded image: quay.io/airshipit/test:latest",
+			expectedOutput: "Loaded image: quay.io/airshipit/test:latest",
+		},
+		{
+			name:        "runtime error",
+			path:        archive,
+			response:    `{"errorDetail": {"message": "invalid tar header"}, "error": "invalid tar header"}`,
+			json:        true,
+			expectedErr: "invalid tar header",
+		},
+		{
+			name:        "archive does not exist",
+			path:        filepath.Join(dir, "missing.tar"),
+			expectedErr: "no such file or directory",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			loader := &mockDockerClient{
+				imageLoad: func() (types.ImageLoadResponse, error) {
+					return types.ImageLoadResponse{
+						Body: ioutil.NopCloser(strings.NewReader(tt.response)),
+						JSON: tt.json,
+					}, nil
+				},
+			}
+			out := &bytes.Buffer{}
+			err := LoadImageArchive(context.Background(), loader, tt.path, out)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), tt.expectedOutput)
+		})
+	}
+}
+
+func TestCheckImage(t *testing.T) {
+	ctx := context.Background()
+	// images are not checked unless pull policy is Never
+	assert.NoError(t, CheckImage(ctx, "", "quay.io/airshipit/test:latest", v1alpha1.PullIfNotPresent))
+	assert.NoError(t, CheckImage(ctx, "", "quay.io/airshipit/test:latest", v1alpha1.PullAlways))
+	assert.Equal(t, ErrNoContainerDriver{},
+		CheckImage(ctx, "", "quay.io/airshipit/test:latest", v1alpha1.PullNever))
+	assert.Equal(t, ErrUnknownPullPolicy{Policy: "Sometimes"},
+		CheckImage(ctx, ContainerDriverDocker, "quay.io/airshipit/test:latest", "Sometimes"))
+}
@@ -23,12 +23,29 @@ const (
 	// Please note that by default every document in the manifest is to be deployed to kubernetes cluster.
 	// so this selector simply checks that deploy-k8s label is not equal to false or False (string)
 	DeployToK8sSelector = "airshipit.org/deploy-k8s notin (False, false)"
+
+	// BIOSSettingsLabel is set on BareMetalHost documents to the name of BIOSSettings document
+	// holding desired BIOS attributes of the host
+	BIOSSettingsLabel = BaseAirshipSelector + "/bios-settings"
+)
+
+// BaremetalHost annotations
+const (
+	// BMCCABundleAnnotation is set on BareMetalHost documents to PEM encoded certificate authorities
+	// trusted to issue BMC certificate of the host
+	BMCCABundleAnnotation = BaseAirshipSelector + "/bmc-ca-bundle"
+	// BMCCASecretAnnotation is set on BareMetalHost documents to the name of Secret document holding
+	// PEM encoded certificate authorities trusted to issue BMC certificate of the host under BMCCASecretKey
+	BMCCASecretAnnotation = BaseAirshipSelector + "/bmc-ca-secret"
+	// BMCCASecretKey is the key of Secret data with certificate authorities
+	BMCCASecretKey = "ca.crt"
 )
 
 // GVKs
 const (
 	SecretKind        = "Secret"
 	BareMetalHostKind = "BareMetalHost"
+	BIOSSettingsKind  = "BIOSSettings"
 
 	ConfigMapKind    = "ConfigMap"
 	ConfigMapVersion = "v1"
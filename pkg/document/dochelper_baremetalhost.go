@@ -14,6 +14,10 @@
 
 package document
 
+import (
+	"strings"
+)
+
 // GetBMHNetworkData retrieves the associated network data string
 // for the bmh document supplied from the bundle supplied
 func GetBMHNetworkData(bmh Document, bundle Bundle) (string, error) {
@@ -44,6 +48,16 @@ func GetBMHBMCAddress(bmh Document) (string, error) {
 	return bmcAddress, nil
 }
 
+// GetBMHBootMode returns the boot mode of the bmh document, "UEFI" is returned if it's not set,
+// in the same way as it's defaulted by baremetal operator
+func GetBMHBootMode(bmh Document) string {
+	bootMode, err := bmh.GetString("spec.bootMode")
+	if err != nil || bootMode == "" {
+		return "UEFI"
+	}
+	return bootMode
+}
+
 // GetBMHBMCCredentials returns the BMC credentials for the bmh document supplied from
 // the supplied bundle
 func GetBMHBMCCredentials(bmh Document, bundle Bundle) (username string, password string, err error) {
@@ -72,3 +86,45 @@ func GetBMHBMCCredentials(bmh Document, bundle Bundle) (username string, passwor
 	// extract the username and password from them
 	return username, password, nil
 }
+
+// GetBMHBIOSSettings returns desired BIOS attributes from BIOSSettings document referenced by
+// the bmh document with BIOSSettingsLabel, nil is returned if the bmh doesn't reference any
+func GetBMHBIOSSettings(bmh Document, bundle Bundle) (map[string]interface{}, error) {
+	name, ok := bmh.GetLabels()[BIOSSettingsLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	doc, err := bundle.SelectOne(NewBIOSSettingsSelector(name))
+	if err != nil {
+		return nil, err
+	}
+	return doc.GetMap("spec.attributes")
+}
+
+// GetBMHBMCCABundle returns PEM encoded certificate authorities trusted to issue BMC certificate of
+// the bmh document, both inline with BMCCABundleAnnotation and from Secret referenced with
+// BMCCASecretAnnotation. Empty string is returned if the bmh doesn't reference any.
+func GetBMHBMCCABundle(bmh Document, bundle Bundle) (string, error) {
+	annotations := bmh.GetAnnotations()
+	caBundle := annotations[BMCCABundleAnnotation]
+
+	name, ok := annotations[BMCCASecretAnnotation]
+	if !ok {
+		return caBundle, nil
+	}
+
+	doc, err := bundle.SelectOne(NewSelector().ByKind(SecretKind).ByName(name))
+	if err != nil {
+		return "", err
+	}
+
+	secretCABundle, err := GetSecretDataKey(doc, BMCCASecretKey)
+	if err != nil {
+		return "", err
+	}
+	if caBundle != "" && !strings.HasSuffix(caBundle, "\n") {
+		caBundle += "\n"
+	}
+	return caBundle + secretCABundle, nil
+}
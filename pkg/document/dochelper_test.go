@@ -66,6 +66,30 @@ func TestDocHelpers(t *testing.T) {
 		assert.Equal(bmcUsername, "username")
 		assert.Equal(bmcPassword, "password")
 	})
+
+	t.Run("GetBMHBIOSSettings", func(t *testing.T) {
+		// retrieve our single bmh in the dataset
+		selector := document.NewSelector().ByKind("BareMetalHost")
+		doc, err := bundle.SelectOne(selector)
+		require.NoError(err)
+
+		attributes, err := document.GetBMHBIOSSettings(doc, bundle)
+		require.NoError(err, "Unexpected error trying to GetBMHBIOSSettings")
+		assert.Len(attributes, 3)
+		assert.Equal("Disabled", attributes["ProcTurboMode"])
+		assert.EqualValues(2, attributes["ProcCoreDisable"])
+		assert.Equal(true, attributes["SriovGlobalEnable"])
+	})
+
+	t.Run("GetBMHBMCCABundle", func(t *testing.T) {
+		selector := document.NewSelector().ByKind("BareMetalHost")
+		doc, err := bundle.SelectOne(selector)
+		require.NoError(err)
+
+		caBundle, err := document.GetBMHBMCCABundle(doc, bundle)
+		require.NoError(err, "Unexpected error trying to GetBMHBMCCABundle")
+		assert.Equal("inline-ca\nsecret-ca", caBundle)
+	})
 }
 
 func TestDocHelpersNegativeCases(t *testing.T) {
@@ -104,4 +128,22 @@ func TestDocHelpersNegativeCases(t *testing.T) {
 		_, _, err = document.GetBMHBMCCredentials(doc, bundle)
 		require.Error(err)
 	})
+
+	t.Run("GetBMHBIOSSettings", func(t *testing.T) {
+		selector := document.NewSelector().ByKind("BareMetalHost")
+		doc, err := bundle.SelectOne(selector)
+		require.NoError(err)
+
+		_, err = document.GetBMHBIOSSettings(doc, bundle)
+		require.Error(err)
+	})
+
+	t.Run("GetBMHBMCCABundle", func(t *testing.T) {
+		selector := document.NewSelector().ByKind("BareMetalHost")
+		doc, err := bundle.SelectOne(selector)
+		require.NoError(err)
+
+		_, err = document.GetBMHBMCCABundle(doc, bundle)
+		require.Error(err)
+	})
 }
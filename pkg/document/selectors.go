@@ -152,6 +152,11 @@ func NewBMCCredentialsSelector(name string) Selector {
 	return NewSelector().ByKind(SecretKind).ByName(name)
 }
 
+// NewBIOSSettingsSelector returns selector to get BIOS settings referenced by BaremetalHost
+func NewBIOSSettingsSelector(name string) Selector {
+	return NewSelector().ByKind(BIOSSettingsKind).ByName(name)
+}
+
 // NewNetworkDataSelector returns selector that can be used to get secret with
 // network data bmhDoc argument is a document interface, that should hold fields
 // spec.networkData.name and spec.networkData.namespace where to find the secret,
@@ -42,6 +42,10 @@ const (
 	GenericContainerType
 	// BaremetalManagerEventType event emitted by BaremetalManager
 	BaremetalManagerEventType
+	// ApplyWaveType event emitted by applier when resources are applied in waves
+	ApplyWaveType
+	// PruneType event emitted by applier when resources are pruned
+	PruneType
 )
 
 // Event holds all possible events that can be produced by airship
@@ -55,6 +59,8 @@ type Event struct {
 	BootstrapEvent        BootstrapEvent
 	GenericContainerEvent GenericContainerEvent
 	BaremetalManagerEvent BaremetalManagerEvent
+	ApplyWaveEvent        ApplyWaveEvent
+	PruneEvent            PruneEvent
 }
 
 //GenericEvent generalized type for custom events
@@ -69,6 +75,8 @@ var mapTypeToEvent = map[Type]string{
 	ClusterctlType:       "ClusterctlEvent",
 	BootstrapType:        "BootstrapEvent",
 	GenericContainerType: "GenericContainerEvent",
+	ApplyWaveType:        "ApplyWaveEvent",
+	PruneType:            "PruneEvent",
 }
 
 var unknownEventType = map[Type]string{
@@ -91,18 +99,32 @@ var bootstrapOperationToString = map[BootstrapOperation]string{
 	BootstrapValidation: "BootstrapValidation",
 	BootstrapRun:        "BootstrapRun",
 	BootstrapEnd:        "BootstrapEnd",
+	BootstrapKubeconfig: "BootstrapKubeconfig",
 }
 
 var genericContainerOperationToString = map[GenericContainerOperation]string{
-	GenericContainerStart: "GenericContainerStart",
-	GenericContainerStop:  "GenericContainerStop",
+	GenericContainerStart:   "GenericContainerStart",
+	GenericContainerStop:    "GenericContainerStop",
+	GenericContainerTimeout: "GenericContainerTimeout",
 }
 
 var baremetalInventoryOperationToString = map[BaremetalManagerStep]string{
 	BaremetalManagerStart:    "BaremetalOperationStart",
+	BaremetalManagerHost:     "BaremetalOperationHost",
 	BaremetalManagerComplete: "BaremetalOperationComplete",
 }
 
+var applyWaveOperationToString = map[ApplyWaveOperation]string{
+	ApplyWaveStart:    "ApplyWaveStart",
+	ApplyWaveComplete: "ApplyWaveComplete",
+}
+
+var pruneOperationToString = map[PruneOperation]string{
+	PrunePreview:   "PrunePreview",
+	PruneProtected: "PruneProtected",
+	Pruned:         "Pruned",
+}
+
 //Normalize cast Event to GenericEvent type
 func Normalize(e Event) GenericEvent {
 	var eventType string
@@ -126,6 +148,12 @@ func Normalize(e Event) GenericEvent {
 	case BaremetalManagerEventType:
 		operation = baremetalInventoryOperationToString[e.BaremetalManagerEvent.Step]
 		message = e.BaremetalManagerEvent.Message
+	case ApplyWaveType:
+		operation = applyWaveOperationToString[e.ApplyWaveEvent.Operation]
+		message = e.ApplyWaveEvent.Message
+	case PruneType:
+		operation = pruneOperationToString[e.PruneEvent.Operation]
+		message = e.PruneEvent.Message
 	}
 
 	return GenericEvent{
@@ -196,6 +224,9 @@ const (
 	BootstrapRun
 	// BootstrapEnd operation
 	BootstrapEnd
+	// BootstrapKubeconfig operation is emitted when kubeconfig of the ephemeral cluster is
+	// written to or removed from the filesystem kubeconfig source
+	BootstrapKubeconfig
 )
 
 // BootstrapEvent needs to to track events in bootstrap executor
@@ -219,6 +250,8 @@ const (
 	GenericContainerStart GenericContainerOperation = iota
 	// GenericContainerStop operation
 	GenericContainerStop
+	// GenericContainerTimeout operation, the container is killed because it didn't finish in time
+	GenericContainerTimeout
 )
 
 // GenericContainerEvent needs to to track events in GenericContainer executor
@@ -243,6 +276,8 @@ const (
 	BaremetalManagerStart BaremetalManagerStep = iota
 	// BaremetalManagerComplete operation
 	BaremetalManagerComplete
+	// BaremetalManagerHost operation is emitted once per host with result of the operation against it
+	BaremetalManagerHost
 )
 
 // BaremetalManagerEvent event emitted by BaremetalManager
@@ -250,6 +285,9 @@ type BaremetalManagerEvent struct {
 	Step BaremetalManagerStep
 	// HostOperation indicates which operation is performed against BMH Host
 	HostOperation string
+	// HostName and HostNamespace identify BMH Host for BaremetalManagerHost step
+	HostName      string
+	HostNamespace string
 	Message       string
 }
 
@@ -259,3 +297,55 @@ func (e Event) WithBaremetalManagerEvent(concreteEvent BaremetalManagerEvent) Ev
 	e.BaremetalManagerEvent = concreteEvent
 	return e
 }
+
+// ApplyWaveOperation type
+type ApplyWaveOperation int
+
+const (
+	// ApplyWaveStart operation
+	ApplyWaveStart ApplyWaveOperation = iota
+	// ApplyWaveComplete operation
+	ApplyWaveComplete
+)
+
+// ApplyWaveEvent is produced by applier when it starts or completes an apply wave
+type ApplyWaveEvent struct {
+	Operation ApplyWaveOperation
+	// Wave is the number of the wave taken from the apply-wave annotation
+	Wave    int
+	Message string
+}
+
+// WithApplyWaveEvent sets type and actual apply wave event
+func (e Event) WithApplyWaveEvent(concreteEvent ApplyWaveEvent) Event {
+	e.Type = ApplyWaveType
+	e.ApplyWaveEvent = concreteEvent
+	return e
+}
+
+// PruneOperation type
+type PruneOperation int
+
+const (
+	// PrunePreview operation, object is going to be pruned after resources are applied
+	PrunePreview PruneOperation = iota
+	// PruneProtected operation, object is not pruned because of prune-protect annotation
+	PruneProtected
+	// Pruned operation, object is deleted from the cluster
+	Pruned
+)
+
+// PruneEvent is produced by applier for every object removed from the phase
+type PruneEvent struct {
+	Operation PruneOperation
+	// Object identifies pruned object in "Kind.group namespace/name" format
+	Object  string
+	Message string
+}
+
+// WithPruneEvent sets type and actual prune event
+func (e Event) WithPruneEvent(concreteEvent PruneEvent) Event {
+	e.Type = PruneType
+	e.PruneEvent = concreteEvent
+	return e
+}
@@ -46,6 +46,18 @@ func TestNormalize(t *testing.T) {
 				Message: "Clusterctl init start",
 			},
 		},
+		{
+			name: "Prune event type",
+			sourceEvent: events.NewEvent().WithPruneEvent(events.PruneEvent{
+				Operation: events.Pruned,
+				Object:    "ConfigMap default/test",
+				Message:   "ConfigMap default/test is pruned",
+			}),
+			expectedEvent: events.GenericEvent{
+				Type:    "PruneEvent",
+				Message: "ConfigMap default/test is pruned",
+			},
+		},
 	}
 
 	for _, tt := range tests {
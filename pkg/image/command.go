@@ -0,0 +1,102 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/container"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/phase"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+// LoadCommand loads container images from the tarballs listed in ImageArchive documents
+// of the site into local container runtime
+type LoadCommand struct {
+	Factory config.Factory
+	Writer  io.Writer
+	// Name of ImageArchive document to load, all ImageArchive documents are loaded if empty
+	Name string
+
+	LoaderFactory container.ImageLoaderFactory
+}
+
+// RunE loads image archives
+func (c *LoadCommand) RunE() error {
+	cfg, err := c.Factory()
+	if err != nil {
+		return err
+	}
+
+	helper, err := phase.NewHelper(cfg)
+	if err != nil {
+		return err
+	}
+
+	selector := document.NewSelector().ByGvk(v1alpha1.GroupVersion.Group, v1alpha1.GroupVersion.Version,
+		"ImageArchive")
+	if c.Name != "" {
+		selector = selector.ByName(c.Name)
+	}
+	docs, err := helper.PhaseConfigBundle().Select(selector)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return ErrImageArchiveNotFound{Name: c.Name}
+	}
+
+	for _, doc := range docs {
+		archive := v1alpha1.DefaultImageArchive()
+		if err = doc.ToAPIObject(archive, v1alpha1.Scheme); err != nil {
+			return err
+		}
+		if err = c.load(archive, helper.TargetPath()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *LoadCommand) load(archive *v1alpha1.ImageArchive, targetPath string) error {
+	runtime := archive.ContainerRuntime
+	if runtime == "" {
+		runtime = container.ContainerDriverDocker
+	}
+
+	ctx := context.Background()
+	loader, err := c.LoaderFactory(ctx, runtime)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range archive.Archives {
+		path = util.ExpandTilde(path)
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(targetPath, path)
+		}
+		fmt.Fprintf(c.Writer, "Loading image archive %s into %s\n", path, runtime)
+		if err = container.LoadImageArchive(ctx, loader, path, c.Writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
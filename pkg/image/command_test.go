@@ -0,0 +1,144 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package image_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/container"
+	"opendev.org/airship/airshipctl/pkg/image"
+)
+
+func testConfig(t *testing.T) *config.Config {
+	t.Helper()
+	confString := `apiVersion: airshipit.org/v1alpha1
+contexts:
+  dummy_cluster:
+    manifest: dummy_manifest
+currentContext: dummy_cluster
+kind: Config
+manifests:
+  dummy_manifest:
+    phaseRepositoryName: primary
+    targetPath: testdata
+    metadataPath: metadata.yaml
+    repositories:
+      primary:
+        url: "empty/filename/"`
+
+	conf := config.NewConfig()
+	err := yaml.Unmarshal([]byte(confString), conf)
+	require.NoError(t, err)
+	return conf
+}
+
+type fakeLoader struct {
+	loaded []string
+	err    error
+}
+
+func (l *fakeLoader) ImageLoad(_ context.Context, r io.Reader, _ bool) (types.ImageLoadResponse, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return types.ImageLoadResponse{}, err
+	}
+	l.loaded = append(l.loaded, string(data))
+	return types.ImageLoadResponse{Body: ioutil.NopCloser(strings.NewReader("Loaded image\n"))}, l.err
+}
+
+func TestLoadCommand(t *testing.T) {
+	tests := []struct {
+		name        string
+		archiveName string
+		loader      *fakeLoader
+		runtimes    []string
+		expectedOut string
+		expectedErr error
+	}{
+		{
+			name:        "load single archive document",
+			archiveName: "bootstrap-images",
+			loader:      &fakeLoader{},
+			runtimes:    []string{"podman"},
+			expectedOut: "Loading image archive testdata/images/bootstrap.tar into podman\nLoaded image\n",
+		},
+		{
+			name:        "archive document not found",
+			archiveName: "not-existing",
+			loader:      &fakeLoader{},
+			expectedErr: image.ErrImageArchiveNotFound{Name: "not-existing"},
+		},
+		{
+			name:        "load error",
+			archiveName: "bootstrap-images",
+			loader:      &fakeLoader{err: fmt.Errorf("runtime error")},
+			runtimes:    []string{"podman"},
+			expectedErr: fmt.Errorf("runtime error"),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var runtimes []string
+			out := &bytes.Buffer{}
+			cmd := &image.LoadCommand{
+				Factory: func() (*config.Config, error) { return testConfig(t), nil },
+				Writer:  out,
+				Name:    tt.archiveName,
+				LoaderFactory: func(_ context.Context, driver string) (container.ImageLoader, error) {
+					runtimes = append(runtimes, driver)
+					return tt.loader, nil
+				},
+			}
+			err := cmd.RunE()
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.runtimes, runtimes)
+			if tt.expectedErr == nil {
+				assert.Equal(t, tt.expectedOut, out.String())
+				assert.Equal(t, []string{"bootstrap image"}, tt.loader.loaded)
+			}
+		})
+	}
+}
+
+func TestLoadCommandAllArchives(t *testing.T) {
+	var runtimes []string
+	cmd := &image.LoadCommand{
+		Factory: func() (*config.Config, error) { return testConfig(t), nil },
+		Writer:  ioutil.Discard,
+		LoaderFactory: func(_ context.Context, driver string) (container.ImageLoader, error) {
+			runtimes = append(runtimes, driver)
+			return &fakeLoader{}, nil
+		},
+	}
+	err := cmd.RunE()
+	// the second document refers to archive that is not present in testdata
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kubeval.tar")
+	assert.Equal(t, []string{"podman", "docker"}, runtimes)
+}
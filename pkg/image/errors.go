@@ -0,0 +1,31 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package image
+
+import (
+	"fmt"
+)
+
+// ErrImageArchiveNotFound is returned if there are no ImageArchive documents to load
+type ErrImageArchiveNotFound struct {
+	Name string
+}
+
+func (e ErrImageArchiveNotFound) Error() string {
+	if e.Name == "" {
+		return "no ImageArchive documents found in phase bundle"
+	}
+	return fmt.Sprintf("ImageArchive document '%s' is not found in phase bundle", e.Name)
+}
@@ -16,14 +16,19 @@ package baremetal
 
 import (
 	"context"
+	"strings"
+	"sync"
 
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
 	"opendev.org/airship/airshipctl/pkg/log"
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
 	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+	redfishhpe "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/hpe"
+	redfishsupermicro "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/supermicro"
 )
 
 // Inventory implements baremetal invenotry interface
@@ -46,6 +51,19 @@ func NewInventory(
 
 // Select selects hosts based on given selector
 func (i Inventory) Select(selector ifc.BaremetalHostSelector) ([]remoteifc.Client, error) {
+	hosts, err := i.selectHosts(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	hostList := []remoteifc.Client{}
+	for _, host := range hosts {
+		hostList = append(hostList, host)
+	}
+	return hostList, nil
+}
+
+func (i Inventory) selectHosts(selector ifc.BaremetalHostSelector) ([]Host, error) {
 	log.Debugf("Using selector %v to filter baremetal hosts", selector)
 	bmhSelector := toDocumentSelector(selector)
 	docs, err := i.inventoryBundle.Select(bmhSelector)
@@ -55,7 +73,7 @@ func (i Inventory) Select(selector ifc.BaremetalHostSelector) ([]remoteifc.Clien
 	}
 
 	log.Debugf("Baremetal hosts count that matched the selector '%v' is '%d'", selector, len(docs))
-	hostList := []remoteifc.Client{}
+	hostList := []Host{}
 	for _, doc := range docs {
 		host, err := i.newHost(doc)
 		if err != nil {
@@ -82,47 +100,220 @@ func (i Inventory) SelectOne(selector ifc.BaremetalHostSelector) (remoteifc.Clie
 }
 
 // RunOperation runs specified operation against the hosts that would be filtered by selector.
-// Options are ignored for now, when we implement concurency, they will be used.
+// Result of the operation is reported for every selected host, if operation failed against
+// any of the hosts ErrBaremetalOperationFailed is returned, unless ContinueOnError option is set.
 func (i Inventory) RunOperation(
 	ctx context.Context,
 	op ifc.BaremetalOperation,
 	selector ifc.BaremetalHostSelector,
-	_ ifc.BaremetalBatchRunOptions) error {
+	opts ifc.BaremetalBatchRunOptions) (ifc.BaremetalBatchResult, error) {
 	log.Debugf("Running operation '%s' against hosts selected by selector '%v'", op, selector)
+	result := ifc.BaremetalBatchResult{Operation: op}
 
-	hostAction, err := action(ctx, op)
+	hostAction, err := action(ctx, op, opts.OperationOptions)
 	if err != nil {
-		return err
+		return result, err
 	}
 
-	hosts, err := i.Select(selector)
+	hosts, err := i.selectHosts(selector)
 	if err != nil {
-		return err
+		return result, err
 	}
 
 	if len(hosts) == 0 {
 		log.Printf("Filtering using selector %v' didn't return any hosts to perform operation '%s'", selector, op)
-		return ErrNoBaremetalHostsFound{Selector: selector}
+		return result, ErrNoBaremetalHostsFound{Selector: selector}
 	}
 
-	// TODO add concurent action execution
-	// TODO consider adding FailFast flag to BaremetalBatchRunOptions that would allow
-	// not fail on first error, but accumulate errors and return them at the end.
+	hosts, skipped := skipHosts(op, hosts, opts.OperationOptions)
+	result.Hosts = append(runBatch(hosts, func(client remoteifc.Client) error {
+		defer CloseSession(ctx, client)
+		return hostAction(client)
+	}, opts), skipped...)
+	if failed := result.Failed(); len(failed) > 0 && !opts.ContinueOnError {
+		return result, ErrBaremetalOperationFailed{
+			Operation: op,
+			Failed:    failed,
+			Total:     len(result.Hosts),
+		}
+	}
+	return result, nil
+}
+
+// skipHosts filters out the hosts the operation should not be performed against, the hosts
+// that are filtered out are returned as skipped results
+func skipHosts(
+	op ifc.BaremetalOperation,
+	hosts []Host,
+	opts ifc.BaremetalOperationOptions) ([]Host, []ifc.BaremetalHostResult) {
+	if op != ifc.BaremetalOperationApplyBIOSSettings || !opts.SkipUnreferencedHosts {
+		return hosts, nil
+	}
+
+	selected := []Host{}
+	skipped := []ifc.BaremetalHostResult{}
 	for _, host := range hosts {
-		if hostErr := hostAction(host); hostErr != nil {
-			return hostErr
+		if host.BIOSSettings != nil {
+			selected = append(selected, host)
+			continue
+		}
+		log.Printf("Host '%s' in namespace '%s' doesn't reference BIOS settings, skipping",
+			host.Name, host.Namespace)
+		skipped = append(skipped, ifc.BaremetalHostResult{
+			Name:      host.Name,
+			Namespace: host.Namespace,
+			NodeID:    host.NodeID(),
+			Skipped:   true,
+		})
+	}
+	return selected, skipped
+}
+
+// RunBatch performs hostAction against the hosts, at most opts.MaxConcurrency at a time, hostAction is called
+// with the index of the host in hosts. Results are returned in the same order as hosts
+func RunBatch(
+	hosts []remoteifc.Client,
+	hostAction func(idx int, client remoteifc.Client) error,
+	opts ifc.BaremetalBatchRunOptions) []ifc.BaremetalHostResult {
+	results := make([]ifc.BaremetalHostResult, len(hosts))
+	for idx, client := range hosts {
+		results[idx] = ifc.BaremetalHostResult{NodeID: client.NodeID(), Skipped: true}
+		if host, ok := client.(Host); ok {
+			results[idx].Name = host.Name
+			results[idx].Namespace = host.Namespace
+		}
+	}
+
+	workers := opts.MaxConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		mu     sync.Mutex
+		failed bool
+		wg     sync.WaitGroup
+	)
+	slots := make(chan struct{}, workers)
+	for idx := range hosts {
+		// wait for a free slot before checking for failures, so that FailFast
+		// accounts for all the hosts processed so far
+		slots <- struct{}{}
+		mu.Lock()
+		stop := opts.FailFast && failed
+		mu.Unlock()
+		if stop {
+			log.Debugf("Operation failed against one of the hosts, skipping remaining hosts")
+			break
 		}
+
+		wg.Add(1)
+		go func(idx int) {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			log.Debugf("Performing operation against host with node id '%s'", results[idx].NodeID)
+			hostErr := hostAction(idx, hosts[idx])
+			mu.Lock()
+			defer mu.Unlock()
+			results[idx].Skipped = false
+			results[idx].Error = hostErr
+			if hostErr != nil {
+				failed = true
+			}
+		}(idx)
 	}
+	wg.Wait()
+
+	return results
+}
 
-	return nil
+// runBatch performs hostAction against the hosts built from BaremetalHost documents, see RunBatch
+func runBatch(
+	hosts []Host,
+	hostAction func(remoteifc.Client) error,
+	opts ifc.BaremetalBatchRunOptions) []ifc.BaremetalHostResult {
+	clients := make([]remoteifc.Client, len(hosts))
+	for idx, host := range hosts {
+		clients[idx] = host
+	}
+	return RunBatch(clients, func(_ int, client remoteifc.Client) error {
+		return hostAction(client)
+	}, opts)
 }
 
 // Host implements baremetal host interface
 type Host struct {
 	remoteifc.Client
+
+	// Name and Namespace of BaremetalHost document the host is built from
+	Name      string
+	Namespace string
+	// BMCAddress is the address of the host BMC with the password redacted if it is part of the address
+	BMCAddress string
+	// BIOSSettings are desired BIOS attributes from BIOSSettings document referenced by the host,
+	// nil if the host doesn't reference any
+	BIOSSettings map[string]interface{}
+	// CredentialsName is the name of the Secret holding BMC credentials of the host
+	CredentialsName string
+
+	bmcUsername string
+	bmcPassword string
 }
 
 var _ remoteifc.Client = Host{}
+var _ remoteifc.SessionCloser = Host{}
+var _ remoteifc.HardwareInspector = Host{}
+var _ remoteifc.FirmwareManager = Host{}
+var _ remoteifc.CredentialsManager = Host{}
+var _ remoteifc.PasswordLengthLimiter = Host{}
+
+// CloseSession closes BMC session of the host if remote client keeps one
+func (h Host) CloseSession(ctx context.Context) error {
+	return remoteifc.CloseSession(ctx, h.Client)
+}
+
+// HardwareDetails collects hardware inventory of the host if remote client supports it
+func (h Host) HardwareDetails(ctx context.Context) (*remoteifc.HardwareDetails, error) {
+	return remoteifc.GetHardwareDetails(ctx, h.Client)
+}
+
+// FirmwareInventory returns firmware components of the host if remote client supports it
+func (h Host) FirmwareInventory(ctx context.Context) ([]remoteifc.FirmwareComponent, error) {
+	return remoteifc.GetFirmwareInventory(ctx, h.Client)
+}
+
+// UpdateFirmware installs firmware image on the host if remote client supports it
+func (h Host) UpdateFirmware(ctx context.Context, imageURL string) error {
+	return remoteifc.UpdateFirmware(ctx, h.Client, imageURL)
+}
+
+// UpdatePassword changes BMC password of the host if remote client supports it
+func (h Host) UpdatePassword(ctx context.Context, newPassword string) error {
+	return remoteifc.UpdatePassword(ctx, h.Client, newPassword)
+}
+
+// VerifyCredentials logs in to BMC of the host with given credentials if remote client supports it
+func (h Host) VerifyCredentials(ctx context.Context, username, password string) error {
+	return remoteifc.VerifyCredentials(ctx, h.Client, username, password)
+}
+
+// MaxPasswordLength returns the maximum length of BMC password of the host, the limit of IPMI applies if either
+// the remote client or BMC address of the host uses IPMI, zero means that the length is not limited
+func (h Host) MaxPasswordLength() int {
+	if strings.HasPrefix(h.BMCAddress, ipmi.ClientType+"://") {
+		return ipmi.MaxPasswordLength
+	}
+	return remoteifc.MaxPasswordLength(h.Client)
+}
+
+// CloseSession closes BMC session of the host, failure to close the session is logged and doesn't fail the operation
+func CloseSession(ctx context.Context, client remoteifc.Client) {
+	if err := remoteifc.CloseSession(ctx, client); err != nil {
+		log.Debugf("Failed to close session with host '%s': %v", client.NodeID(), err)
+	}
+}
 
 func (i Inventory) newHost(doc document.Document) (Host, error) {
 	address, err := document.GetBMHBMCAddress(doc)
@@ -135,35 +326,79 @@ func (i Inventory) newHost(doc document.Document) (Host, error) {
 		return Host{}, err
 	}
 
-	var clientFactory remoteifc.ClientFactory
-	switch i.mgmtCfg.Type {
-	case redfish.ClientType:
-		clientFactory = redfish.ClientFactory
-	case redfishdell.ClientType:
-		clientFactory = redfishdell.ClientFactory
-	default:
-		return Host{}, ErrRemoteDriverNotSupported{
-			BMHName:      doc.GetName(),
-			BMHNamespace: doc.GetNamespace(),
-			RemoteType:   i.mgmtCfg.Type,
-		}
+	credentialsName, err := doc.GetString("spec.bmc.credentialsName")
+	if err != nil {
+		return Host{}, err
+	}
+
+	biosSettings, err := document.GetBMHBIOSSettings(doc, i.inventoryBundle)
+	if err != nil {
+		return Host{}, err
+	}
+
+	mgmtCfg, err := i.managementConfig(doc)
+	if err != nil {
+		return Host{}, err
+	}
+
+	factory, err := clientFactory(doc, mgmtCfg.Type)
+	if err != nil {
+		return Host{}, err
 	}
 
-	client, err := clientFactory(
+	client, err := factory(
 		address,
-		i.mgmtCfg.Insecure,
-		i.mgmtCfg.UseProxy,
+		mgmtCfg.Insecure,
+		mgmtCfg.UseProxy,
 		username,
 		password,
-		i.mgmtCfg.SystemActionRetries,
-		i.mgmtCfg.SystemRebootDelay)
+		mgmtCfg.SystemActionRetries,
+		mgmtCfg.SystemRebootDelay)
 	if err != nil {
 		return Host{}, err
 	}
-	return Host{Client: client}, nil
+
+	if err = i.setRootCAs(doc, client); err != nil {
+		return Host{}, err
+	}
+	remoteifc.SetBootMode(client, remoteifc.BootMode(document.GetBMHBootMode(doc)))
+	return Host{
+		Client:          client,
+		Name:            doc.GetName(),
+		Namespace:       doc.GetNamespace(),
+		BMCAddress:      redactURL(address),
+		BIOSSettings:    biosSettings,
+		CredentialsName: credentialsName,
+		bmcUsername:     username,
+		bmcPassword:     password,
+	}, nil
+}
+
+func clientFactory(doc document.Document, remoteType string) (remoteifc.ClientFactory, error) {
+	switch remoteType {
+	case redfish.ClientType:
+		return redfish.ClientFactory, nil
+	case redfishdell.ClientType:
+		return redfishdell.ClientFactory, nil
+	case redfishhpe.ClientType:
+		return redfishhpe.ClientFactory, nil
+	case redfishsupermicro.ClientType:
+		return redfishsupermicro.ClientFactory, nil
+	case ipmi.ClientType:
+		return ipmi.ClientFactory, nil
+	default:
+		return nil, ErrRemoteDriverNotSupported{
+			BMHName:      doc.GetName(),
+			BMHNamespace: doc.GetNamespace(),
+			RemoteType:   remoteType,
+		}
+	}
 }
 
-func action(ctx context.Context, op ifc.BaremetalOperation) (func(remoteifc.Client) error, error) {
+func action(
+	ctx context.Context,
+	op ifc.BaremetalOperation,
+	opts ifc.BaremetalOperationOptions) (func(remoteifc.Client) error, error) {
 	switch op {
 	case ifc.BaremetalOperationReboot:
 		return func(host remoteifc.Client) error {
@@ -181,6 +416,30 @@ func action(ctx context.Context, op ifc.BaremetalOperation) (func(remoteifc.Clie
 		return func(host remoteifc.Client) error {
 			return host.EjectVirtualMedia(ctx)
 		}, nil
+	case ifc.BaremetalOperationPowerCycle:
+		return func(host remoteifc.Client) error {
+			return host.SystemPowerCycle(ctx)
+		}, nil
+	case ifc.BaremetalOperationSetBootDevice:
+		return func(host remoteifc.Client) error {
+			return host.SetBootDevice(ctx, opts.BootDevice, opts.Persistent)
+		}, nil
+	case ifc.BaremetalOperationWaitForPowerState:
+		return func(host remoteifc.Client) error {
+			return host.WaitForPowerState(ctx, opts.PowerState)
+		}, nil
+	case ifc.BaremetalOperationApplyBIOSSettings:
+		return func(host remoteifc.Client) error {
+			applier, ok := host.(biosApplier)
+			if !ok {
+				return ErrBaremetalOperationNotSupported{Operation: op}
+			}
+			return applier.ApplyBIOSSettings(ctx, !opts.SkipReboot)
+		}, nil
+	case ifc.BaremetalOperationUpdateFirmware:
+		return func(host remoteifc.Client) error {
+			return remoteifc.UpdateFirmware(ctx, host, opts.FirmwareImageURL)
+		}, nil
 	default:
 		return nil, ErrBaremetalOperationNotSupported{Operation: op}
 	}
@@ -16,7 +16,10 @@ package baremetal
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,6 +27,9 @@ import (
 	"opendev.org/airship/airshipctl/pkg/config"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
 )
 
 func TestSelect(t *testing.T) {
@@ -51,6 +57,24 @@ func TestSelect(t *testing.T) {
 			expectedErr:  "not supported",
 			selector:     (ifc.BaremetalHostSelector{}).ByLabel("host-group=control-plane"),
 		},
+		{
+			name:          "success hpe remote driver",
+			remoteDriver:  "redfish-hpe",
+			expectedHosts: 1,
+			selector:      (ifc.BaremetalHostSelector{}).ByName("master-0"),
+		},
+		{
+			name:          "success supermicro remote driver",
+			remoteDriver:  "redfish-supermicro",
+			expectedHosts: 1,
+			selector:      (ifc.BaremetalHostSelector{}).ByName("master-0"),
+		},
+		{
+			name:         "error ipmi driver with redfish address",
+			remoteDriver: "ipmi",
+			expectedErr:  "unsupported scheme 'redfish+http'",
+			selector:     (ifc.BaremetalHostSelector{}).ByName("master-0"),
+		},
 		{
 			name:         "error no credentials",
 			remoteDriver: "redfish",
@@ -114,7 +138,9 @@ func TestSelectOne(t *testing.T) {
 				assert.Contains(t, err.Error(), tt.expectedErr)
 			} else {
 				require.NoError(t, err)
-				assert.NotNil(t, host)
+				require.IsType(t, Host{}, host)
+				assert.Equal(t, "redfish+http://nolocalhost:32201/redfish/v1/Systems/ephemeral",
+					host.(Host).BMCAddress)
 			}
 		})
 	}
@@ -156,7 +182,7 @@ func TestRunAction(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mgmCfg := config.ManagementConfiguration{Type: tt.remoteDriver}
 			inventory := NewInventory(&mgmCfg, bundle)
-			err := inventory.RunOperation(
+			_, err := inventory.RunOperation(
 				context.Background(),
 				tt.operation,
 				tt.selector,
@@ -171,6 +197,167 @@ func TestRunAction(t *testing.T) {
 	}
 }
 
+func TestRunActionResult(t *testing.T) {
+	bundle := testBundle(t)
+	mgmCfg := config.ManagementConfiguration{Type: "redfish"}
+	inventory := NewInventory(&mgmCfg, bundle)
+
+	t.Run("error aggregated per host", func(t *testing.T) {
+		result, err := inventory.RunOperation(
+			context.Background(),
+			ifc.BaremetalOperationPowerOn,
+			(ifc.BaremetalHostSelector{}).ByLabel("host-group=control-plane"),
+			ifc.BaremetalBatchRunOptions{MaxConcurrency: 2})
+		require.Error(t, err)
+		assert.IsType(t, ErrBaremetalOperationFailed{}, err)
+		assert.Equal(t, ifc.BaremetalOperationPowerOn, result.Operation)
+		require.Len(t, result.Hosts, 2)
+		assert.Len(t, result.Failed(), 2)
+		for _, host := range result.Hosts {
+			assert.NotEmpty(t, host.Name)
+			assert.False(t, host.Skipped)
+			assert.Contains(t, host.Error.Error(), "HTTP request failed")
+		}
+	})
+
+	t.Run("success continue on error", func(t *testing.T) {
+		result, err := inventory.RunOperation(
+			context.Background(),
+			ifc.BaremetalOperationPowerOn,
+			(ifc.BaremetalHostSelector{}).ByLabel("host-group=control-plane"),
+			ifc.BaremetalBatchRunOptions{ContinueOnError: true})
+		require.NoError(t, err)
+		assert.Len(t, result.Failed(), 2)
+	})
+
+	t.Run("hosts without BIOS settings skipped", func(t *testing.T) {
+		result, err := inventory.RunOperation(
+			context.Background(),
+			ifc.BaremetalOperationApplyBIOSSettings,
+			(ifc.BaremetalHostSelector{}).ByLabel("host-group=control-plane"),
+			ifc.BaremetalBatchRunOptions{
+				OperationOptions: ifc.BaremetalOperationOptions{SkipUnreferencedHosts: true},
+			})
+		require.NoError(t, err)
+		require.Len(t, result.Hosts, 2)
+		for _, host := range result.Hosts {
+			assert.True(t, host.Skipped)
+			assert.NoError(t, host.Error)
+		}
+	})
+}
+
+type fakeClient struct {
+	remoteifc.Client
+
+	nodeID string
+	err    error
+
+	mu      *sync.Mutex
+	running *int
+	maxSeen *int
+}
+
+func (c fakeClient) NodeID() string {
+	return c.nodeID
+}
+
+func (c fakeClient) SystemPowerOn(context.Context) error {
+	c.mu.Lock()
+	*c.running++
+	if *c.running > *c.maxSeen {
+		*c.maxSeen = *c.running
+	}
+	c.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	c.mu.Lock()
+	*c.running--
+	c.mu.Unlock()
+	return c.err
+}
+
+func TestRunBatch(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           ifc.BaremetalBatchRunOptions
+		failingHosts   map[int]bool
+		maxConcurrency int
+		expectSkipped  int
+		expectFailed   int
+	}{
+		{
+			name:           "sequential by default",
+			maxConcurrency: 1,
+		},
+		{
+			name:           "concurrent",
+			opts:           ifc.BaremetalBatchRunOptions{MaxConcurrency: 3},
+			maxConcurrency: 3,
+		},
+		{
+			name:           "all hosts processed on error",
+			failingHosts:   map[int]bool{0: true, 4: true},
+			maxConcurrency: 1,
+			expectFailed:   2,
+		},
+		{
+			name:           "fail fast skips remaining hosts",
+			opts:           ifc.BaremetalBatchRunOptions{FailFast: true},
+			failingHosts:   map[int]bool{1: true},
+			maxConcurrency: 1,
+			expectFailed:   1,
+			expectSkipped:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mu               sync.Mutex
+				running, maxSeen int
+			)
+			hosts := make([]Host, 6)
+			for idx := range hosts {
+				client := fakeClient{
+					nodeID:  fmt.Sprintf("node-%d", idx),
+					mu:      &mu,
+					running: &running,
+					maxSeen: &maxSeen,
+				}
+				if tt.failingHosts[idx] {
+					client.err = fmt.Errorf("power on failed")
+				}
+				hosts[idx] = Host{Client: client, Name: fmt.Sprintf("host-%d", idx), Namespace: "metal3"}
+			}
+
+			hostAction, err := action(context.Background(), ifc.BaremetalOperationPowerOn,
+				ifc.BaremetalOperationOptions{})
+			require.NoError(t, err)
+
+			results := runBatch(hosts, hostAction, tt.opts)
+			require.Len(t, results, len(hosts))
+			assert.Equal(t, tt.maxConcurrency, maxSeen)
+
+			var skipped, failed int
+			for idx, result := range results {
+				assert.Equal(t, hosts[idx].Name, result.Name)
+				assert.Equal(t, hosts[idx].NodeID(), result.NodeID)
+				if result.Skipped {
+					skipped++
+				}
+				if result.Error != nil {
+					failed++
+				}
+			}
+			assert.Equal(t, tt.expectSkipped, skipped)
+			assert.Equal(t, tt.expectFailed, failed)
+		})
+	}
+}
+
 func TestAction(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -193,6 +380,26 @@ func TestAction(t *testing.T) {
 			name:   "reboot",
 			action: ifc.BaremetalOperationReboot,
 		},
+		{
+			name:   "powercycle",
+			action: ifc.BaremetalOperationPowerCycle,
+		},
+		{
+			name:   "setbootdevice",
+			action: ifc.BaremetalOperationSetBootDevice,
+		},
+		{
+			name:   "waitforpowerstate",
+			action: ifc.BaremetalOperationWaitForPowerState,
+		},
+		{
+			name:   "applybiossettings",
+			action: ifc.BaremetalOperationApplyBIOSSettings,
+		},
+		{
+			name:   "updatefirmware",
+			action: ifc.BaremetalOperationUpdateFirmware,
+		},
 		{
 			name:      "reboot",
 			action:    ifc.BaremetalOperation("not supported"),
@@ -202,7 +409,7 @@ func TestAction(t *testing.T) {
 	for _, tt := range tests {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			actionFunc, err := action(context.Background(), tt.action)
+			actionFunc, err := action(context.Background(), tt.action, ifc.BaremetalOperationOptions{})
 			if tt.expectErr {
 				assert.Error(t, err)
 			} else {
@@ -215,6 +422,33 @@ func TestAction(t *testing.T) {
 	}
 }
 
+func TestActionOperationOptions(t *testing.T) {
+	opts := ifc.BaremetalOperationOptions{
+		BootDevice: remoteifc.BootDevicePXE,
+		PowerState: power.StatusOff,
+
+		FirmwareImageURL: "http://images.example.com/bmc.bin",
+	}
+
+	host := &redfishutils.MockClient{}
+	host.On("SetBootDevice", remoteifc.BootDevicePXE, false).Once().Return(nil)
+	host.On("WaitForPowerState", power.StatusOff).Once().Return(fmt.Errorf("timeout"))
+	host.On("UpdateFirmware", "http://images.example.com/bmc.bin").Once().Return(nil)
+	defer host.AssertExpectations(t)
+
+	setBootDevice, err := action(context.Background(), ifc.BaremetalOperationSetBootDevice, opts)
+	require.NoError(t, err)
+	assert.NoError(t, setBootDevice(host))
+
+	waitForPowerState, err := action(context.Background(), ifc.BaremetalOperationWaitForPowerState, opts)
+	require.NoError(t, err)
+	assert.EqualError(t, waitForPowerState(host), "timeout")
+
+	updateFirmware, err := action(context.Background(), ifc.BaremetalOperationUpdateFirmware, opts)
+	require.NoError(t, err)
+	assert.NoError(t, updateFirmware(Host{Client: host}))
+}
+
 func testBundle(t *testing.T) document.Bundle {
 	t.Helper()
 	bundle, err := document.NewBundleByPath("testdata")
@@ -0,0 +1,114 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+// BIOSAttribute holds current and desired values of BIOS attribute of the host
+type BIOSAttribute struct {
+	Name    string
+	Current interface{}
+	// Desired is nil if the attribute is not managed by BIOS settings referenced by the host
+	Desired interface{}
+}
+
+// Drifted returns true if current value of the attribute differs from the desired one
+func (a BIOSAttribute) Drifted() bool {
+	return a.Desired != nil && !biosValuesEqual(a.Current, a.Desired)
+}
+
+type biosApplier interface {
+	ApplyBIOSSettings(ctx context.Context, reboot bool) error
+}
+
+var _ biosApplier = Host{}
+
+// CompareBIOSSettings returns BIOS attributes of the host sorted by name. If the host references BIOS settings
+// only the attributes managed by them are returned along with desired values, otherwise all current
+// attributes of the host are returned.
+func (h Host) CompareBIOSSettings(ctx context.Context) ([]BIOSAttribute, error) {
+	current, err := remoteifc.GetBIOSAttributes(ctx, h.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := []BIOSAttribute{}
+	if h.BIOSSettings == nil {
+		for name, value := range current {
+			attributes = append(attributes, BIOSAttribute{Name: name, Current: value})
+		}
+	} else {
+		for name, desired := range h.BIOSSettings {
+			attributes = append(attributes, BIOSAttribute{Name: name, Current: current[name], Desired: desired})
+		}
+	}
+
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+	return attributes, nil
+}
+
+// ApplyBIOSSettings schedules drifted BIOS attributes of the host to be changed to desired values and
+// reboots the host to apply them if reboot is true. Nothing is done if the host is already in sync.
+func (h Host) ApplyBIOSSettings(ctx context.Context, reboot bool) error {
+	if h.BIOSSettings == nil {
+		return ErrBIOSSettingsNotReferenced{BMHName: h.Name, BMHNamespace: h.Namespace}
+	}
+
+	attributes, err := h.CompareBIOSSettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := make(map[string]interface{})
+	for _, attribute := range attributes {
+		if attribute.Drifted() {
+			log.Printf("BIOS attribute '%s' of host '%s' in namespace '%s' is '%v', desired '%v'",
+				attribute.Name, h.Name, h.Namespace, attribute.Current, attribute.Desired)
+			changes[attribute.Name] = attribute.Desired
+		}
+	}
+	if len(changes) == 0 {
+		log.Debugf("BIOS settings of host '%s' in namespace '%s' are in sync", h.Name, h.Namespace)
+		return nil
+	}
+
+	log.Printf("Scheduling %d BIOS attribute change(s) on host '%s' in namespace '%s'",
+		len(changes), h.Name, h.Namespace)
+	if err = remoteifc.SetBIOSAttributes(ctx, h.Client, changes); err != nil {
+		return err
+	}
+
+	if !reboot {
+		log.Printf("BIOS settings of host '%s' in namespace '%s' will be applied on the next reboot",
+			h.Name, h.Namespace)
+		return nil
+	}
+	return h.RebootSystem(ctx)
+}
+
+// biosValuesEqual compares values of BIOS attribute, numbers decoded from BMC response and from
+// documents may have different types, so the values are compared by their string representation
+func biosValuesEqual(current, desired interface{}) bool {
+	return fmt.Sprint(current) == fmt.Sprint(desired)
+}
@@ -0,0 +1,157 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+var testBIOSAttributes = map[string]interface{}{
+	"BootMode":        "Uefi",
+	"ProcCoreDisable": float64(0),
+	"ProcTurboMode":   "Enabled",
+}
+
+func TestCompareBIOSSettings(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		expected []BIOSAttribute
+	}{
+		{
+			name: "host without settings",
+			expected: []BIOSAttribute{
+				{Name: "BootMode", Current: "Uefi"},
+				{Name: "ProcCoreDisable", Current: float64(0)},
+				{Name: "ProcTurboMode", Current: "Enabled"},
+			},
+		},
+		{
+			name:     "host with settings",
+			settings: map[string]interface{}{"ProcTurboMode": "Disabled", "ProcCoreDisable": 0},
+			expected: []BIOSAttribute{
+				{Name: "ProcCoreDisable", Current: float64(0), Desired: 0},
+				{Name: "ProcTurboMode", Current: "Enabled", Desired: "Disabled"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := &redfishutils.MockClient{}
+			client.On("BIOSAttributes").Once().Return(testBIOSAttributes, nil)
+			host := Host{Client: client, Name: "master-0", Namespace: "metal3", BIOSSettings: tt.settings}
+
+			attributes, err := host.CompareBIOSSettings(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, attributes)
+			assert.False(t, attributes[0].Drifted())
+		})
+	}
+}
+
+func TestApplyBIOSSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    map[string]interface{}
+		reboot      bool
+		setErr      error
+		expectSet   map[string]interface{}
+		expectErr   string
+		expectBoot  bool
+		skipCurrent bool
+	}{
+		{
+			name:        "host without settings",
+			skipCurrent: true,
+			expectErr:   "doesn't reference BIOS settings",
+		},
+		{
+			name:     "settings in sync",
+			settings: map[string]interface{}{"ProcTurboMode": "Enabled", "ProcCoreDisable": 0},
+			reboot:   true,
+		},
+		{
+			name:       "drifted settings with reboot",
+			settings:   map[string]interface{}{"ProcTurboMode": "Disabled", "ProcCoreDisable": 0},
+			reboot:     true,
+			expectSet:  map[string]interface{}{"ProcTurboMode": "Disabled"},
+			expectBoot: true,
+		},
+		{
+			name:      "drifted settings without reboot",
+			settings:  map[string]interface{}{"ProcTurboMode": "Disabled"},
+			expectSet: map[string]interface{}{"ProcTurboMode": "Disabled"},
+		},
+		{
+			name:      "error setting attributes",
+			settings:  map[string]interface{}{"ProcTurboMode": "Disabled"},
+			reboot:    true,
+			setErr:    fmt.Errorf("attribute is read only"),
+			expectSet: map[string]interface{}{"ProcTurboMode": "Disabled"},
+			expectErr: "attribute is read only",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client := &redfishutils.MockClient{}
+			if !tt.skipCurrent {
+				client.On("BIOSAttributes").Once().Return(testBIOSAttributes, nil)
+			}
+			if tt.expectSet != nil {
+				client.On("SetBIOSAttributes", tt.expectSet).Once().Return(tt.setErr)
+			}
+			if tt.expectBoot {
+				client.On("RebootSystem").Once().Return(nil)
+			}
+			defer client.AssertExpectations(t)
+			host := Host{Client: client, Name: "master-0", Namespace: "metal3", BIOSSettings: tt.settings}
+
+			err := host.ApplyBIOSSettings(context.Background(), tt.reboot)
+			if tt.expectErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestActionApplyBIOSSettings(t *testing.T) {
+	client := &redfishutils.MockClient{}
+	client.On("BIOSAttributes").Once().Return(testBIOSAttributes, nil)
+	client.On("SetBIOSAttributes", map[string]interface{}{"BootMode": "Bios"}).Once().Return(nil)
+	defer client.AssertExpectations(t)
+
+	applyBIOSSettings, err := action(context.Background(), ifc.BaremetalOperationApplyBIOSSettings,
+		ifc.BaremetalOperationOptions{SkipReboot: true})
+	require.NoError(t, err)
+	assert.NoError(t, applyBIOSSettings(Host{Client: client, BIOSSettings: map[string]interface{}{"BootMode": "Bios"}}))
+
+	// remote clients which are not built from BaremetalHost documents can't reference BIOS settings
+	assert.Error(t, applyBIOSSettings(client))
+}
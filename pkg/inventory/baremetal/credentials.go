@@ -0,0 +1,293 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"bytes"
+	"context"
+	b64 "encoding/base64"
+	"sync"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/ipmi"
+	"opendev.org/airship/airshipctl/pkg/secret/generate"
+)
+
+// DefaultPasswordLength is the length of generated BMC passwords if the length is not specified,
+// passwords of this length are supported by IPMI as well
+const DefaultPasswordLength = ipmi.MaxPasswordLength
+
+// credentialsRevertTimeout is the time given to revert BMC password of the host, the revert is performed
+// after the rotation, so it doesn't depend on the rotation deadline
+const credentialsRevertTimeout = time.Minute
+
+// RotateCredentials generates new BMC password for every credentials Secret referenced by the hosts matched
+// by the selector and changes the password on all the hosts referencing the Secret, including the hosts not
+// matched by the selector. Before any password is changed, all the hosts are checked to support password
+// change and the length of the password. The new password is verified by logging in to the BMC with it.
+// If the password wasn't changed on any of the hosts sharing the Secret, the hosts that already changed it
+// are reverted to the current password. Returned bundle holds updated Secret documents of the credentials
+// rotated on all their hosts, or not reverted on some of them, it has to be written to the inventory even
+// if error is returned.
+func (i Inventory) RotateCredentials(
+	ctx context.Context,
+	selector ifc.BaremetalHostSelector,
+	opts ifc.BaremetalBatchRunOptions) (ifc.BaremetalBatchResult, document.Bundle, error) {
+	log.Debugf("Rotating BMC credentials of hosts selected by selector '%v'", selector)
+	result := ifc.BaremetalBatchResult{Operation: ifc.BaremetalOperationRotateCredentials}
+
+	hosts, err := i.credentialsHosts(selector)
+	if err != nil {
+		return result, nil, err
+	}
+
+	length := opts.OperationOptions.PasswordLength
+	if length <= 0 {
+		length = DefaultPasswordLength
+	}
+	if err = checkCredentialsHosts(hosts, length); err != nil {
+		return result, nil, err
+	}
+
+	engine := generate.NewEncryptionKeyEngine(nil)
+	var rotated map[string]string
+	result.Hosts, rotated = rotateCredentials(ctx, hosts, func() string {
+		return engine.GeneratePassword(length)
+	}, opts)
+	for _, host := range hosts {
+		CloseSession(ctx, host)
+	}
+
+	buf := &bytes.Buffer{}
+	for _, host := range hosts {
+		password, ok := rotated[host.CredentialsName]
+		if !ok {
+			continue
+		}
+		delete(rotated, host.CredentialsName)
+
+		if err = i.writeRotatedSecret(buf, host.CredentialsName, password); err != nil {
+			return result, nil, err
+		}
+	}
+
+	secrets, err := document.NewBundleFromBytes(buf.Bytes())
+	if err != nil {
+		return result, nil, err
+	}
+
+	if failed := result.Failed(); len(failed) > 0 && !opts.ContinueOnError {
+		return result, secrets, ErrBaremetalOperationFailed{
+			Operation: result.Operation,
+			Failed:    failed,
+			Total:     len(hosts),
+		}
+	}
+	return result, secrets, nil
+}
+
+// CredentialsSecrets returns credentials Secrets that are rotated by RotateCredentials for the hosts matched
+// by the selector, values of the Secrets are redacted. It allows to check that the Secrets can be written
+// to the inventory before BMC passwords are changed.
+func (i Inventory) CredentialsSecrets(selector ifc.BaremetalHostSelector) (document.Bundle, error) {
+	hosts, err := i.credentialsHosts(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	written := make(map[string]bool)
+	for _, host := range hosts {
+		if written[host.CredentialsName] {
+			continue
+		}
+		written[host.CredentialsName] = true
+
+		if err = i.writeSecret(buf, host.CredentialsName, redactSecret); err != nil {
+			return nil, err
+		}
+	}
+	return document.NewBundleFromBytes(buf.Bytes())
+}
+
+// checkCredentialsHosts makes sure that BMC password of every host can be changed to the password of given
+// length, so that the hosts are checked before the password is changed on any of them
+func checkCredentialsHosts(hosts []Host, length int) error {
+	for _, host := range hosts {
+		if _, ok := host.Client.(remoteifc.CredentialsManager); !ok {
+			return remoteifc.ErrCredentialsManagementNotSupported{NodeID: host.NodeID()}
+		}
+		if maxLength := host.MaxPasswordLength(); maxLength > 0 && length > maxLength {
+			return ErrPasswordTooLong{
+				BMHName:      host.Name,
+				BMHNamespace: host.Namespace,
+				Length:       length,
+				MaxLength:    maxLength,
+			}
+		}
+	}
+	return nil
+}
+
+// credentialsHosts returns hosts matched by the selector together with all the hosts sharing credentials
+// Secrets with them
+func (i Inventory) credentialsHosts(selector ifc.BaremetalHostSelector) ([]Host, error) {
+	selected, err := i.selectHosts(selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(selected) == 0 {
+		return nil, ErrNoBaremetalHostsFound{Selector: selector}
+	}
+
+	secrets := make(map[string]bool)
+	isSelected := make(map[string]bool)
+	for _, host := range selected {
+		secrets[host.CredentialsName] = true
+		isSelected[host.Namespace+"/"+host.Name] = true
+	}
+
+	docs, err := i.inventoryBundle.Select(toDocumentSelector(ifc.BaremetalHostSelector{}))
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := []Host{}
+	for _, doc := range docs {
+		credentialsName, err := doc.GetString("spec.bmc.credentialsName")
+		if err != nil || !secrets[credentialsName] {
+			continue
+		}
+		if !isSelected[doc.GetNamespace()+"/"+doc.GetName()] {
+			log.Printf("Host '%s' in namespace '%s' shares credentials Secret '%s' with selected hosts, "+
+				"its credentials are rotated as well", doc.GetName(), doc.GetNamespace(), credentialsName)
+		}
+		host, err := i.newHost(doc)
+		if err != nil {
+			return nil, err
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
+
+// rotateCredentials changes BMC password of the hosts to the password generated for their credentials Secret
+// and returns new passwords of the Secrets that were rotated on all their hosts. Hosts sharing the Secret with
+// any host that failed are reverted to the current password, if the password of any of them can't be reverted
+// the new password of the Secret is returned as well, so that the host is not locked out.
+func rotateCredentials(
+	ctx context.Context,
+	hosts []Host,
+	newPassword func() string,
+	opts ifc.BaremetalBatchRunOptions) ([]ifc.BaremetalHostResult, map[string]string) {
+	generated := make(map[string]string)
+	for _, host := range hosts {
+		if _, ok := generated[host.CredentialsName]; !ok {
+			generated[host.CredentialsName] = newPassword()
+		}
+	}
+
+	var changed sync.Map
+	results := runBatch(hosts, func(client remoteifc.Client) error {
+		host, ok := client.(Host)
+		if !ok {
+			return ErrBaremetalOperationNotSupported{Operation: ifc.BaremetalOperationRotateCredentials}
+		}
+		password := generated[host.CredentialsName]
+		if err := remoteifc.UpdatePassword(ctx, host, password); err != nil {
+			return err
+		}
+		changed.Store(host.Namespace+"/"+host.Name, true)
+		return remoteifc.VerifyCredentials(ctx, host, host.bmcUsername, password)
+	}, opts)
+
+	failed := make(map[string]bool)
+	for idx, result := range results {
+		if result.Skipped || result.Error != nil {
+			failed[hosts[idx].CredentialsName] = true
+		}
+	}
+	passwords := make(map[string]string)
+	for credentialsName, password := range generated {
+		if !failed[credentialsName] {
+			passwords[credentialsName] = password
+		}
+	}
+
+	// the revert is performed even if the rotation timed out
+	revertCtx, cancel := context.WithTimeout(context.Background(), credentialsRevertTimeout)
+	defer cancel()
+	for idx, host := range hosts {
+		if !failed[host.CredentialsName] {
+			continue
+		}
+		if _, ok := changed.Load(host.Namespace + "/" + host.Name); !ok {
+			continue
+		}
+		log.Printf("Reverting BMC password of host '%s' in namespace '%s', credentials Secret '%s' "+
+			"is not rotated on all the hosts", host.Name, host.Namespace, host.CredentialsName)
+		rotationErr := results[idx].Error
+		if rotationErr == nil {
+			rotationErr = ErrCredentialsNotRotated{CredentialsName: host.CredentialsName}
+		}
+		revertErr := remoteifc.UpdatePassword(revertCtx, host, host.bmcPassword)
+		if revertErr != nil {
+			log.Printf("Failed to revert BMC password of host '%s' in namespace '%s', credentials Secret '%s' "+
+				"keeps the new password, hosts that were reverted keep the current password",
+				host.Name, host.Namespace, host.CredentialsName)
+			passwords[host.CredentialsName] = generated[host.CredentialsName]
+		}
+		results[idx].Error = ErrCredentialsReverted{
+			Err:       rotationErr,
+			RevertErr: revertErr,
+		}
+	}
+	return results, passwords
+}
+
+// writeRotatedSecret writes a copy of the credentials Secret with the password set to the new one
+func (i Inventory) writeRotatedSecret(buf *bytes.Buffer, credentialsName, password string) error {
+	return i.writeSecret(buf, credentialsName, func(obj map[string]interface{}) {
+		setSecretPassword(obj, password)
+	})
+}
+
+// writeSecret writes a copy of the credentials Secret modified by mutate
+func (i Inventory) writeSecret(buf *bytes.Buffer, credentialsName string, mutate func(map[string]interface{})) error {
+	secret, err := i.inventoryBundle.SelectOne(document.NewBMCCredentialsSelector(credentialsName))
+	if err != nil {
+		return err
+	}
+	return writeRendered(buf, secret, mutate)
+}
+
+// setSecretPassword sets password of the credentials Secret in the same field it is read from by
+// document.GetSecretDataKey
+func setSecretPassword(obj map[string]interface{}, password string) {
+	if stringData, ok := obj["stringData"].(map[string]interface{}); ok {
+		stringData["password"] = password
+		return
+	}
+	data, ok := obj["data"].(map[string]interface{})
+	if !ok {
+		data = make(map[string]interface{})
+		obj["data"] = data
+	}
+	data["password"] = b64.StdEncoding.EncodeToString([]byte(password))
+}
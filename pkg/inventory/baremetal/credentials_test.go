@@ -0,0 +1,301 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+type fakeCredentialsClient struct {
+	remoteifc.Client
+
+	nodeID    string
+	verifyErr error
+	revertErr error
+
+	mu        *sync.Mutex
+	passwords map[string][]string
+}
+
+func (c fakeCredentialsClient) NodeID() string {
+	return c.nodeID
+}
+
+func (c fakeCredentialsClient) UpdatePassword(ctx context.Context, newPassword string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.passwords[c.nodeID]) > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if c.revertErr != nil {
+			return c.revertErr
+		}
+	}
+	c.passwords[c.nodeID] = append(c.passwords[c.nodeID], newPassword)
+	return nil
+}
+
+func (c fakeCredentialsClient) VerifyCredentials(_ context.Context, _, _ string) error {
+	return c.verifyErr
+}
+
+func TestRotateCredentials(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           ifc.BaremetalBatchRunOptions
+		failingHosts   map[int]bool
+		failingReverts map[int]bool
+		expectRotated  []string
+		// expected passwords set on every host, in the order they were set
+		expectPasswords map[string][]string
+		expectReverted  []int
+		expectNotRevert []int
+	}{
+		{
+			name:          "success shared credentials",
+			expectRotated: []string{"secret-a", "secret-b"},
+			expectPasswords: map[string][]string{
+				"node-0": {"new-1"},
+				"node-1": {"new-2"},
+				"node-2": {"new-1"},
+			},
+		},
+		{
+			name:          "failed host reverts hosts sharing credentials",
+			failingHosts:  map[int]bool{2: true},
+			expectRotated: []string{"secret-b"},
+			expectPasswords: map[string][]string{
+				"node-0": {"new-1", "old-0"},
+				"node-1": {"new-2"},
+				"node-2": {"new-1", "old-2"},
+			},
+			expectReverted: []int{0, 2},
+		},
+		{
+			name:           "failed revert keeps new password",
+			failingHosts:   map[int]bool{2: true},
+			failingReverts: map[int]bool{0: true},
+			expectRotated:  []string{"secret-a", "secret-b"},
+			expectPasswords: map[string][]string{
+				"node-0": {"new-1"},
+				"node-1": {"new-2"},
+				"node-2": {"new-1", "old-2"},
+			},
+			expectReverted:  []int{2},
+			expectNotRevert: []int{0},
+		},
+		{
+			name:          "skipped host reverts hosts sharing credentials",
+			opts:          ifc.BaremetalBatchRunOptions{FailFast: true},
+			failingHosts:  map[int]bool{1: true},
+			expectRotated: []string{},
+			expectPasswords: map[string][]string{
+				"node-0": {"new-1", "old-0"},
+				"node-1": {"new-2", "old-1"},
+			},
+			expectReverted: []int{0, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			mu := &sync.Mutex{}
+			passwords := make(map[string][]string)
+			credentials := []string{"secret-a", "secret-b", "secret-a"}
+			hosts := make([]Host, len(credentials))
+			for idx, credentialsName := range credentials {
+				client := fakeCredentialsClient{
+					nodeID:    fmt.Sprintf("node-%d", idx),
+					mu:        mu,
+					passwords: passwords,
+				}
+				if tt.failingHosts[idx] {
+					client.verifyErr = fmt.Errorf("login failed")
+				}
+				if tt.failingReverts[idx] {
+					client.revertErr = fmt.Errorf("BMC is unreachable")
+				}
+				hosts[idx] = Host{
+					Client:          client,
+					Name:            fmt.Sprintf("host-%d", idx),
+					Namespace:       "metal3",
+					CredentialsName: credentialsName,
+					bmcUsername:     "admin",
+					bmcPassword:     fmt.Sprintf("old-%d", idx),
+				}
+			}
+
+			// fake client ignores the context unless the password is reverted, so that it is checked
+			// that passwords are reverted even if the rotation context is done
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			generated := 0
+			results, rotated := rotateCredentials(ctx, hosts, func() string {
+				generated++
+				return fmt.Sprintf("new-%d", generated)
+			}, tt.opts)
+			require.Len(t, results, len(hosts))
+
+			rotatedNames := []string{}
+			for credentialsName := range rotated {
+				rotatedNames = append(rotatedNames, credentialsName)
+			}
+			assert.ElementsMatch(t, tt.expectRotated, rotatedNames)
+			assert.Equal(t, tt.expectPasswords, passwords)
+
+			for _, idx := range tt.expectReverted {
+				assert.IsType(t, ErrCredentialsReverted{}, results[idx].Error)
+				assert.NoError(t, results[idx].Error.(ErrCredentialsReverted).RevertErr)
+			}
+			for _, idx := range tt.expectNotRevert {
+				assert.IsType(t, ErrCredentialsReverted{}, results[idx].Error)
+				assert.Error(t, results[idx].Error.(ErrCredentialsReverted).RevertErr)
+				assert.Equal(t, "new-1", rotated[hosts[idx].CredentialsName])
+			}
+		})
+	}
+}
+
+func TestCheckCredentialsHosts(t *testing.T) {
+	mu := &sync.Mutex{}
+	redfishHost := Host{
+		Client:     fakeCredentialsClient{nodeID: "node-0", mu: mu},
+		Name:       "master-0",
+		Namespace:  "metal3",
+		BMCAddress: "redfish+https://10.23.25.1/redfish/v1/Systems/1",
+	}
+	ipmiHost := Host{
+		Client:     fakeCredentialsClient{nodeID: "node-1", mu: mu},
+		Name:       "master-1",
+		Namespace:  "metal3",
+		BMCAddress: "ipmi://10.23.25.2",
+	}
+
+	assert.NoError(t, checkCredentialsHosts([]Host{redfishHost, ipmiHost}, DefaultPasswordLength))
+	assert.NoError(t, checkCredentialsHosts([]Host{redfishHost}, 32))
+	assert.Equal(t, ErrPasswordTooLong{BMHName: "master-1", BMHNamespace: "metal3", Length: 32, MaxLength: 20},
+		checkCredentialsHosts([]Host{redfishHost, ipmiHost}, 32))
+
+	unsupported := Host{Client: fakeClient{nodeID: "node-2"}, Name: "master-2", Namespace: "metal3"}
+	assert.Equal(t, remoteifc.ErrCredentialsManagementNotSupported{NodeID: "node-2"},
+		checkCredentialsHosts([]Host{redfishHost, unsupported}, DefaultPasswordLength))
+}
+
+func TestInventoryRotateCredentials(t *testing.T) {
+	bundle := testBundle(t)
+	inventory := NewInventory(&config.ManagementConfiguration{Type: "redfish"}, bundle)
+
+	t.Run("error no hosts found", func(t *testing.T) {
+		_, _, err := inventory.RotateCredentials(
+			context.Background(),
+			(ifc.BaremetalHostSelector{}).ByName("does not exist"),
+			ifc.BaremetalBatchRunOptions{})
+		assert.IsType(t, ErrNoBaremetalHostsFound{}, err)
+	})
+
+	t.Run("error invalid host sharing credentials", func(t *testing.T) {
+		_, _, err := inventory.RotateCredentials(
+			context.Background(),
+			(ifc.BaremetalHostSelector{}).ByName("master-0"),
+			ifc.BaremetalBatchRunOptions{})
+		assert.IsType(t, ErrInvalidCABundle{}, err)
+	})
+
+	t.Run("error rotation failed", func(t *testing.T) {
+		result, secrets, err := inventory.RotateCredentials(
+			context.Background(),
+			(ifc.BaremetalHostSelector{}).ByName("master-1"),
+			ifc.BaremetalBatchRunOptions{})
+		assert.IsType(t, ErrBaremetalOperationFailed{}, err)
+		assert.Equal(t, ifc.BaremetalOperationRotateCredentials, result.Operation)
+		// master-2 shares credentials Secret with master-1
+		require.Len(t, result.Hosts, 2)
+		assert.Len(t, result.Failed(), 2)
+
+		require.NotNil(t, secrets)
+		docs, err := secrets.GetAllDocuments()
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+}
+
+func TestCredentialsSecrets(t *testing.T) {
+	bundle := testBundle(t)
+	inventory := NewInventory(&config.ManagementConfiguration{Type: "redfish"}, bundle)
+
+	secrets, err := inventory.CredentialsSecrets((ifc.BaremetalHostSelector{}).ByName("master-1"))
+	require.NoError(t, err)
+	docs, err := secrets.GetAllDocuments()
+	require.NoError(t, err)
+	// master-2 shares credentials Secret with master-1
+	require.Len(t, docs, 1)
+	assert.Equal(t, "master-1-bmc-secret", docs[0].GetName())
+	password, err := document.DecodeSecretData(docs[0], "password")
+	require.NoError(t, err)
+	assert.Equal(t, redactedValue, string(password))
+
+	_, err = inventory.CredentialsSecrets((ifc.BaremetalHostSelector{}).ByName("does not exist"))
+	assert.IsType(t, ErrNoBaremetalHostsFound{}, err)
+}
+
+func TestSetSecretPassword(t *testing.T) {
+	t.Run("string data", func(t *testing.T) {
+		obj := map[string]interface{}{
+			"stringData": map[string]interface{}{"username": "admin", "password": "old"},
+		}
+		setSecretPassword(obj, "new")
+		assert.Equal(t, map[string]interface{}{
+			"stringData": map[string]interface{}{"username": "admin", "password": "new"},
+		}, obj)
+	})
+
+	t.Run("data", func(t *testing.T) {
+		doc, err := document.NewDocumentFromBytes([]byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: bmc-secret
+data:
+  username: YWRtaW4=
+  password: b2xk
+`))
+		require.NoError(t, err)
+		buf := &bytes.Buffer{}
+		require.NoError(t, writeRendered(buf, doc, func(obj map[string]interface{}) {
+			setSecretPassword(obj, "new")
+		}))
+
+		updated, err := document.NewBundleFromBytes(buf.Bytes())
+		require.NoError(t, err)
+		secret, err := updated.SelectOne(document.NewBMCCredentialsSelector("bmc-secret"))
+		require.NoError(t, err)
+		password, err := document.DecodeSecretData(secret, "password")
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(password))
+	})
+}
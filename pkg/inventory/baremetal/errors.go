@@ -16,7 +16,9 @@ package baremetal
 
 import (
 	"fmt"
+	"strings"
 
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
 )
 
@@ -49,3 +51,98 @@ type ErrBaremetalOperationNotSupported struct {
 func (e ErrBaremetalOperationNotSupported) Error() string {
 	return fmt.Sprintf("Baremetal operation not supported: '%s'", e.Operation)
 }
+
+// ErrBaremetalOperationFailed is returned when baremetal operation failed against one or more hosts
+type ErrBaremetalOperationFailed struct {
+	Operation ifc.BaremetalOperation
+	Failed    []ifc.BaremetalHostResult
+	Total     int
+}
+
+func (e ErrBaremetalOperationFailed) Error() string {
+	hostErrors := make([]string, 0, len(e.Failed))
+	for _, host := range e.Failed {
+		hostErrors = append(hostErrors, fmt.Sprintf("host '%s' in namespace '%s': %v",
+			host.Name, host.Namespace, host.Error))
+	}
+	return fmt.Sprintf("Baremetal operation '%s' failed against %d out of %d hosts: %s",
+		e.Operation, len(e.Failed), e.Total, strings.Join(hostErrors, "; "))
+}
+
+// ErrBIOSSettingsNotReferenced is returned when BIOS settings are applied to the host that doesn't
+// reference BIOSSettings document
+type ErrBIOSSettingsNotReferenced struct {
+	BMHName      string
+	BMHNamespace string
+}
+
+func (e ErrBIOSSettingsNotReferenced) Error() string {
+	return fmt.Sprintf("Baremetal host named '%s' in namespace '%s' doesn't reference BIOS settings with label '%s'",
+		e.BMHName, e.BMHNamespace, document.BIOSSettingsLabel)
+}
+
+// ErrInvalidAnnotation is returned when annotation of baremetal host overriding its management
+// configuration has invalid value
+type ErrInvalidAnnotation struct {
+	BMHName      string
+	BMHNamespace string
+	Annotation   string
+	Value        string
+}
+
+func (e ErrInvalidAnnotation) Error() string {
+	return fmt.Sprintf("Baremetal host named '%s' in namespace '%s' has invalid value '%s' of annotation '%s'",
+		e.BMHName, e.BMHNamespace, e.Value, e.Annotation)
+}
+
+// ErrInvalidCABundle is returned when certificate authorities referenced by baremetal host don't contain
+// any valid PEM encoded certificate
+type ErrInvalidCABundle struct {
+	BMHName      string
+	BMHNamespace string
+}
+
+func (e ErrInvalidCABundle) Error() string {
+	return fmt.Sprintf("Baremetal host named '%s' in namespace '%s' references no valid BMC CA certificates",
+		e.BMHName, e.BMHNamespace)
+}
+
+// ErrCredentialsNotRotated is returned for the host that changed its BMC password when the password
+// couldn't be changed on other hosts sharing the same credentials Secret
+type ErrCredentialsNotRotated struct {
+	CredentialsName string
+}
+
+func (e ErrCredentialsNotRotated) Error() string {
+	return fmt.Sprintf("BMC credentials Secret '%s' wasn't rotated on all the hosts referencing it",
+		e.CredentialsName)
+}
+
+// ErrCredentialsReverted is returned when BMC password of the host was reverted because the credentials
+// Secret couldn't be rotated, RevertErr is set if the password couldn't be reverted
+type ErrCredentialsReverted struct {
+	Err       error
+	RevertErr error
+}
+
+func (e ErrCredentialsReverted) Error() string {
+	if e.RevertErr != nil {
+		return fmt.Sprintf("%v, failed to revert BMC password, credentials Secret keeps the new password: %v",
+			e.Err, e.RevertErr)
+	}
+	return fmt.Sprintf("%v, BMC password reverted", e.Err)
+}
+
+// ErrPasswordTooLong is returned if BMC password of the host can't be changed to the password of requested
+// length, because the remote driver of the host doesn't support passwords that long
+type ErrPasswordTooLong struct {
+	BMHName      string
+	BMHNamespace string
+	Length       int
+	MaxLength    int
+}
+
+func (e ErrPasswordTooLong) Error() string {
+	return fmt.Sprintf("BMC password of baremetal host named '%s' in namespace '%s' can't be longer than %d "+
+		"characters, requested length is %d", e.BMHName, e.BMHNamespace, e.MaxLength, e.Length)
+}
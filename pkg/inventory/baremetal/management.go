@@ -0,0 +1,116 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"crypto/x509"
+	"strconv"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+// Annotations of BaremetalHost documents overriding management configuration of the context for
+// a single host, remote driver is overridden with BMCDriverAnnotation
+const (
+	// BMCSystemActionRetriesAnnotation overrides the number of attempts to poll the host for a status
+	BMCSystemActionRetriesAnnotation = "airshipit.org/bmc-system-action-retries"
+	// BMCSystemRebootDelayAnnotation overrides the number of seconds to wait between power actions
+	BMCSystemRebootDelayAnnotation = "airshipit.org/bmc-system-reboot-delay"
+	// BMCUseProxyAnnotation overrides whether requests to the BMC are transmitted through a proxy server
+	BMCUseProxyAnnotation = "airshipit.org/bmc-use-proxy"
+)
+
+// managementConfig returns management configuration of the host, which is management configuration
+// of the context with per host overrides from annotations of BaremetalHost document applied. BMC
+// certificate isn't verified if it is disabled by spec.bmc.disableCertificateVerification of the host.
+func (i Inventory) managementConfig(doc document.Document) (config.ManagementConfiguration, error) {
+	mgmtCfg := *i.mgmtCfg
+	if driver, ok := doc.GetAnnotations()[BMCDriverAnnotation]; ok {
+		mgmtCfg.Type = driver
+	}
+
+	if err := intAnnotation(doc, BMCSystemActionRetriesAnnotation, &mgmtCfg.SystemActionRetries); err != nil {
+		return config.ManagementConfiguration{}, err
+	}
+	if err := intAnnotation(doc, BMCSystemRebootDelayAnnotation, &mgmtCfg.SystemRebootDelay); err != nil {
+		return config.ManagementConfiguration{}, err
+	}
+	if err := boolAnnotation(doc, BMCUseProxyAnnotation, &mgmtCfg.UseProxy); err != nil {
+		return config.ManagementConfiguration{}, err
+	}
+
+	if disabled, err := doc.GetBool("spec.bmc.disableCertificateVerification"); err == nil && disabled {
+		mgmtCfg.Insecure = true
+	}
+	return mgmtCfg, nil
+}
+
+// intAnnotation sets value to non-negative integer from the annotation of the document if it is present
+func intAnnotation(doc document.Document, annotation string, value *int) error {
+	raw, ok := doc.GetAnnotations()[annotation]
+	if !ok {
+		return nil
+	}
+	parsed, err := strconv.Atoi(raw)
+	if err != nil || parsed < 0 {
+		return ErrInvalidAnnotation{
+			BMHName:      doc.GetName(),
+			BMHNamespace: doc.GetNamespace(),
+			Annotation:   annotation,
+			Value:        raw,
+		}
+	}
+	*value = parsed
+	return nil
+}
+
+// boolAnnotation sets value to boolean from the annotation of the document if it is present
+func boolAnnotation(doc document.Document, annotation string, value *bool) error {
+	raw, ok := doc.GetAnnotations()[annotation]
+	if !ok {
+		return nil
+	}
+	parsed, err := strconv.ParseBool(raw)
+	if err != nil {
+		return ErrInvalidAnnotation{
+			BMHName:      doc.GetName(),
+			BMHNamespace: doc.GetNamespace(),
+			Annotation:   annotation,
+			Value:        raw,
+		}
+	}
+	*value = parsed
+	return nil
+}
+
+// setRootCAs makes the client trust certificate authorities referenced by BaremetalHost document,
+// client is left untouched if the host doesn't reference any
+func (i Inventory) setRootCAs(doc document.Document, client remoteifc.Client) error {
+	caBundle, err := document.GetBMHBMCCABundle(doc, i.inventoryBundle)
+	if err != nil || caBundle == "" {
+		return err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caBundle)) {
+		return ErrInvalidCABundle{
+			BMHName:      doc.GetName(),
+			BMHNamespace: doc.GetNamespace(),
+		}
+	}
+	return remoteifc.SetRootCAs(client, pool)
+}
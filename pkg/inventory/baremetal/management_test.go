@@ -0,0 +1,103 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	redfishdell "opendev.org/airship/airshipctl/pkg/remote/redfish/vendors/dell"
+)
+
+func TestManagementConfig(t *testing.T) {
+	mgmtCfg := &config.ManagementConfiguration{
+		Type:                "redfish",
+		SystemActionRetries: 1,
+		SystemRebootDelay:   2,
+	}
+
+	tests := []struct {
+		name, host, expectedErr string
+		expected                config.ManagementConfiguration
+	}{
+		{
+			name:     "success no overrides",
+			host:     "master-0",
+			expected: *mgmtCfg,
+		},
+		{
+			name: "success overrides",
+			host: "overrides",
+			expected: config.ManagementConfiguration{
+				Type:                "redfish-dell",
+				Insecure:            true,
+				UseProxy:            true,
+				SystemActionRetries: 5,
+				SystemRebootDelay:   30,
+			},
+		},
+		{
+			name:        "error invalid annotation",
+			host:        "invalid-annotation",
+			expectedErr: "invalid value 'soon' of annotation 'airshipit.org/bmc-system-reboot-delay'",
+		},
+	}
+
+	bundle := testBundle(t)
+	inventory := Inventory{mgmtCfg: mgmtCfg, inventoryBundle: bundle}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := bundle.SelectOne(document.NewSelector().ByKind(document.BareMetalHostKind).ByName(tt.host))
+			require.NoError(t, err)
+
+			actual, err := inventory.managementConfig(doc)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, actual)
+			}
+		})
+	}
+	// context management configuration must not be modified by overrides
+	assert.Equal(t, "redfish", mgmtCfg.Type)
+}
+
+func TestNewHostManagementOverrides(t *testing.T) {
+	bundle := testBundle(t)
+	inventory := NewInventory(&config.ManagementConfiguration{Type: "redfish"}, bundle)
+
+	t.Run("success overrides and CA bundle", func(t *testing.T) {
+		host, err := inventory.SelectOne((ifc.BaremetalHostSelector{}).ByName("overrides"))
+		require.NoError(t, err)
+		require.IsType(t, Host{}, host)
+		client, ok := host.(Host).Client.(*redfishdell.Client)
+		require.True(t, ok)
+		assert.Equal(t, 5, client.SystemActionRetries())
+		assert.Equal(t, 30, client.SystemRebootDelay())
+	})
+
+	t.Run("error invalid CA bundle", func(t *testing.T) {
+		_, err := inventory.SelectOne((ifc.BaremetalHostSelector{}).ByName("invalid-ca"))
+		assert.Equal(t, ErrInvalidCABundle{BMHName: "invalid-ca"}, err)
+	})
+}
@@ -0,0 +1,153 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package baremetal
+
+import (
+	"bytes"
+	"net/url"
+
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	// BMCDriverAnnotation overrides the remote driver used to manage the host, it is set on rendered
+	// BaremetalHost documents to the remote driver resolved for the host
+	BMCDriverAnnotation = "airshipit.org/bmc-driver"
+	// BMCAddressAnnotation is set on rendered BaremetalHost documents to the BMC address used to manage the host
+	BMCAddressAnnotation = "airshipit.org/bmc-address"
+
+	redactedValue = "REDACTED"
+)
+
+// RenderHosts returns a bundle with BaremetalHost documents matched by the selector. Every document is
+// annotated with the BMC driver and address resolved by the inventory, BMC credentials Secrets referenced
+// by the hosts are added to the bundle with all their values redacted.
+func (i Inventory) RenderHosts(selector ifc.BaremetalHostSelector) (document.Bundle, error) {
+	log.Debugf("Rendering baremetal hosts selected by selector '%v'", selector)
+	docs, err := i.inventoryBundle.Select(toDocumentSelector(selector))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(docs) == 0 {
+		return nil, ErrNoBaremetalHostsFound{Selector: selector}
+	}
+
+	buf := &bytes.Buffer{}
+	renderedSecrets := make(map[string]bool)
+	for _, doc := range docs {
+		address, err := document.GetBMHBMCAddress(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		mgmtCfg, err := i.managementConfig(doc)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err = clientFactory(doc, mgmtCfg.Type); err != nil {
+			return nil, err
+		}
+
+		err = writeRendered(buf, doc, func(obj map[string]interface{}) {
+			metadata, ok := obj["metadata"].(map[string]interface{})
+			if !ok {
+				metadata = make(map[string]interface{})
+				obj["metadata"] = metadata
+			}
+			annotations, ok := metadata["annotations"].(map[string]interface{})
+			if !ok {
+				annotations = make(map[string]interface{})
+				metadata["annotations"] = annotations
+			}
+			annotations[BMCDriverAnnotation] = mgmtCfg.Type
+			annotations[BMCAddressAnnotation] = redactURL(address)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		credentialsName, err := doc.GetString("spec.bmc.credentialsName")
+		if err != nil {
+			return nil, err
+		}
+		if renderedSecrets[credentialsName] {
+			continue
+		}
+		secret, err := i.inventoryBundle.SelectOne(document.NewBMCCredentialsSelector(credentialsName))
+		if err != nil {
+			return nil, err
+		}
+		if err = writeRendered(buf, secret, redactSecret); err != nil {
+			return nil, err
+		}
+		renderedSecrets[credentialsName] = true
+	}
+
+	return document.NewBundleFromBytes(buf.Bytes())
+}
+
+// writeRendered writes a copy of the document modified by the mutate function, the document
+// itself is left untouched
+func writeRendered(buf *bytes.Buffer, doc document.Document, mutate func(map[string]interface{})) error {
+	obj := make(map[string]interface{})
+	if err := doc.ToObject(&obj); err != nil {
+		return err
+	}
+	mutate(obj)
+
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	buf.WriteString("---\n")
+	buf.Write(out)
+	return nil
+}
+
+// redactSecret replaces values of the secret with redacted value, keeping only the keys so that
+// it is visible which credentials are going to be used
+func redactSecret(obj map[string]interface{}) {
+	redacted := make(map[string]interface{})
+	for _, field := range []string{"data", "stringData"} {
+		values, ok := obj[field].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key := range values {
+			redacted[key] = redactedValue
+		}
+		delete(obj, field)
+	}
+	obj["stringData"] = redacted
+}
+
+// redactURL hides password if it is part of the BMC address
+func redactURL(address string) string {
+	u, err := url.Parse(address)
+	if err != nil || u.User == nil {
+		return address
+	}
+	if _, ok := u.User.Password(); !ok {
+		return address
+	}
+	u.User = url.UserPassword(u.User.Username(), redactedValue)
+	return u.String()
+}
@@ -0,0 +1,78 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+// PrintBIOSSettings prints BIOS attributes of the selected hosts. Current and desired values are printed
+// for the attributes managed by BIOS settings referenced by the host, all current attributes are printed
+// for hosts that don't reference any. If driftOnly is true only attributes that differ from desired
+// values are printed.
+func (o *CommandOptions) PrintBIOSSettings(w io.Writer, driftOnly bool) error {
+	if err := o.validateBMHAction(); err != nil {
+		return err
+	}
+
+	bmhInventory, err := o.Inventory.BaremetalInventory()
+	if err != nil {
+		return err
+	}
+
+	hosts, err := bmhInventory.Select(o.selector())
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return baremetal.ErrNoBaremetalHostsFound{Selector: o.selector()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+
+	tw := util.NewTabWriter(w)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tATTRIBUTE\tCURRENT\tDESIRED")
+	for _, host := range hosts {
+		bmh, ok := host.(baremetal.Host)
+		if !ok {
+			bmh = baremetal.Host{Client: host}
+		}
+
+		var attributes []baremetal.BIOSAttribute
+		attributes, err = bmh.CompareBIOSSettings(ctx)
+		baremetal.CloseSession(ctx, bmh)
+		if err != nil {
+			return err
+		}
+
+		for _, attribute := range attributes {
+			if driftOnly && !attribute.Drifted() {
+				continue
+			}
+			desired := ""
+			if attribute.Desired != nil {
+				desired = fmt.Sprint(attribute.Desired)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%v\t%s\n", bmh.Namespace, bmh.Name, attribute.Name, attribute.Current, desired)
+		}
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,98 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	mockinventory "opendev.org/airship/airshipctl/testutil/inventory"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+func newBIOSHost(name string, settings map[string]interface{}, err error) remoteifc.Client {
+	client := &redfishutils.MockClient{}
+	client.On("BIOSAttributes").Once().Return(map[string]interface{}{
+		"BootMode":        "Uefi",
+		"ProcCoreDisable": float64(0),
+		"ProcTurboMode":   "Enabled",
+	}, err)
+	return baremetal.Host{Client: client, Name: name, Namespace: "metal3", BIOSSettings: settings}
+}
+
+func newSelectOptions(hosts ...remoteifc.Client) *inventory.CommandOptions {
+	bmhInv := &mockinventory.MockBMHInventory{}
+	bmhInv.On("Select").Once().Return(hosts, nil)
+
+	inv := &mockinventory.MockInventory{}
+	inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+
+	co := inventory.NewOptions(inv)
+	co.All = true
+	return co
+}
+
+func TestPrintBIOSSettings(t *testing.T) {
+	settings := map[string]interface{}{"ProcTurboMode": "Disabled", "ProcCoreDisable": 0}
+
+	t.Run("success all attributes", func(t *testing.T) {
+		co := newSelectOptions(newBIOSHost("master-0", settings, nil), newBIOSHost("master-1", nil, nil))
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.PrintBIOSSettings(buf, false))
+
+		expected := "NAMESPACE   NAME       ATTRIBUTE         CURRENT   DESIRED\n" +
+			"metal3      master-0   ProcCoreDisable   0         0\n" +
+			"metal3      master-0   ProcTurboMode     Enabled   Disabled\n" +
+			"metal3      master-1   BootMode          Uefi      \n" +
+			"metal3      master-1   ProcCoreDisable   0         \n" +
+			"metal3      master-1   ProcTurboMode     Enabled   \n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("success drift only", func(t *testing.T) {
+		co := newSelectOptions(newBIOSHost("master-0", settings, nil), newBIOSHost("master-1", nil, nil))
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.PrintBIOSSettings(buf, true))
+
+		expected := "NAMESPACE   NAME       ATTRIBUTE       CURRENT   DESIRED\n" +
+			"metal3      master-0   ProcTurboMode   Enabled   Disabled\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("error BIOSAttributes", func(t *testing.T) {
+		expectedErr := fmt.Errorf("BMC is unreachable")
+		co := newSelectOptions(newBIOSHost("master-0", settings, expectedErr))
+		assert.Equal(t, expectedErr, co.PrintBIOSSettings(bytes.NewBuffer(nil), false))
+	})
+
+	t.Run("error no hosts", func(t *testing.T) {
+		co := newSelectOptions()
+		assert.IsType(t, baremetal.ErrNoBaremetalHostsFound{}, co.PrintBIOSSettings(bytes.NewBuffer(nil), false))
+	})
+
+	t.Run("error invalid options", func(t *testing.T) {
+		co := inventory.NewOptions(&mockinventory.MockInventory{})
+		err := co.PrintBIOSSettings(bytes.NewBuffer(nil), false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
+	})
+}
@@ -18,10 +18,27 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/isoserver"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+const (
+	// DefaultIsoServerIdleTimeout is the time ISO image must not be requested by the host for the host to be
+	// considered booted, BMCs read virtual media in chunks while the host loads the image
+	DefaultIsoServerIdleTimeout = time.Minute
+
+	// DefaultPasswordLength is the length of BMC passwords generated by credentials rotation
+	DefaultPasswordLength = baremetal.DefaultPasswordLength
+
+	minPasswordLength = 8
 )
 
 // CommandOptions is used to store common variables from cmd flags for baremetal command group
@@ -34,6 +51,29 @@ type CommandOptions struct {
 	IsoURL    string
 	Timeout   time.Duration
 
+	IsoFile              string
+	IsoServerAddress     string
+	IsoServerIdleTimeout time.Duration
+
+	MaxConcurrency  int
+	FailFast        bool
+	ContinueOnError bool
+
+	BootDevice string
+	Persistent bool
+	PowerState string
+
+	HardwareFormat string
+	OutputFormat   string
+
+	SkipReboot bool
+
+	FirmwareImageURL string
+
+	Encrypter      string
+	PasswordLength int
+	PrintOnFailure bool
+
 	Inventory ifc.Inventory
 }
 
@@ -54,6 +94,41 @@ func (o *CommandOptions) validateBMHAction() error {
 	return nil
 }
 
+func (o *CommandOptions) operationOptions(op ifc.BaremetalOperation) (ifc.BaremetalOperationOptions, error) {
+	opts := ifc.BaremetalOperationOptions{}
+	switch op {
+	case ifc.BaremetalOperationSetBootDevice:
+		device := remoteifc.BootDevice(strings.ToLower(o.BootDevice))
+		switch device {
+		case remoteifc.BootDevicePXE, remoteifc.BootDeviceDisk, remoteifc.BootDeviceCD:
+		default:
+			return opts, ErrInvalidOptions{Message: fmt.Sprintf("boot device must be one of '%s', '%s' or '%s', got '%s'",
+				remoteifc.BootDevicePXE, remoteifc.BootDeviceDisk, remoteifc.BootDeviceCD, o.BootDevice)}
+		}
+		opts.BootDevice = device
+		opts.Persistent = o.Persistent
+	case ifc.BaremetalOperationWaitForPowerState:
+		switch strings.ToLower(o.PowerState) {
+		case "on":
+			opts.PowerState = power.StatusOn
+		case "off":
+			opts.PowerState = power.StatusOff
+		default:
+			return opts, ErrInvalidOptions{Message: fmt.Sprintf("power state must be one of 'on' or 'off', got '%s'",
+				o.PowerState)}
+		}
+	case ifc.BaremetalOperationApplyBIOSSettings:
+		opts.SkipReboot = o.SkipReboot
+		opts.SkipUnreferencedHosts = o.All
+	case ifc.BaremetalOperationUpdateFirmware:
+		if o.FirmwareImageURL == "" {
+			return opts, ErrInvalidOptions{Message: "firmware image URL must be specified"}
+		}
+		opts.FirmwareImageURL = o.FirmwareImageURL
+	}
+	return opts, nil
+}
+
 func (o *CommandOptions) validateSingleHostAction() error {
 	if o.Name == "" && o.Namespace == "" && o.Labels == "" {
 		return ErrInvalidOptions{Message: "No options are specified, must provide atleast 'name', 'namespace' or 'labels'"}
@@ -61,15 +136,20 @@ func (o *CommandOptions) validateSingleHostAction() error {
 	return nil
 }
 
-// BMHAction performs an action against BaremetalHost objects
-func (o *CommandOptions) BMHAction(op ifc.BaremetalOperation) error {
+// BMHAction performs an action against BaremetalHost objects and returns per host results
+func (o *CommandOptions) BMHAction(op ifc.BaremetalOperation) (ifc.BaremetalBatchResult, error) {
 	if err := o.validateBMHAction(); err != nil {
-		return err
+		return ifc.BaremetalBatchResult{}, err
+	}
+
+	opOptions, err := o.operationOptions(op)
+	if err != nil {
+		return ifc.BaremetalBatchResult{}, err
 	}
 
 	bmhInventory, err := o.Inventory.BaremetalInventory()
 	if err != nil {
-		return err
+		return ifc.BaremetalBatchResult{}, err
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
@@ -78,41 +158,150 @@ func (o *CommandOptions) BMHAction(op ifc.BaremetalOperation) error {
 		ctx,
 		op,
 		o.selector(),
-		ifc.BaremetalBatchRunOptions{})
+		ifc.BaremetalBatchRunOptions{
+			MaxConcurrency:   o.MaxConcurrency,
+			FailFast:         o.FailFast,
+			ContinueOnError:  o.ContinueOnError,
+			OperationOptions: opOptions,
+		})
 }
 
-// RemoteDirect perform RemoteDirect operation against single host
-func (o *CommandOptions) RemoteDirect() error {
-	if err := o.validateSingleHostAction(); err != nil {
-		return err
+// RotateCredentials rotates BMC credentials of BaremetalHost objects and writes updated credentials Secrets
+// to the inventory, the Secrets rotated on all their hosts are written even if rotation failed on other hosts.
+// It is checked that the Secrets can be written to the inventory before BMC passwords are changed. If the
+// Secrets are not written anyway, they are printed unencrypted to the writer only if PrintOnFailure is set.
+func (o *CommandOptions) RotateCredentials(w io.Writer) (ifc.BaremetalBatchResult, error) {
+	if err := o.validateRotateCredentials(); err != nil {
+		return ifc.BaremetalBatchResult{}, err
 	}
-	host, err := o.getHost()
+
+	bmhInventory, err := o.Inventory.BaremetalInventory()
 	if err != nil {
-		return err
+		return ifc.BaremetalBatchResult{}, err
+	}
+
+	current, err := bmhInventory.CredentialsSecrets(o.selector())
+	if err != nil {
+		return ifc.BaremetalBatchResult{}, err
+	}
+	encrypter, err := o.Inventory.ResolveEncrypter(current, o.Encrypter)
+	if err != nil {
+		return ifc.BaremetalBatchResult{}, err
 	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
 	defer cancel()
-	return host.RemoteDirect(ctx, o.IsoURL)
+	result, secrets, err := bmhInventory.RotateCredentials(
+		ctx,
+		o.selector(),
+		ifc.BaremetalBatchRunOptions{
+			MaxConcurrency:  o.MaxConcurrency,
+			FailFast:        o.FailFast,
+			ContinueOnError: o.ContinueOnError,
+			OperationOptions: ifc.BaremetalOperationOptions{
+				PasswordLength: o.PasswordLength,
+			},
+		})
+	if secrets == nil {
+		return result, err
+	}
+
+	docs, getErr := secrets.GetAllDocuments()
+	if getErr != nil {
+		return result, getErr
+	}
+	if len(docs) == 0 {
+		return result, err
+	}
+	if updateErr := o.Inventory.UpdateDocuments(secrets, encrypter); updateErr != nil {
+		notWritten := ErrCredentialsNotWritten{Err: updateErr}
+		if o.PrintOnFailure {
+			// BMC passwords are already changed, print them so that the inventory can be fixed manually
+			notWritten.PrintErr = secrets.Write(w)
+			notWritten.Printed = notWritten.PrintErr == nil
+		}
+		return result, notWritten
+	}
+	return result, err
 }
 
-// PowerStatus get power status of the single host
-func (o *CommandOptions) PowerStatus(w io.Writer) error {
+func (o *CommandOptions) validateRotateCredentials() error {
+	if err := o.validateBMHAction(); err != nil {
+		return err
+	}
+	if o.PasswordLength != 0 && o.PasswordLength < minPasswordLength {
+		return ErrInvalidOptions{Message: fmt.Sprintf("password length must be at least %d, got %d",
+			minPasswordLength, o.PasswordLength)}
+	}
+	return nil
+}
+
+// PrintBatchResult prints per host summary table of the baremetal operation
+func PrintBatchResult(w io.Writer, result ifc.BaremetalBatchResult) error {
+	tw := util.NewTabWriter(w)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tNODE ID\tSTATUS\tERROR")
+	for _, host := range result.Hosts {
+		status, errMsg := "OK", ""
+		switch {
+		case host.Skipped:
+			status = "SKIPPED"
+		case host.Error != nil:
+			status, errMsg = "FAILED", host.Error.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", host.Namespace, host.Name, host.NodeID, status, errMsg)
+	}
+	return tw.Flush()
+}
+
+// RemoteDirect perform RemoteDirect operation against single host. If ISO file is specified instead of
+// ISO URL, the file is served by built-in HTTP server until the host finishes booting from it.
+func (o *CommandOptions) RemoteDirect() error {
 	if err := o.validateSingleHostAction(); err != nil {
 		return err
 	}
+	if o.IsoURL != "" && o.IsoFile != "" {
+		return ErrInvalidOptions{Message: "options 'iso-url' and 'iso-file' can not be used together"}
+	}
 	host, err := o.getHost()
 	if err != nil {
 		return err
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
 	defer cancel()
-	status, err := host.SystemPowerStatus(ctx)
+	defer baremetal.CloseSession(ctx, host)
+	if o.IsoFile == "" {
+		return host.RemoteDirect(ctx, o.IsoURL)
+	}
+	return o.remoteDirectISOFile(ctx, host)
+}
+
+func (o *CommandOptions) remoteDirectISOFile(ctx context.Context, host remoteifc.Client) error {
+	bmcAddress := ""
+	if bmh, ok := host.(baremetal.Host); ok {
+		bmcAddress = bmh.BMCAddress
+	}
+
+	srv, err := isoserver.Start(o.IsoFile, o.IsoServerAddress, bmcAddress)
 	if err != nil {
 		return err
 	}
-	// TODO support different output formats
-	fmt.Fprintf(w, "Host with node id '%s' has power status: '%s'\n", host.NodeID(), status)
-	return nil
+	defer func() {
+		if closeErr := srv.Close(); closeErr != nil {
+			log.Debugf("Failed to stop ISO server: %v", closeErr)
+		}
+	}()
+
+	log.Printf("Serving ISO image '%s' at '%s'", o.IsoFile, srv.URL())
+	if err = host.RemoteDirect(ctx, srv.URL()); err != nil {
+		return err
+	}
+
+	idleTimeout := o.IsoServerIdleTimeout
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIsoServerIdleTimeout
+	}
+	log.Printf("Waiting for host with node id '%s' to finish reading ISO image", host.NodeID())
+	return srv.WaitIdle(ctx, idleTimeout)
 }
 
 func (o *CommandOptions) getHost() (remoteifc.Client, error) {
@@ -16,20 +16,38 @@ package inventory_test
 
 import (
 	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
-	"opendev.org/airship/airshipctl/pkg/remote/power"
 	mockinventory "opendev.org/airship/airshipctl/testutil/inventory"
 	"opendev.org/airship/airshipctl/testutil/redfishutils"
 )
 
-const testNode = "node-0"
+const (
+	testNode = "node-0"
+
+	testCredentialsSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: master-0-bmc-secret
+type: Opaque
+stringData:
+  username: admin
+  password: new-password
+`
+)
 
 func TestCommandOptions(t *testing.T) {
 	t.Run("error BMHAction bmh inventory", func(t *testing.T) {
@@ -39,7 +57,7 @@ func TestCommandOptions(t *testing.T) {
 
 		co := inventory.NewOptions(inv)
 		co.All = true
-		actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		_, actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn)
 		assert.Equal(t, expectedErr, actualErr)
 	})
 
@@ -47,7 +65,7 @@ func TestCommandOptions(t *testing.T) {
 		inv := &mockinventory.MockInventory{}
 
 		co := inventory.NewOptions(inv)
-		err := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		_, err := co.BMHAction(ifc.BaremetalOperationPowerOn)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
 	})
@@ -58,92 +76,214 @@ func TestCommandOptions(t *testing.T) {
 		co := inventory.NewOptions(inv)
 		co.All = true
 		co.Labels = "foo=bar"
-		err := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		_, err := co.BMHAction(ifc.BaremetalOperationPowerOn)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
 	})
 
+	t.Run("error BMHAction invalid operation options", func(t *testing.T) {
+		inv := &mockinventory.MockInventory{}
+
+		co := inventory.NewOptions(inv)
+		co.All = true
+		co.BootDevice = "floppy"
+		_, err := co.BMHAction(ifc.BaremetalOperationSetBootDevice)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "boot device must be one of")
+
+		co.PowerState = "unknown"
+		_, err = co.BMHAction(ifc.BaremetalOperationWaitForPowerState)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "power state must be one of")
+
+		_, err = co.BMHAction(ifc.BaremetalOperationUpdateFirmware)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "firmware image URL must be specified")
+	})
+
 	t.Run("success BMHAction", func(t *testing.T) {
 		bmhInv := &mockinventory.MockBMHInventory{}
-		bmhInv.On("RunOperation").Once().Return(nil)
+		expectedResult := ifc.BaremetalBatchResult{
+			Operation: ifc.BaremetalOperationPowerOn,
+			Hosts:     []ifc.BaremetalHostResult{{Name: "master-0", Namespace: "metal3", NodeID: testNode}},
+		}
+		bmhInv.On("RunOperation").Once().Return(expectedResult, nil)
 
 		inv := &mockinventory.MockInventory{}
 		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
 
 		co := inventory.NewOptions(inv)
 		co.All = true
-		actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn)
+		result, actualErr := co.BMHAction(ifc.BaremetalOperationPowerOn)
 		assert.Equal(t, nil, actualErr)
+		assert.Equal(t, expectedResult, result)
 	})
 
-	t.Run("error PowerStatus SelectOne", func(t *testing.T) {
-		expectedErr := fmt.Errorf("SelectOne inventory error")
+	t.Run("success PrintBatchResult", func(t *testing.T) {
+		buf := bytes.NewBuffer([]byte{})
+		err := inventory.PrintBatchResult(buf, ifc.BaremetalBatchResult{
+			Operation: ifc.BaremetalOperationPowerOn,
+			Hosts: []ifc.BaremetalHostResult{
+				{Name: "master-0", Namespace: "metal3", NodeID: "node-0"},
+				{Name: "master-1", Namespace: "metal3", NodeID: "node-1", Error: fmt.Errorf("power on failed")},
+				{Name: "master-2", Namespace: "metal3", NodeID: "node-2", Skipped: true},
+			},
+		})
+		require.NoError(t, err)
+		expected := "NAMESPACE   NAME       NODE ID   STATUS    ERROR\n" +
+			"metal3      master-0   node-0    OK        \n" +
+			"metal3      master-1   node-1    FAILED    power on failed\n" +
+			"metal3      master-2   node-2    SKIPPED   \n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("success RotateCredentials partially failed", func(t *testing.T) {
+		secrets, err := document.NewBundleFromBytes([]byte(testCredentialsSecret))
+		require.NoError(t, err)
+		expectedResult := ifc.BaremetalBatchResult{
+			Operation: ifc.BaremetalOperationRotateCredentials,
+			Hosts: []ifc.BaremetalHostResult{
+				{Name: "master-0", Namespace: "metal3", NodeID: "node-0"},
+				{Name: "master-1", Namespace: "metal3", NodeID: "node-1", Error: fmt.Errorf("login failed")},
+			},
+		}
+		expectedErr := fmt.Errorf("rotation failed")
 		bmhInv := &mockinventory.MockBMHInventory{}
-		bmhInv.On("SelectOne").Once().Return(nil, expectedErr)
+		bmhInv.On("CredentialsSecrets").Once().Return(secrets, nil)
+		bmhInv.On("RotateCredentials").Once().Return(expectedResult, secrets, expectedErr)
 
 		inv := &mockinventory.MockInventory{}
 		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+		inv.On("ResolveEncrypter", secrets, "encrypter").Once().Return("encrypter", nil)
+		inv.On("UpdateDocuments", secrets, "encrypter").Once().Return(nil)
 
 		co := inventory.NewOptions(inv)
-		co.Name = testNode
-		buf := bytes.NewBuffer([]byte{})
-		actualErr := co.PowerStatus(buf)
+		co.All = true
+		co.Encrypter = "encrypter"
+		result, actualErr := co.RotateCredentials(ioutil.Discard)
 		assert.Equal(t, expectedErr, actualErr)
-		assert.Len(t, buf.Bytes(), 0)
+		assert.Equal(t, expectedResult, result)
+		inv.AssertExpectations(t)
 	})
 
-	t.Run("error PowerStatus BMHInventory", func(t *testing.T) {
+	t.Run("success RotateCredentials nothing rotated", func(t *testing.T) {
+		secrets, err := document.NewBundleFromBytes(nil)
+		require.NoError(t, err)
+		bmhInv := &mockinventory.MockBMHInventory{}
+		bmhInv.On("CredentialsSecrets").Once().Return(secrets, nil)
+		bmhInv.On("RotateCredentials").Once().Return(ifc.BaremetalBatchResult{}, secrets, nil)
+
 		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+		inv.On("ResolveEncrypter", secrets, "").Once().Return("", nil)
 
-		expectedErr := fmt.Errorf("bmh inventory error")
-		inv.On("BaremetalInventory").Once().Return(nil, expectedErr)
+		co := inventory.NewOptions(inv)
+		co.All = true
+		_, actualErr := co.RotateCredentials(ioutil.Discard)
+		assert.NoError(t, actualErr)
+		inv.AssertNotCalled(t, "UpdateDocuments")
+	})
+
+	t.Run("error RotateCredentials documents not written", func(t *testing.T) {
+		secrets, err := document.NewBundleFromBytes([]byte(testCredentialsSecret))
+		require.NoError(t, err)
+		bmhInv := &mockinventory.MockBMHInventory{}
+		bmhInv.On("CredentialsSecrets").Once().Return(secrets, nil)
+		bmhInv.On("RotateCredentials").Once().Return(ifc.BaremetalBatchResult{}, secrets, nil)
+
+		updateErr := fmt.Errorf("read-only file system")
+		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+		inv.On("ResolveEncrypter", secrets, "").Once().Return("", nil)
+		inv.On("UpdateDocuments", secrets, "").Once().Return(updateErr)
 
 		co := inventory.NewOptions(inv)
-		co.Name = testNode
+		co.All = true
 		buf := bytes.NewBuffer([]byte{})
-		actualErr := co.PowerStatus(buf)
-		assert.Equal(t, expectedErr, actualErr)
-		assert.Len(t, buf.Bytes(), 0)
+		_, actualErr := co.RotateCredentials(buf)
+		assert.Equal(t, inventory.ErrCredentialsNotWritten{Err: updateErr}, actualErr)
+		// credentials are never printed unless it is requested
+		assert.Empty(t, buf.String())
 	})
 
-	t.Run("error PowerStatus SystemPowerStatus", func(t *testing.T) {
-		expectedErr := fmt.Errorf("SystemPowerStatus error")
-		host := &redfishutils.MockClient{}
-		host.On("SystemPowerStatus").Once().Return(power.StatusUnknown, expectedErr)
-
+	t.Run("error RotateCredentials documents not written printed", func(t *testing.T) {
+		secrets, err := document.NewBundleFromBytes([]byte(testCredentialsSecret))
+		require.NoError(t, err)
 		bmhInv := &mockinventory.MockBMHInventory{}
-		bmhInv.On("SelectOne").Once().Return(host, nil)
+		bmhInv.On("CredentialsSecrets").Once().Return(secrets, nil)
+		bmhInv.On("RotateCredentials").Once().Return(ifc.BaremetalBatchResult{}, secrets, nil)
 
+		updateErr := fmt.Errorf("read-only file system")
 		inv := &mockinventory.MockInventory{}
 		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+		inv.On("ResolveEncrypter", secrets, "").Once().Return("", nil)
+		inv.On("UpdateDocuments", secrets, "").Once().Return(updateErr)
 
 		co := inventory.NewOptions(inv)
-		co.Name = testNode
+		co.All = true
+		co.PrintOnFailure = true
 		buf := bytes.NewBuffer([]byte{})
-		actualErr := co.PowerStatus(buf)
-		assert.Equal(t, expectedErr, actualErr)
-		assert.Len(t, buf.Bytes(), 0)
+		_, actualErr := co.RotateCredentials(buf)
+		assert.Equal(t, inventory.ErrCredentialsNotWritten{Err: updateErr, Printed: true}, actualErr)
+		assert.Contains(t, buf.String(), "master-0-bmc-secret")
 	})
 
-	t.Run("success PowerStatus", func(t *testing.T) {
-		host := &redfishutils.MockClient{}
-		nodeID := "node01"
-		host.On("SystemPowerStatus").Once().Return(power.StatusPoweringOn, nil)
-		host.On("NodeID").Once().Return(nodeID)
+	t.Run("error RotateCredentials documents not printed", func(t *testing.T) {
+		secrets, err := document.NewBundleFromBytes([]byte(testCredentialsSecret))
+		require.NoError(t, err)
+		bmhInv := &mockinventory.MockBMHInventory{}
+		bmhInv.On("CredentialsSecrets").Once().Return(secrets, nil)
+		bmhInv.On("RotateCredentials").Once().Return(ifc.BaremetalBatchResult{}, secrets, nil)
+
+		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+		inv.On("ResolveEncrypter", secrets, "").Once().Return("", nil)
+		inv.On("UpdateDocuments", secrets, "").Once().Return(fmt.Errorf("read-only file system"))
+
+		co := inventory.NewOptions(inv)
+		co.All = true
+		co.PrintOnFailure = true
+		_, actualErr := co.RotateCredentials(failingWriter{})
+		require.IsType(t, inventory.ErrCredentialsNotWritten{}, actualErr)
+		notWritten := actualErr.(inventory.ErrCredentialsNotWritten)
+		assert.False(t, notWritten.Printed)
+		assert.Error(t, notWritten.PrintErr)
+		assert.Contains(t, actualErr.Error(), "failed to print rotated credentials Secrets")
+	})
 
+	t.Run("error RotateCredentials encrypter not resolved", func(t *testing.T) {
+		secrets, err := document.NewBundleFromBytes([]byte(testCredentialsSecret))
+		require.NoError(t, err)
 		bmhInv := &mockinventory.MockBMHInventory{}
-		bmhInv.On("SelectOne").Once().Return(host, nil)
+		bmhInv.On("CredentialsSecrets").Once().Return(secrets, nil)
 
+		expectedErr := inventory.ErrEncrypterNotFound{Name: inventory.DefaultEncrypter}
 		inv := &mockinventory.MockInventory{}
 		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+		inv.On("ResolveEncrypter", secrets, "").Once().Return("", expectedErr)
 
 		co := inventory.NewOptions(inv)
-		co.Name = testNode
-		buf := bytes.NewBuffer([]byte{})
-		actualErr := co.PowerStatus(buf)
-		assert.Equal(t, nil, actualErr)
-		assert.Contains(t, buf.String(), nodeID)
-		assert.Contains(t, buf.String(), power.StatusPoweringOn.String())
+		co.All = true
+		_, actualErr := co.RotateCredentials(ioutil.Discard)
+		assert.Equal(t, expectedErr, actualErr)
+		// BMC passwords are not changed if the Secrets can't be written
+		bmhInv.AssertNotCalled(t, "RotateCredentials")
+		inv.AssertNotCalled(t, "UpdateDocuments")
+	})
+
+	t.Run("error RotateCredentials invalid options", func(t *testing.T) {
+		inv := &mockinventory.MockInventory{}
+
+		co := inventory.NewOptions(inv)
+		_, err := co.RotateCredentials(ioutil.Discard)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
+
+		co.All = true
+		co.PasswordLength = 4
+		_, err = co.RotateCredentials(ioutil.Discard)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "password length must be at least")
 	})
 
 	t.Run("success RemoteDirect", func(t *testing.T) {
@@ -180,6 +320,44 @@ func TestCommandOptions(t *testing.T) {
 		assert.Error(t, actualErr)
 	})
 
+	t.Run("success RemoteDirect iso-file", func(t *testing.T) {
+		isoFile, err := ioutil.TempFile("", "airshipctl-remotedirect-*.iso")
+		require.NoError(t, err)
+		defer os.Remove(isoFile.Name())
+		require.NoError(t, isoFile.Close())
+
+		host := &redfishutils.MockClient{}
+		host.On("NodeID").Return(testNode)
+		host.On("RemoteDirect").Once().Return(nil)
+
+		bmhInv := &mockinventory.MockBMHInventory{}
+		bmhInv.On("SelectOne").Once().Return(isoReadingClient{host}, nil)
+
+		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+
+		co := inventory.NewOptions(inv)
+		co.Name = testNode
+		co.IsoFile = isoFile.Name()
+		co.IsoServerAddress = "127.0.0.1:0"
+		co.IsoServerIdleTimeout = 10 * time.Millisecond
+		co.Timeout = time.Minute
+		assert.NoError(t, co.RemoteDirect())
+		host.AssertExpectations(t)
+	})
+
+	t.Run("error RemoteDirect iso-url and iso-file", func(t *testing.T) {
+		inv := &mockinventory.MockInventory{}
+
+		co := inventory.NewOptions(inv)
+		co.Name = testNode
+		co.IsoURL = "http://some-url"
+		co.IsoFile = "/tmp/some.iso"
+		err := co.RemoteDirect()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can not be used together")
+	})
+
 	t.Run("error RemoteDirect BMHInventory", func(t *testing.T) {
 		inv := &mockinventory.MockInventory{}
 
@@ -200,16 +378,28 @@ func TestCommandOptions(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
 	})
+}
 
-	t.Run("error RemoteDirect invalid options", func(t *testing.T) {
-		inv := &mockinventory.MockInventory{}
+// isoReadingClient reads ISO image on RemoteDirect as BMC does
+type isoReadingClient struct {
+	*redfishutils.MockClient
+}
 
-		co := inventory.NewOptions(inv)
-		buf := bytes.NewBuffer([]byte{})
-		err := co.PowerStatus(buf)
+func (c isoReadingClient) RemoteDirect(ctx context.Context, isoURL string) error {
+	resp, err := http.Get(isoURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if _, err = io.Copy(ioutil.Discard, resp.Body); err != nil {
+		return err
+	}
+	return c.MockClient.RemoteDirect(ctx, isoURL)
+}
 
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
-		assert.Len(t, buf.Bytes(), 0)
-	})
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, fmt.Errorf("broken pipe")
 }
@@ -0,0 +1,492 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	b64 "encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const passwordKey = "password"
+
+// credentialsSource is the literal document of the inventory files a credentials Secret is rendered from
+type credentialsSource struct {
+	// path of the yaml file and index of the document in it
+	path  string
+	index int
+	// content is the yaml of the document as it is in the file
+	content   string
+	encrypted bool
+	// secret is the rendered credentials Secret with the rotated password
+	secret document.Document
+}
+
+// findSources returns the literal documents of yaml files found under the root directory the given Secrets
+// are rendered from. Documents are matched by kind, name and namespace if the namespace is set in the file.
+// Error is returned if any of the Secrets is produced by a generator or changed by a patch of kustomization
+// files, or its password is not set in the document, so that it can't be updated in the file.
+func findSources(root string, secrets []document.Document) ([]credentialsSource, error) {
+	if err := checkKustomizations(root, secrets); err != nil {
+		return nil, err
+	}
+
+	pending := pendingDocuments(secrets)
+	var sources []credentialsSource
+	err := walkYAMLFiles(root, func(path string, content []byte) error {
+		for index, chunk := range splitDocuments(content) {
+			sourceDoc, idx := matchDocument(chunk, secrets, pending)
+			if sourceDoc == nil {
+				continue
+			}
+			delete(pending, idx)
+			source := credentialsSource{
+				path:      path,
+				index:     index,
+				content:   chunk,
+				encrypted: isEncrypted(sourceDoc),
+				secret:    secrets[idx],
+			}
+			if source.encrypted {
+				log.Debugf("Document %s/%s in file '%s' is encrypted", sourceDoc.GetKind(), sourceDoc.GetName(), path)
+			}
+			if _, _, err := passwordNode(source); err != nil {
+				return err
+			}
+			sources = append(sources, source)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sources, checkFound(root, secrets, pending)
+}
+
+// passwordUpdate returns the source document with only the value of the password field replaced by the
+// password of the rotated Secret, the value is written to the same field and in the same line it is set in
+func passwordUpdate(source credentialsSource) (string, error) {
+	if source.encrypted {
+		return "", ErrEncryptedDocument{File: source.path, Kind: source.secret.GetKind(), Name: source.secret.GetName()}
+	}
+	password, err := secretValue(source.secret, passwordKey)
+	if err != nil {
+		return "", err
+	}
+	node, field, err := passwordNode(source)
+	if err != nil {
+		return "", err
+	}
+
+	value, encoded := password, strconv.Quote(password)
+	if field == "data" {
+		value = b64.StdEncoding.EncodeToString([]byte(password))
+		encoded = value
+	}
+	comment := ""
+	if node.YNode().LineComment != "" {
+		comment = " " + node.YNode().LineComment
+	}
+	lines := strings.SplitAfter(source.content, "\n")
+	lineIdx, column := node.YNode().Line-1, node.YNode().Column-1
+	line := lines[lineIdx]
+	lines[lineIdx] = line[:column] + encoded + comment + line[len(strings.TrimRight(line, "\r\n")):]
+	updated := credentialsSource{path: source.path, content: strings.Join(lines, ""), secret: source.secret}
+
+	// the value is replaced in a single line, make sure it isn't continued in the next ones
+	if node, _, err = passwordNode(updated); err != nil || node.YNode().Value != value {
+		return "", ErrSecretNotLiteral{
+			Name:   source.secret.GetName(),
+			Reason: fmt.Sprintf("password in file '%s' is not a single-line value", source.path),
+		}
+	}
+	return updated.content, nil
+}
+
+// plaintextDocument returns the source document without SOPS metadata and with the values of data and
+// stringData fields set to the values of the rotated Secret, so that it can be encrypted as a whole
+func plaintextDocument(source credentialsSource) (string, error) {
+	node, err := yaml.Parse(strings.TrimPrefix(source.content, leadingComments(source.content)))
+	if err != nil {
+		return "", err
+	}
+	if _, err = node.Pipe(yaml.Clear("sops")); err != nil {
+		return "", err
+	}
+	for _, field := range []string{"stringData", "data"} {
+		var values *yaml.RNode
+		if values, err = node.Pipe(yaml.Lookup(field)); err != nil {
+			return "", err
+		}
+		if values == nil {
+			continue
+		}
+		content := values.YNode().Content
+		for idx := 0; idx+1 < len(content); idx += 2 {
+			var value string
+			if value, err = secretValue(source.secret, content[idx].Value); err != nil {
+				return "", err
+			}
+			if field == "data" {
+				value = b64.StdEncoding.EncodeToString([]byte(value))
+			}
+			content[idx+1].Value, content[idx+1].Tag, content[idx+1].Style = value, yaml.NodeTagString, 0
+		}
+	}
+
+	out, err := node.String()
+	if err != nil {
+		return "", err
+	}
+	if strings.Contains(out, "ENC[") {
+		return "", ErrSecretNotLiteral{
+			Name: source.secret.GetName(),
+			Reason: fmt.Sprintf("fields other than data and stringData of the document in file '%s' are encrypted",
+				source.path),
+		}
+	}
+	return out, nil
+}
+
+// writeSources replaces the source documents in their files with the updated ones, rest of the files
+// content is kept as is
+func writeSources(sources []credentialsSource, updated []string) error {
+	var paths []string
+	replacements := make(map[string]map[int]string)
+	for idx, source := range sources {
+		if replacements[source.path] == nil {
+			paths = append(paths, source.path)
+			replacements[source.path] = make(map[int]string)
+		}
+		replacements[source.path][source.index] = updated[idx]
+	}
+
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		log.Printf("Updating credentials Secrets in file '%s'", path)
+		if err = ioutil.WriteFile(path, replaceDocuments(content, replacements[path]), info.Mode()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replaceDocuments replaces documents of multi-document yaml file by their indexes
+func replaceDocuments(content []byte, replacements map[int]string) []byte {
+	var (
+		result strings.Builder
+		chunk  strings.Builder
+		index  int
+	)
+	flush := func() {
+		if replacement, ok := replacements[index]; ok {
+			result.WriteString(replacement)
+		} else {
+			result.WriteString(chunk.String())
+		}
+		chunk.Reset()
+		index++
+	}
+
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		if isSeparator(line) {
+			flush()
+			result.WriteString(line)
+			continue
+		}
+		chunk.WriteString(line)
+	}
+	flush()
+	return []byte(result.String())
+}
+
+// checkKustomizations returns ErrSecretNotLiteral if any of the Secrets is generated by secretGenerator
+// or may be changed by a patch of kustomization files found under the root directory
+func checkKustomizations(root string, secrets []document.Document) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !isKustomization(path) {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		k := types.Kustomization{}
+		if err = k.Unmarshal(content); err != nil {
+			return err
+		}
+		for _, secret := range secrets {
+			var reason string
+			if reason, err = kustomizedBy(k, filepath.Dir(path), secret); err != nil {
+				return err
+			}
+			if reason != "" {
+				return ErrSecretNotLiteral{
+					Name:   secret.GetName(),
+					Reason: fmt.Sprintf("it is %s in '%s'", reason, path),
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// kustomizedBy returns how the Secret is produced or changed by the kustomization, empty string is returned
+// if the Secret is used by the kustomization as is
+func kustomizedBy(k types.Kustomization, dir string, secret document.Document) (string, error) {
+	for _, generator := range k.SecretGenerator {
+		// name of generated Secret has suffix of its content hash unless it is disabled
+		if secret.GetName() == generator.Name || strings.HasPrefix(secret.GetName(), generator.Name+"-") {
+			return "generated by secretGenerator", nil
+		}
+	}
+
+	for _, patch := range k.PatchesStrategicMerge {
+		// patch is either the path to the patch file or the inline patch
+		path, inline := string(patch), ""
+		if strings.Contains(path, "\n") {
+			path, inline = "", path
+		}
+		patched, err := patchMatches(dir, path, inline, secret)
+		if err != nil || patched {
+			return "patched by patchesStrategicMerge", err
+		}
+	}
+
+	for _, patch := range append(k.PatchesJson6902, k.Patches...) {
+		if patch.Target != nil {
+			if fieldMatches(patch.Target.Kind, secret.GetKind()) && fieldMatches(patch.Target.Name, secret.GetName()) {
+				return "targeted by patches", nil
+			}
+			continue
+		}
+		patched, err := patchMatches(dir, patch.Path, patch.Patch, secret)
+		if err != nil || patched {
+			return "patched by patches", err
+		}
+	}
+	return "", nil
+}
+
+// patchMatches returns true if the patch file or the inline patch has the document with the same kind
+// and name as the Secret
+func patchMatches(dir, path, inline string, secret document.Document) (bool, error) {
+	content := []byte(inline)
+	if path != "" {
+		var err error
+		if content, err = ioutil.ReadFile(filepath.Join(dir, path)); err != nil {
+			return false, err
+		}
+	}
+
+	secrets := []document.Document{secret}
+	for _, chunk := range splitDocuments(content) {
+		if doc, _ := matchDocument(chunk, secrets, pendingDocuments(secrets)); doc != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// fieldMatches returns true if the field of patch target matches the value, empty field matches any value
+func fieldMatches(pattern, value string) bool {
+	if pattern == "" || pattern == value {
+		return true
+	}
+	matched, err := regexp.MatchString("^(?:"+pattern+")$", value)
+	return err == nil && matched
+}
+
+// passwordNode returns the password value node of the source document and the field it is set in
+func passwordNode(source credentialsSource) (*yaml.RNode, string, error) {
+	node, err := yaml.Parse(source.content)
+	if err != nil {
+		return nil, "", err
+	}
+	// the same fields order is used by document.GetSecretDataKey
+	for _, field := range []string{"stringData", "data"} {
+		var value *yaml.RNode
+		if value, err = node.Pipe(yaml.Lookup(field, passwordKey)); err != nil {
+			return nil, "", err
+		}
+		if value != nil {
+			return value, field, nil
+		}
+	}
+	return nil, "", ErrSecretNotLiteral{
+		Name:   source.secret.GetName(),
+		Reason: fmt.Sprintf("password is not set in the document in file '%s'", source.path),
+	}
+}
+
+// secretValue returns the value of the key of the rendered Secret, decoded if it is set in data field
+func secretValue(secret document.Document, key string) (string, error) {
+	if stringData, err := secret.GetStringMap("stringData"); err == nil {
+		if value, ok := stringData[key]; ok {
+			return value, nil
+		}
+	}
+	data, err := secret.GetStringMap("data")
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", document.ErrDocumentDataKeyNotFound{DocName: secret.GetName(), Key: key}
+	}
+	decoded, err := b64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", err
+	}
+	return string(decoded), nil
+}
+
+// walkYAMLFiles calls fileFunc with the content of every yaml file found under the root directory,
+// kustomization files are skipped
+func walkYAMLFiles(root string, fileFunc func(path string, content []byte) error) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := filepath.Ext(path)
+		if info.IsDir() || (ext != ".yaml" && ext != ".yml") || isKustomization(path) {
+			return nil
+		}
+
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return fileFunc(path, content)
+	})
+}
+
+// isKustomization returns true if the file is a kustomization file
+func isKustomization(path string) bool {
+	switch filepath.Base(path) {
+	case "kustomization.yaml", "kustomization.yml", "Kustomization":
+		return true
+	}
+	return false
+}
+
+// pendingDocuments returns indexes of the documents that are not yet found in the files
+func pendingDocuments(docs []document.Document) map[int]bool {
+	pending := make(map[int]bool, len(docs))
+	for idx := range docs {
+		pending[idx] = true
+	}
+	return pending
+}
+
+// checkFound returns ErrDocumentsNotFound if any of the documents is still pending
+func checkFound(root string, docs []document.Document, pending map[int]bool) error {
+	if len(pending) == 0 {
+		return nil
+	}
+	notFound := make([]string, 0, len(pending))
+	for idx, doc := range docs {
+		if pending[idx] {
+			notFound = append(notFound, doc.GetKind()+"/"+doc.GetName())
+		}
+	}
+	return ErrDocumentsNotFound{Root: root, Documents: notFound}
+}
+
+// matchDocument returns the source document parsed and the index of the pending document matching it,
+// nil is returned if the source isn't matched by any of the pending documents
+func matchDocument(source string, docs []document.Document, pending map[int]bool) (document.Document, int) {
+	if strings.TrimSpace(source) == "" {
+		return nil, -1
+	}
+	sourceDoc, err := document.NewDocumentFromBytes([]byte(source))
+	if err != nil || sourceDoc.GetKind() == "" {
+		// anything that is not a kubernetes object is never matched
+		return nil, -1
+	}
+
+	for idx, doc := range docs {
+		if pending[idx] &&
+			doc.GetKind() == sourceDoc.GetKind() &&
+			doc.GetName() == sourceDoc.GetName() &&
+			(sourceDoc.GetNamespace() == "" || doc.GetNamespace() == sourceDoc.GetNamespace()) {
+			return sourceDoc, idx
+		}
+	}
+	return nil, -1
+}
+
+// splitDocuments returns documents of multi-document yaml file
+func splitDocuments(content []byte) []string {
+	var (
+		sources []string
+		chunk   strings.Builder
+	)
+	for _, line := range strings.SplitAfter(string(content), "\n") {
+		if isSeparator(line) {
+			sources = append(sources, chunk.String())
+			chunk.Reset()
+			continue
+		}
+		chunk.WriteString(line)
+	}
+	return append(sources, chunk.String())
+}
+
+// isSeparator returns true if the line separates documents of multi-document yaml file
+func isSeparator(line string) bool {
+	return strings.TrimRight(line, " \r\n") == "---"
+}
+
+// isEncrypted returns true if the document is encrypted with SOPS
+func isEncrypted(doc document.Document) bool {
+	_, err := doc.GetFieldValue("sops")
+	return err == nil
+}
+
+// leadingComments returns comments and empty lines the source document starts with
+func leadingComments(source string) string {
+	var comments strings.Builder
+	for _, line := range strings.SplitAfter(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			break
+		}
+		comments.WriteString(line)
+	}
+	return comments.String()
+}
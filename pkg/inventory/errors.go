@@ -14,7 +14,10 @@
 
 package inventory
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ErrInvalidOptions is returned when incompetible flags are
 type ErrInvalidOptions struct {
@@ -24,3 +27,101 @@ type ErrInvalidOptions struct {
 func (e ErrInvalidOptions) Error() string {
 	return fmt.Sprintf("invalid options are supplied: %s", e.Message)
 }
+
+// ErrHardwareInventoryFailed is returned when hardware inventory failed to be collected from some of the hosts
+type ErrHardwareInventoryFailed struct {
+	NodeIDs []string
+}
+
+func (e ErrHardwareInventoryFailed) Error() string {
+	return fmt.Sprintf("failed to collect hardware inventory of hosts with node ids: %s",
+		strings.Join(e.NodeIDs, ", "))
+}
+
+// ErrDocumentsNotFound is returned when updated documents are not found in the inventory files
+type ErrDocumentsNotFound struct {
+	Root      string
+	Documents []string
+}
+
+func (e ErrDocumentsNotFound) Error() string {
+	return fmt.Sprintf("documents %s are not found in yaml files under '%s'",
+		strings.Join(e.Documents, ", "), e.Root)
+}
+
+// ErrEncryptedDocument is returned when encrypted inventory document is about to be replaced by the
+// document that isn't encrypted
+type ErrEncryptedDocument struct {
+	File string
+	Kind string
+	Name string
+}
+
+func (e ErrEncryptedDocument) Error() string {
+	return fmt.Sprintf("document %s/%s in file '%s' is encrypted, set encrypter to update it",
+		e.Kind, e.Name, e.File)
+}
+
+// ErrEncrypterNotFound is returned when the GenericContainer used to encrypt documents written to the
+// inventory is not found in the phase bundle
+type ErrEncrypterNotFound struct {
+	Name string
+	Err  error
+}
+
+func (e ErrEncrypterNotFound) Error() string {
+	return fmt.Sprintf("unable to find GenericContainer '%s' to encrypt inventory documents: %v", e.Name, e.Err)
+}
+
+// ErrEncrypterType is returned when the GenericContainer used to encrypt documents written to the inventory
+// is not a KRM function
+type ErrEncrypterType struct {
+	Name string
+}
+
+func (e ErrEncrypterType) Error() string {
+	return fmt.Sprintf("GenericContainer '%s' used to encrypt inventory documents must be of krm type", e.Name)
+}
+
+// ErrEncrypterOutput is returned when the encrypted document is not found in the output of the encrypter
+type ErrEncrypterOutput struct {
+	Encrypter string
+	Kind      string
+	Name      string
+}
+
+func (e ErrEncrypterOutput) Error() string {
+	return fmt.Sprintf("document %s/%s is not found in the output of GenericContainer '%s'",
+		e.Kind, e.Name, e.Encrypter)
+}
+
+// ErrSecretNotLiteral is returned when password of the credentials Secret can't be updated in the inventory,
+// since the Secret is not rendered from a literal document of the inventory files as is
+type ErrSecretNotLiteral struct {
+	Name   string
+	Reason string
+}
+
+func (e ErrSecretNotLiteral) Error() string {
+	return fmt.Sprintf("password of Secret '%s' can't be updated in the inventory: %s", e.Name, e.Reason)
+}
+
+// ErrCredentialsNotWritten is returned when rotated BMC credentials couldn't be written to the inventory,
+// the credentials Secrets are printed only if it is requested by the options
+type ErrCredentialsNotWritten struct {
+	Err      error
+	Printed  bool
+	PrintErr error
+}
+
+func (e ErrCredentialsNotWritten) Error() string {
+	msg := fmt.Sprintf("failed to write rotated BMC credentials to the inventory: %v", e.Err)
+	switch {
+	case e.PrintErr != nil:
+		return fmt.Sprintf("%s, failed to print rotated credentials Secrets: %v", msg, e.PrintErr)
+	case e.Printed:
+		return msg + ", rotated credentials Secrets are printed unencrypted, update the inventory with them"
+	default:
+		return msg + ", rotated credentials Secrets are not printed, BMC passwords have to be reset manually"
+	}
+}
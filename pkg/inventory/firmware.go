@@ -0,0 +1,70 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+// PrintFirmwareInventory prints firmware components installed on the selected hosts
+func (o *CommandOptions) PrintFirmwareInventory(w io.Writer) error {
+	if err := o.validateBMHAction(); err != nil {
+		return err
+	}
+
+	bmhInventory, err := o.Inventory.BaremetalInventory()
+	if err != nil {
+		return err
+	}
+
+	hosts, err := bmhInventory.Select(o.selector())
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return baremetal.ErrNoBaremetalHostsFound{Selector: o.selector()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+
+	tw := util.NewTabWriter(w)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tCOMPONENT\tDESCRIPTION\tVERSION\tUPDATEABLE")
+	for _, host := range hosts {
+		bmh, ok := host.(baremetal.Host)
+		if !ok {
+			bmh = baremetal.Host{Client: host}
+		}
+
+		var components []remoteifc.FirmwareComponent
+		components, err = bmh.FirmwareInventory(ctx)
+		baremetal.CloseSession(ctx, bmh)
+		if err != nil {
+			return err
+		}
+
+		for _, component := range components {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%t\n", bmh.Namespace, bmh.Name,
+				component.ID, component.Name, component.Version, component.Updateable)
+		}
+	}
+	return tw.Flush()
+}
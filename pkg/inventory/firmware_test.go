@@ -0,0 +1,63 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+func newFirmwareHost(name string, err error) remoteifc.Client {
+	client := &redfishutils.MockClient{}
+	client.On("FirmwareInventory").Once().Return([]remoteifc.FirmwareComponent{
+		{ID: "BMC", Name: "Contoso BMC Firmware", Version: "1.45.455b66-rev4", Updateable: true},
+		{ID: "NIC.Slot.2", Name: "Contoso 25GbE Adapter", Version: "20.5.13"},
+	}, err)
+	return baremetal.Host{Client: client, Name: name, Namespace: "metal3"}
+}
+
+func TestPrintFirmwareInventory(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		co := newSelectOptions(newFirmwareHost("master-0", nil), newFirmwareHost("master-1", nil))
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.PrintFirmwareInventory(buf))
+
+		expected := "NAMESPACE   NAME       COMPONENT    DESCRIPTION             VERSION            UPDATEABLE\n" +
+			"metal3      master-0   BMC          Contoso BMC Firmware    1.45.455b66-rev4   true\n" +
+			"metal3      master-0   NIC.Slot.2   Contoso 25GbE Adapter   20.5.13            false\n" +
+			"metal3      master-1   BMC          Contoso BMC Firmware    1.45.455b66-rev4   true\n" +
+			"metal3      master-1   NIC.Slot.2   Contoso 25GbE Adapter   20.5.13            false\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("error FirmwareInventory", func(t *testing.T) {
+		expectedErr := fmt.Errorf("BMC is unreachable")
+		co := newSelectOptions(newFirmwareHost("master-0", expectedErr))
+		assert.Equal(t, expectedErr, co.PrintFirmwareInventory(bytes.NewBuffer(nil)))
+	})
+
+	t.Run("error no hosts", func(t *testing.T) {
+		co := newSelectOptions()
+		assert.IsType(t, baremetal.ErrNoBaremetalHostsFound{}, co.PrintFirmwareInventory(bytes.NewBuffer(nil)))
+	})
+}
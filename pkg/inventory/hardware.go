@@ -0,0 +1,333 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+const (
+	// HardwareFormatReport prints normalized hardware report of every selected host
+	HardwareFormatReport = "report"
+	// HardwareFormatProfile prints HardwareClassification profile matching every selected host
+	HardwareFormatProfile = "hardware-profile"
+	// HardwareFormatBMH prints BareMetalHost fragment with hardware details annotation for every selected host
+	HardwareFormatBMH = "bmh"
+
+	// OutputFormatTable prints human readable table
+	OutputFormatTable = "table"
+	// OutputFormatYAML prints documents in yaml format
+	OutputFormatYAML = "yaml"
+	// OutputFormatJSON prints documents in json format
+	OutputFormatJSON = "json"
+
+	// InspectAnnotation is used by metal3 to disable inspection of the host
+	InspectAnnotation = "inspect.metal3.io"
+	// HardwareDetailsAnnotation is used by metal3 to populate hardware details of the host when
+	// inspection is disabled
+	HardwareDetailsAnnotation = "inspect.metal3.io/hardwaredetails"
+
+	metal3APIVersion      = "metal3.io/v1alpha1"
+	bytesInGiB            = 1 << 30
+	mebibytesInGiB        = 1 << 10
+	inspectDisabled       = "disabled"
+	hwccProfileNamePrefix = "hardwareclassification-"
+)
+
+// HostHardware is hardware inventory of single baremetal host
+type HostHardware struct {
+	Name      string                     `json:"name"`
+	Namespace string                     `json:"namespace"`
+	NodeID    string                     `json:"nodeID"`
+	Hardware  *remoteifc.HardwareDetails `json:"hardware,omitempty"`
+	Error     string                     `json:"error,omitempty"`
+}
+
+// HardwareReport is hardware inventory of the selected baremetal hosts
+type HardwareReport struct {
+	Hosts []HostHardware `json:"hosts"`
+}
+
+type objectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type object struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Metadata   objectMeta  `json:"metadata"`
+	Spec       interface{} `json:"spec,omitempty"`
+}
+
+type objectList struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Items      []object `json:"items"`
+}
+
+type hwccSpec struct {
+	HardwareCharacteristics hwccCharacteristics `json:"hardwareCharacteristics"`
+}
+
+type hwccCharacteristics struct {
+	CPU  hwccCPU  `json:"cpu"`
+	Disk hwccDisk `json:"disk"`
+	RAM  hwccRAM  `json:"ram"`
+	NIC  hwccNIC  `json:"nic"`
+}
+
+type hwccCPU struct {
+	MinimumCount    int `json:"minimumCount"`
+	MaximumCount    int `json:"maximumCount"`
+	MinimumSpeedMHz int `json:"minimumSpeedMHz,omitempty"`
+	MaximumSpeedMHz int `json:"maximumSpeedMHz,omitempty"`
+}
+
+type hwccDisk struct {
+	MinimumCount            int   `json:"minimumCount"`
+	MaximumCount            int   `json:"maximumCount"`
+	MinimumIndividualSizeGB int64 `json:"minimumIndividualSizeGB,omitempty"`
+	MaximumIndividualSizeGB int64 `json:"maximumIndividualSizeGB,omitempty"`
+}
+
+type hwccRAM struct {
+	MinimumSizeGB int `json:"minimumSizeGB"`
+	MaximumSizeGB int `json:"maximumSizeGB"`
+}
+
+type hwccNIC struct {
+	MinimumCount int `json:"minimumCount"`
+	MaximumCount int `json:"maximumCount"`
+}
+
+func (o *CommandOptions) validateHardwareInventory() error {
+	if err := o.validateBMHAction(); err != nil {
+		return err
+	}
+	switch o.HardwareFormat {
+	case HardwareFormatReport, HardwareFormatProfile, HardwareFormatBMH:
+	default:
+		return ErrInvalidOptions{Message: fmt.Sprintf("format must be one of '%s', '%s' or '%s', got '%s'",
+			HardwareFormatReport, HardwareFormatProfile, HardwareFormatBMH, o.HardwareFormat)}
+	}
+	switch o.OutputFormat {
+	case OutputFormatYAML, OutputFormatJSON:
+	default:
+		return ErrInvalidOptions{Message: fmt.Sprintf("output format must be one of '%s' or '%s', got '%s'",
+			OutputFormatYAML, OutputFormatJSON, o.OutputFormat)}
+	}
+	return nil
+}
+
+// HardwareInventory collects hardware inventory of the selected hosts from their BMCs and prints it
+// in the requested format. Hosts that inventory failed to be collected for are listed in the report
+// with the error, ErrHardwareInventoryFailed is returned once the output is printed.
+func (o *CommandOptions) HardwareInventory(w io.Writer) error {
+	if err := o.validateHardwareInventory(); err != nil {
+		return err
+	}
+
+	bmhInventory, err := o.Inventory.BaremetalInventory()
+	if err != nil {
+		return err
+	}
+
+	hosts, err := bmhInventory.Select(o.selector())
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return baremetal.ErrNoBaremetalHostsFound{Selector: o.selector()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+	report := o.collectHardware(ctx, hosts)
+
+	if err = printHardware(w, report, o.HardwareFormat, o.OutputFormat); err != nil {
+		return err
+	}
+
+	var failed []string
+	for _, host := range report.Hosts {
+		if host.Error != "" {
+			failed = append(failed, host.NodeID)
+		}
+	}
+	if len(failed) > 0 {
+		return ErrHardwareInventoryFailed{NodeIDs: failed}
+	}
+	return nil
+}
+
+// collectHardware queries BMCs of the hosts, at most o.MaxConcurrency at a time
+func (o *CommandOptions) collectHardware(ctx context.Context, hosts []remoteifc.Client) HardwareReport {
+	report := HardwareReport{Hosts: make([]HostHardware, len(hosts))}
+	for idx, host := range hosts {
+		report.Hosts[idx] = HostHardware{NodeID: host.NodeID()}
+		if bmh, ok := host.(baremetal.Host); ok {
+			report.Hosts[idx].Name = bmh.Name
+			report.Hosts[idx].Namespace = bmh.Namespace
+		}
+	}
+
+	baremetal.RunBatch(hosts, func(idx int, host remoteifc.Client) error {
+		defer baremetal.CloseSession(ctx, host)
+		result := &report.Hosts[idx]
+
+		log.Debugf("Collecting hardware inventory of host with node id '%s'", result.NodeID)
+		details, hwErr := remoteifc.GetHardwareDetails(ctx, host)
+		if hwErr != nil {
+			log.Printf("Failed to collect hardware inventory of host with node id '%s': %v", result.NodeID, hwErr)
+			result.Error = hwErr.Error()
+			return hwErr
+		}
+		result.Hardware = details
+		return nil
+	}, ifc.BaremetalBatchRunOptions{MaxConcurrency: o.MaxConcurrency})
+
+	return report
+}
+
+func printHardware(w io.Writer, report HardwareReport, format, output string) error {
+	if format == HardwareFormatReport {
+		return writeObject(w, report, output)
+	}
+
+	var objects []object
+	for _, host := range report.Hosts {
+		if host.Hardware == nil {
+			continue
+		}
+		if format == HardwareFormatProfile {
+			objects = append(objects, hardwareProfile(host))
+			continue
+		}
+		obj, err := bmhFragment(host)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, obj)
+	}
+
+	if output == OutputFormatJSON {
+		return writeObject(w, objectList{APIVersion: "v1", Kind: "List", Items: objects}, output)
+	}
+	for _, obj := range objects {
+		if _, err := io.WriteString(w, "---\n"); err != nil {
+			return err
+		}
+		if err := writeObject(w, obj, output); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeObject(w io.Writer, obj interface{}, output string) error {
+	var out []byte
+	var err error
+	if output == OutputFormatJSON {
+		out, err = json.MarshalIndent(obj, "", "  ")
+		out = append(out, '\n')
+	} else {
+		out, err = yaml.Marshal(obj)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// hardwareProfile returns HardwareClassification profile that matches exactly the hardware of the host
+func hardwareProfile(host HostHardware) object {
+	hw := host.Hardware
+	chars := hwccCharacteristics{
+		CPU: hwccCPU{
+			MinimumCount:    hw.CPU.Count,
+			MaximumCount:    hw.CPU.Count,
+			MinimumSpeedMHz: int(math.Floor(hw.CPU.ClockMegahertz)),
+			MaximumSpeedMHz: int(math.Ceil(hw.CPU.ClockMegahertz)),
+		},
+		Disk: hwccDisk{
+			MinimumCount: len(hw.Storage),
+			MaximumCount: len(hw.Storage),
+		},
+		RAM: hwccRAM{
+			MinimumSizeGB: hw.RAMMebibytes / mebibytesInGiB,
+			MaximumSizeGB: (hw.RAMMebibytes + mebibytesInGiB - 1) / mebibytesInGiB,
+		},
+		NIC: hwccNIC{
+			MinimumCount: len(hw.NIC),
+			MaximumCount: len(hw.NIC),
+		},
+	}
+	for idx, disk := range hw.Storage {
+		minSize := disk.SizeBytes / bytesInGiB
+		maxSize := (disk.SizeBytes + bytesInGiB - 1) / bytesInGiB
+		if idx == 0 || minSize < chars.Disk.MinimumIndividualSizeGB {
+			chars.Disk.MinimumIndividualSizeGB = minSize
+		}
+		if maxSize > chars.Disk.MaximumIndividualSizeGB {
+			chars.Disk.MaximumIndividualSizeGB = maxSize
+		}
+	}
+
+	return object{
+		APIVersion: metal3APIVersion,
+		Kind:       "HardwareClassification",
+		Metadata: objectMeta{
+			Name:      hwccProfileNamePrefix + strings.ToLower(host.Name),
+			Namespace: host.Namespace,
+		},
+		Spec: hwccSpec{HardwareCharacteristics: chars},
+	}
+}
+
+// bmhFragment returns BareMetalHost metadata with hardware details annotation, it can be merged into
+// BareMetalHost document to skip inspection of the host by metal3
+func bmhFragment(host HostHardware) (object, error) {
+	details, err := json.Marshal(host.Hardware)
+	if err != nil {
+		return object{}, err
+	}
+	return object{
+		APIVersion: metal3APIVersion,
+		Kind:       "BareMetalHost",
+		Metadata: objectMeta{
+			Name:      host.Name,
+			Namespace: host.Namespace,
+			Annotations: map[string]string{
+				InspectAnnotation:         inspectDisabled,
+				HardwareDetailsAnnotation: string(details),
+			},
+		},
+	}, nil
+}
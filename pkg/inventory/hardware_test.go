@@ -0,0 +1,212 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	mockinventory "opendev.org/airship/airshipctl/testutil/inventory"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+var testHardware = &remoteifc.HardwareDetails{
+	SystemVendor: remoteifc.HardwareSystemVendor{Manufacturer: "Contoso", ProductName: "3500"},
+	RAMMebibytes: 65536,
+	NIC: []remoteifc.HardwareNIC{
+		{Name: "NIC.1", MAC: "52:54:00:b6:ed:31", SpeedGbps: 25},
+		{Name: "NIC.2", MAC: "52:54:00:b6:ed:32", SpeedGbps: 1},
+	},
+	Storage: []remoteifc.HardwareStorage{
+		{Name: "Disk.0", SizeBytes: 480103981056},
+		{Name: "Disk.1", SizeBytes: 1999844147200, Rotational: true},
+	},
+	CPU: remoteifc.HardwareCPU{Arch: "x86_64", Model: "Xeon", ClockMegahertz: 3700, Count: 32},
+}
+
+func newHardwareOptions(t *testing.T, hosts ...remoteifc.Client) *inventory.CommandOptions {
+	t.Helper()
+	bmhInv := &mockinventory.MockBMHInventory{}
+	bmhInv.On("Select").Once().Return(hosts, nil)
+
+	inv := &mockinventory.MockInventory{}
+	inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+
+	co := inventory.NewOptions(inv)
+	co.All = true
+	co.HardwareFormat = inventory.HardwareFormatReport
+	co.OutputFormat = inventory.OutputFormatYAML
+	return co
+}
+
+func newHardwareHost(name, nodeID string, details *remoteifc.HardwareDetails, err error) remoteifc.Client {
+	client := &redfishutils.MockClient{}
+	client.On("NodeID").Return(nodeID)
+	client.On("HardwareDetails").Once().Return(details, err)
+	return baremetal.Host{Client: client, Name: name, Namespace: "metal3"}
+}
+
+func TestHardwareInventory(t *testing.T) {
+	t.Run("error invalid format", func(t *testing.T) {
+		co := inventory.NewOptions(&mockinventory.MockInventory{})
+		co.All = true
+		co.HardwareFormat = "profile"
+		co.OutputFormat = inventory.OutputFormatYAML
+		err := co.HardwareInventory(bytes.NewBuffer(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "format must be one of")
+	})
+
+	t.Run("error invalid output format", func(t *testing.T) {
+		co := inventory.NewOptions(&mockinventory.MockInventory{})
+		co.All = true
+		co.HardwareFormat = inventory.HardwareFormatReport
+		co.OutputFormat = "table"
+		err := co.HardwareInventory(bytes.NewBuffer(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "output format must be one of")
+	})
+
+	t.Run("error invalid selector options", func(t *testing.T) {
+		co := inventory.NewOptions(&mockinventory.MockInventory{})
+		err := co.HardwareInventory(bytes.NewBuffer(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
+	})
+
+	t.Run("error Select", func(t *testing.T) {
+		expectedErr := fmt.Errorf("select error")
+		bmhInv := &mockinventory.MockBMHInventory{}
+		bmhInv.On("Select").Once().Return(nil, expectedErr)
+		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+
+		co := inventory.NewOptions(inv)
+		co.All = true
+		co.HardwareFormat = inventory.HardwareFormatReport
+		co.OutputFormat = inventory.OutputFormatYAML
+		assert.Equal(t, expectedErr, co.HardwareInventory(bytes.NewBuffer(nil)))
+	})
+
+	t.Run("error no hosts", func(t *testing.T) {
+		co := newHardwareOptions(t)
+		err := co.HardwareInventory(bytes.NewBuffer(nil))
+		assert.IsType(t, baremetal.ErrNoBaremetalHostsFound{}, err)
+	})
+
+	t.Run("success report with failed host", func(t *testing.T) {
+		co := newHardwareOptions(t,
+			newHardwareHost("master-0", "node-0", testHardware, nil),
+			newHardwareHost("master-1", "node-1", nil, fmt.Errorf("BMC is unreachable")))
+		co.MaxConcurrency = 2
+		buf := bytes.NewBuffer(nil)
+		err := co.HardwareInventory(buf)
+		assert.Equal(t, inventory.ErrHardwareInventoryFailed{NodeIDs: []string{"node-1"}}, err)
+
+		report := inventory.HardwareReport{}
+		require.NoError(t, yaml.Unmarshal(buf.Bytes(), &report))
+		assert.Equal(t, inventory.HardwareReport{Hosts: []inventory.HostHardware{
+			{Name: "master-0", Namespace: "metal3", NodeID: "node-0", Hardware: testHardware},
+			{Name: "master-1", Namespace: "metal3", NodeID: "node-1", Error: "BMC is unreachable"},
+		}}, report)
+	})
+
+	t.Run("success report json", func(t *testing.T) {
+		co := newHardwareOptions(t, newHardwareHost("master-0", "node-0", testHardware, nil))
+		co.OutputFormat = inventory.OutputFormatJSON
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.HardwareInventory(buf))
+
+		report := inventory.HardwareReport{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+		require.Len(t, report.Hosts, 1)
+		assert.Equal(t, testHardware, report.Hosts[0].Hardware)
+	})
+
+	t.Run("success hardware profile", func(t *testing.T) {
+		co := newHardwareOptions(t,
+			newHardwareHost("master-0", "node-0", testHardware, nil),
+			newHardwareHost("master-1", "node-1", nil, fmt.Errorf("BMC is unreachable")))
+		co.HardwareFormat = inventory.HardwareFormatProfile
+		buf := bytes.NewBuffer(nil)
+		require.Error(t, co.HardwareInventory(buf))
+
+		expected := `---
+apiVersion: metal3.io/v1alpha1
+kind: HardwareClassification
+metadata:
+  name: hardwareclassification-master-0
+  namespace: metal3
+spec:
+  hardwareCharacteristics:
+    cpu:
+      maximumCount: 32
+      maximumSpeedMHz: 3700
+      minimumCount: 32
+      minimumSpeedMHz: 3700
+    disk:
+      maximumCount: 2
+      maximumIndividualSizeGB: 1863
+      minimumCount: 2
+      minimumIndividualSizeGB: 447
+    nic:
+      maximumCount: 2
+      minimumCount: 2
+    ram:
+      maximumSizeGB: 64
+      minimumSizeGB: 64
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("success bmh fragment", func(t *testing.T) {
+		co := newHardwareOptions(t, newHardwareHost("master-0", "node-0", testHardware, nil))
+		co.HardwareFormat = inventory.HardwareFormatBMH
+		co.OutputFormat = inventory.OutputFormatJSON
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.HardwareInventory(buf))
+
+		list := struct {
+			Kind  string `json:"kind"`
+			Items []struct {
+				Kind     string `json:"kind"`
+				Metadata struct {
+					Name        string            `json:"name"`
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+			} `json:"items"`
+		}{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &list))
+		assert.Equal(t, "List", list.Kind)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "BareMetalHost", list.Items[0].Kind)
+		assert.Equal(t, "master-0", list.Items[0].Metadata.Name)
+		assert.Equal(t, "disabled", list.Items[0].Metadata.Annotations[inventory.InspectAnnotation])
+
+		details := &remoteifc.HardwareDetails{}
+		require.NoError(t, json.Unmarshal(
+			[]byte(list.Items[0].Metadata.Annotations[inventory.HardwareDetailsAnnotation]), details))
+		assert.Equal(t, testHardware, details)
+	})
+}
@@ -17,19 +17,28 @@ package ifc
 import (
 	"context"
 
+	"opendev.org/airship/airshipctl/pkg/document"
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
 )
 
 // Inventory interface for airshipctl
 type Inventory interface {
 	BaremetalInventory() (BaremetalInventory, error)
+	UpdateDocuments(bundle document.Bundle, encrypter string) error
+	ResolveEncrypter(bundle document.Bundle, encrypter string) (string, error)
 }
 
 // BaremetalInventory interface that allows working with baremetal hosts
 type BaremetalInventory interface {
 	Select(BaremetalHostSelector) ([]remoteifc.Client, error)
 	SelectOne(BaremetalHostSelector) (remoteifc.Client, error)
-	RunOperation(context.Context, BaremetalOperation, BaremetalHostSelector, BaremetalBatchRunOptions) error
+	RunOperation(context.Context, BaremetalOperation, BaremetalHostSelector, BaremetalBatchRunOptions) (
+		BaremetalBatchResult, error)
+	RenderHosts(BaremetalHostSelector) (document.Bundle, error)
+	RotateCredentials(context.Context, BaremetalHostSelector, BaremetalBatchRunOptions) (
+		BaremetalBatchResult, document.Bundle, error)
+	CredentialsSecrets(BaremetalHostSelector) (document.Bundle, error)
 }
 
 // BaremetalOperation baremetal operation
@@ -44,9 +53,81 @@ const (
 	BaremetalOperationPowerOn BaremetalOperation = "power-on"
 	// BaremetalOperationEjectVirtualMedia eject virtual media
 	BaremetalOperationEjectVirtualMedia BaremetalOperation = "eject-virtual-media"
+	// BaremetalOperationPowerCycle power cycle
+	BaremetalOperationPowerCycle BaremetalOperation = "power-cycle"
+	// BaremetalOperationSetBootDevice set boot device
+	BaremetalOperationSetBootDevice BaremetalOperation = "set-boot-device"
+	// BaremetalOperationWaitForPowerState wait for host to reach power state
+	BaremetalOperationWaitForPowerState BaremetalOperation = "wait-for-power-state"
+	// BaremetalOperationApplyBIOSSettings apply BIOS settings referenced by the host
+	BaremetalOperationApplyBIOSSettings BaremetalOperation = "apply-bios-settings"
+	// BaremetalOperationUpdateFirmware update firmware from the image
+	BaremetalOperationUpdateFirmware BaremetalOperation = "update-firmware"
+	// BaremetalOperationRotateCredentials rotate BMC credentials
+	BaremetalOperationRotateCredentials BaremetalOperation = "rotate-credentials"
 )
 
-// BaremetalBatchRunOptions are options to be passed to RunOperation, this is to be
-// exetended in the future, to support such things as concurency
+// BaremetalOperationOptions hold parameters of the operations that require them
+type BaremetalOperationOptions struct {
+	// BootDevice to be set by set-boot-device operation
+	BootDevice remoteifc.BootDevice
+	// Persistent makes set-boot-device operation keep the boot device for all future boots,
+	// otherwise the host boots from the device only once
+	Persistent bool
+	// PowerState to wait for by wait-for-power-state operation
+	PowerState power.Status
+	// SkipReboot makes apply-bios-settings operation leave BIOS settings pending until the next
+	// reboot of the host, otherwise the host is rebooted to apply them
+	SkipReboot bool
+	// SkipUnreferencedHosts makes apply-bios-settings operation skip the hosts that don't reference
+	// BIOS settings, otherwise the operation fails against such hosts
+	SkipUnreferencedHosts bool
+	// PasswordLength is the length of BMC passwords generated by rotate-credentials operation,
+	// zero or negative value means that the default length is used
+	PasswordLength int
+	// FirmwareImageURL is URL of the firmware image to be installed by update-firmware operation,
+	// the image is downloaded by the BMC so the URL must be reachable from BMC network
+	FirmwareImageURL string
+}
+
+// BaremetalBatchRunOptions are options to be passed to RunOperation
 type BaremetalBatchRunOptions struct {
+	// MaxConcurrency is the maximum number of hosts the operation is performed against
+	// at the same time, zero or negative value means that hosts are processed one by one
+	MaxConcurrency int
+	// FailFast stops starting the operation against remaining hosts after first failure,
+	// hosts that were not processed are reported as skipped
+	FailFast bool
+	// ContinueOnError makes RunOperation return no error even if operation failed against
+	// some of the hosts, per host errors are still reported in the result
+	ContinueOnError bool
+	// OperationOptions are passed to the operation performed against every host
+	OperationOptions BaremetalOperationOptions
+}
+
+// BaremetalHostResult is the result of the operation performed against single baremetal host
+type BaremetalHostResult struct {
+	Name      string
+	Namespace string
+	NodeID    string
+	// Skipped is true if operation was not started against the host
+	Skipped bool
+	Error   error
+}
+
+// BaremetalBatchResult is aggregated result of the operation performed against multiple hosts
+type BaremetalBatchResult struct {
+	Operation BaremetalOperation
+	Hosts     []BaremetalHostResult
+}
+
+// Failed returns results of the hosts that operation failed against
+func (r BaremetalBatchResult) Failed() []BaremetalHostResult {
+	var failed []BaremetalHostResult
+	for _, host := range r.Hosts {
+		if host.Error != nil {
+			failed = append(failed, host)
+		}
+	}
+	return failed
 }
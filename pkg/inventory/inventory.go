@@ -15,14 +15,23 @@
 package inventory
 
 import (
+	"bytes"
 	"path/filepath"
+	"strings"
 
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
 )
 
+// DefaultEncrypter is the name of the GenericContainer used to encrypt updated documents which are encrypted
+// with SOPS in the inventory, if no other encrypter is specified
+const DefaultEncrypter = "encrypter"
+
 var _ ifc.Inventory = Inventory{}
 
 // Inventory implementation of the interface
@@ -49,12 +58,141 @@ func (i Inventory) BaremetalInventory() (ifc.BaremetalInventory, error) {
 		return nil, err
 	}
 
-	targetPath, err := cfg.CurrentContextTargetPath()
+	paths, err := manifestPaths(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	phaseDir, err := cfg.CurrentContextInventoryRepositoryName()
+	bundle, err := document.NewBundleByPath(paths.inventory)
+	if err != nil {
+		return nil, err
+	}
+	return baremetal.NewInventory(mgmCfg, bundle), nil
+}
+
+// UpdateDocuments updates passwords of the credentials Secrets of the bundle in the inventory source files,
+// only the password field of the literal documents with the same kind, name and namespace is changed.
+// If the GenericContainer returned by ResolveEncrypter is set, the documents are encrypted by it as a whole,
+// since SOPS MAC covers all the values of the document. Error is returned if any of the Secrets is produced
+// by a generator or changed by a patch, documents encrypted in the inventory are never written unencrypted.
+func (i Inventory) UpdateDocuments(bundle document.Bundle, encrypter string) error {
+	cfg, paths, sources, err := i.credentialsSources(bundle)
+	if err != nil {
+		return err
+	}
+
+	conf, err := encrypterConfig(cfg, paths, sources, encrypter)
+	if err != nil {
+		return err
+	}
+
+	updated := make([]string, len(sources))
+	if conf != nil {
+		updated, err = encryptSources(conf, sources, paths.target)
+		if err != nil {
+			return err
+		}
+	} else {
+		for idx, source := range sources {
+			if updated[idx], err = passwordUpdate(source); err != nil {
+				return err
+			}
+		}
+	}
+	return writeSources(sources, updated)
+}
+
+// ResolveEncrypter returns the name of the GenericContainer that encrypts documents of the bundle before they
+// are written to the inventory by UpdateDocuments, empty name means that the documents are written unencrypted.
+// If encrypter isn't set, DefaultEncrypter is used if any of the documents is encrypted with SOPS in the
+// inventory. Error is returned if the documents can't be updated in the inventory or the encrypter is not
+// defined.
+func (i Inventory) ResolveEncrypter(bundle document.Bundle, encrypter string) (string, error) {
+	cfg, paths, sources, err := i.credentialsSources(bundle)
+	if err != nil {
+		return "", err
+	}
+
+	conf, err := encrypterConfig(cfg, paths, sources, encrypter)
+	if err != nil || conf == nil {
+		return "", err
+	}
+	return conf.Name, nil
+}
+
+// credentialsSources returns config of the current context, its manifest paths and the literal documents of
+// the inventory files the credentials Secrets of the bundle are rendered from
+func (i Inventory) credentialsSources(bundle document.Bundle) (
+	*config.Config, contextPaths, []credentialsSource, error) {
+	cfg, err := i.Factory()
+	if err != nil {
+		return nil, contextPaths{}, nil, err
+	}
+
+	paths, err := manifestPaths(cfg)
+	if err != nil {
+		return nil, contextPaths{}, nil, err
+	}
+
+	docs, err := bundle.GetAllDocuments()
+	if err != nil {
+		return nil, contextPaths{}, nil, err
+	}
+
+	sources, err := findSources(paths.inventory, docs)
+	if err != nil {
+		return nil, contextPaths{}, nil, err
+	}
+	return cfg, paths, sources, nil
+}
+
+type contextPaths struct {
+	target    string
+	inventory string
+}
+
+// manifestPaths returns paths to the target directory and inventory bundle of the current context
+func manifestPaths(cfg *config.Config) (contextPaths, error) {
+	targetPath, err := cfg.CurrentContextTargetPath()
+	if err != nil {
+		return contextPaths{}, err
+	}
+
+	inventoryDir, err := cfg.CurrentContextInventoryRepositoryName()
+	if err != nil {
+		return contextPaths{}, err
+	}
+
+	metadata, err := cfg.CurrentContextManifestMetadata()
+	if err != nil {
+		return contextPaths{}, err
+	}
+
+	return contextPaths{
+		target:    targetPath,
+		inventory: filepath.Join(targetPath, inventoryDir, metadata.Inventory.Path),
+	}, nil
+}
+
+// encrypterConfig returns the GenericContainer that encrypts the source documents before they are written
+// to the inventory, nil is returned if the documents are written unencrypted
+func encrypterConfig(
+	cfg *config.Config,
+	paths contextPaths,
+	sources []credentialsSource,
+	encrypter string) (*v1alpha1.GenericContainer, error) {
+	encrypted := false
+	for _, source := range sources {
+		encrypted = encrypted || source.encrypted
+	}
+	if encrypter == "" && encrypted {
+		encrypter = DefaultEncrypter
+	}
+	if encrypter == "" {
+		return nil, nil
+	}
+
+	phaseDir, err := cfg.CurrentContextPhaseRepositoryDir()
 	if err != nil {
 		return nil, err
 	}
@@ -64,11 +202,94 @@ func (i Inventory) BaremetalInventory() (ifc.BaremetalInventory, error) {
 		return nil, err
 	}
 
-	inventoryBundle := filepath.Join(targetPath, phaseDir, metadata.Inventory.Path)
+	phaseBundle, err := document.NewBundleByPath(filepath.Join(paths.target, phaseDir, metadata.PhaseMeta.Path))
+	if err != nil {
+		return nil, err
+	}
 
-	bundle, err := document.NewBundleByPath(inventoryBundle)
+	selector, err := document.NewSelector().ByObject(&v1alpha1.GenericContainer{}, v1alpha1.Scheme)
 	if err != nil {
 		return nil, err
 	}
-	return baremetal.NewInventory(mgmCfg, bundle), nil
+	doc, err := phaseBundle.SelectOne(selector.ByName(encrypter))
+	if err != nil {
+		return nil, ErrEncrypterNotFound{Name: encrypter, Err: err}
+	}
+
+	conf := v1alpha1.DefaultGenericContainer()
+	if err = doc.ToAPIObject(conf, v1alpha1.Scheme); err != nil {
+		return nil, err
+	}
+	// plaintext documents are passed to KRM function container through the pipe and it is removed by the
+	// runtime once it's finished, while output of airship type container is read from its logs
+	if conf.Spec.Type != v1alpha1.GenericContainerTypeKrm {
+		return nil, ErrEncrypterType{Name: encrypter}
+	}
+	// encrypted documents are written to the inventory rather than to the container results directory
+	conf.Spec.SinkOutputDir = ""
+	return conf, nil
+}
+
+// encryptSources returns the source documents with the rotated passwords encrypted by the encrypter
+// GenericContainer, leading comments of the source documents are kept
+func encryptSources(conf *v1alpha1.GenericContainer, sources []credentialsSource, targetPath string) (
+	[]string, error) {
+	plaintext := &bytes.Buffer{}
+	for _, source := range sources {
+		doc, err := plaintextDocument(source)
+		if err != nil {
+			return nil, err
+		}
+		plaintext.WriteString("---\n" + doc)
+	}
+	bundle, err := document.NewBundleFromBytes(plaintext.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := encrypt(conf, bundle, targetPath)
+	if err != nil {
+		return nil, err
+	}
+	docs, err := encrypted.GetAllDocuments()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := pendingDocuments(docs)
+	updated := make([]string, len(sources))
+	for idx, source := range sources {
+		_, docIdx := matchDocument(source.content, docs, pending)
+		if docIdx < 0 {
+			return nil, ErrEncrypterOutput{
+				Encrypter: conf.Name,
+				Kind:      source.secret.GetKind(),
+				Name:      source.secret.GetName(),
+			}
+		}
+		delete(pending, docIdx)
+		var out []byte
+		if out, err = docs[docIdx].AsYAML(); err != nil {
+			return nil, err
+		}
+		updated[idx] = leadingComments(source.content) + string(out)
+		if !strings.HasSuffix(source.content, "\n") {
+			updated[idx] = strings.TrimSuffix(updated[idx], "\n")
+		}
+	}
+	return updated, nil
+}
+
+// encrypt runs documents of the bundle through the encrypter KRM function and returns its output
+func encrypt(conf *v1alpha1.GenericContainer, bundle document.Bundle, targetPath string) (document.Bundle, error) {
+	input := &bytes.Buffer{}
+	if err := bundle.Write(input); err != nil {
+		return nil, err
+	}
+	output := &bytes.Buffer{}
+	log.Debugf("Encrypting documents using GenericContainer '%s'", conf.Name)
+	if err := container.NewClientV1Alpha1("", input, output, conf, targetPath).Run(); err != nil {
+		return nil, err
+	}
+	return document.NewBundleFromBytes(output.Bytes())
 }
@@ -15,13 +15,19 @@
 package inventory_test
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
 )
 
 func TestBaremetalInventory(t *testing.T) {
@@ -94,3 +100,324 @@ func TestBaremetalInventory(t *testing.T) {
 		})
 	}
 }
+
+const (
+	testSecrets = `# BMC credentials
+apiVersion: v1
+kind: Secret
+metadata:
+  name: node-0-bmc-secret
+type: Opaque
+data:
+  username: YWRtaW4=
+  password: cGFzc3dvcmQ=
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: unrelated
+data:
+  key: value
+`
+
+	testEncryptedSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: node-1-bmc-secret
+data:
+  password: ENC[AES256_GCM,data:aGVsbG8=,type:str]
+sops:
+  version: 3.6.1
+`
+
+	testEncrypter = `apiVersion: airshipit.org/v1alpha1
+kind: GenericContainer
+metadata:
+  name: encrypter
+spec:
+  type: krm
+  image: gcr.io/kpt-fn-contrib/sops:v0.1.0
+`
+
+	testRotatedSecret = `apiVersion: v1
+kind: Secret
+metadata:
+  name: node-0-bmc-secret
+type: Opaque
+stringData:
+  username: admin
+  password: rotated
+`
+)
+
+func TestUpdateDocuments(t *testing.T) {
+	tests := []struct {
+		name      string
+		documents string
+		files     map[string]string
+		expected  string
+		errString string
+	}{
+		{
+			name:      "success",
+			documents: testRotatedSecret,
+			// only the password is changed, it is set in data field as it is in the source document
+			expected: strings.Replace(testSecrets, "cGFzc3dvcmQ=", "cm90YXRlZA==", 1),
+		},
+		{
+			name:      "success string data",
+			documents: testRotatedSecret,
+			files: map[string]string{
+				"secrets.yaml": `apiVersion: v1
+kind: Secret
+metadata:
+  name: node-0-bmc-secret
+stringData:
+  username: admin
+  password: 'password' # BMC password
+`,
+			},
+			expected: `apiVersion: v1
+kind: Secret
+metadata:
+  name: node-0-bmc-secret
+stringData:
+  username: admin
+  password: "rotated" # BMC password
+`,
+		},
+		{
+			name: "error document not found",
+			documents: `apiVersion: v1
+kind: Secret
+metadata:
+  name: unknown
+`,
+			errString: "documents Secret/unknown are not found",
+		},
+		{
+			name: "error encrypted document",
+			documents: `apiVersion: v1
+kind: Secret
+metadata:
+  name: node-1-bmc-secret
+stringData:
+  password: rotated
+`,
+			// encrypted documents are encrypted by default encrypter, which is not defined
+			errString: "unable to find GenericContainer 'encrypter'",
+		},
+		{
+			name:      "error secret generated",
+			documents: testRotatedSecret,
+			files: map[string]string{
+				"kustomization.yaml": `resources:
+  - secrets.yaml
+  - encrypted.yaml
+secretGenerator:
+  - name: node-0-bmc-secret
+    literals:
+      - password=password
+`,
+			},
+			errString: "it is generated by secretGenerator",
+		},
+		{
+			name:      "error secret patched",
+			documents: testRotatedSecret,
+			files: map[string]string{
+				"kustomization.yaml": `resources:
+  - secrets.yaml
+  - encrypted.yaml
+patchesStrategicMerge:
+  - patch.yaml
+`,
+				"patch.yaml": `apiVersion: v1
+kind: Secret
+metadata:
+  name: node-0-bmc-secret
+stringData:
+  password: patched
+`,
+			},
+			errString: "it is patched by patchesStrategicMerge",
+		},
+		{
+			name:      "error secret targeted by patch",
+			documents: testRotatedSecret,
+			files: map[string]string{
+				"kustomization.yaml": `resources:
+  - secrets.yaml
+  - encrypted.yaml
+patches:
+  - target:
+      kind: Secret
+      name: node-.*-bmc-secret
+    patch: |-
+      - op: replace
+        path: /data/password
+        value: cGF0Y2hlZA==
+`,
+			},
+			errString: "it is targeted by patches",
+		},
+		{
+			name:      "error password not set",
+			documents: testRotatedSecret,
+			files: map[string]string{
+				"secrets.yaml": `apiVersion: v1
+kind: Secret
+metadata:
+  name: node-0-bmc-secret
+data:
+  username: YWRtaW4=
+`,
+			},
+			errString: "password is not set in the document",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			i, inventoryPath, cleanup := newTestInventory(t, "", tt.files)
+			defer cleanup()
+
+			bundle, err := document.NewBundleFromBytes([]byte(tt.documents))
+			require.NoError(t, err)
+			err = i.UpdateDocuments(bundle, "")
+			if tt.errString != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errString)
+				return
+			}
+			require.NoError(t, err)
+
+			updated, err := ioutil.ReadFile(filepath.Join(inventoryPath, "secrets.yaml"))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(updated))
+
+			encrypted, err := ioutil.ReadFile(filepath.Join(inventoryPath, "encrypted.yaml"))
+			require.NoError(t, err)
+			assert.Equal(t, testEncryptedSecret, string(encrypted))
+		})
+	}
+}
+
+func TestResolveEncrypter(t *testing.T) {
+	tests := []struct {
+		name              string
+		documents         string
+		encrypter         string
+		encrypterConf     string
+		expectedEncrypter string
+		errString         string
+	}{
+		{
+			name:      "success unencrypted",
+			documents: testRotatedSecret,
+		},
+		{
+			name:              "success encrypted by default encrypter",
+			documents:         testEncryptedSecret,
+			encrypterConf:     testEncrypter,
+			expectedEncrypter: inventory.DefaultEncrypter,
+		},
+		{
+			name:              "success encrypter specified",
+			documents:         testRotatedSecret,
+			encrypter:         "encrypter",
+			encrypterConf:     testEncrypter,
+			expectedEncrypter: "encrypter",
+		},
+		{
+			name:      "error encrypted without encrypter",
+			documents: testEncryptedSecret,
+			errString: "unable to find GenericContainer 'encrypter'",
+		},
+		{
+			name:          "error encrypter not found",
+			documents:     testRotatedSecret,
+			encrypter:     "missing",
+			encrypterConf: testEncrypter,
+			errString:     "unable to find GenericContainer 'missing'",
+		},
+		{
+			name:          "error encrypter not krm function",
+			documents:     testEncryptedSecret,
+			encrypterConf: strings.Replace(testEncrypter, "type: krm", "type: airship", 1),
+			errString:     "GenericContainer 'encrypter' used to encrypt inventory documents must be of krm type",
+		},
+		{
+			name: "error document not found",
+			documents: `apiVersion: v1
+kind: Secret
+metadata:
+  name: unknown
+`,
+			errString: "documents Secret/unknown are not found",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			i, _, cleanup := newTestInventory(t, tt.encrypterConf, nil)
+			defer cleanup()
+
+			bundle, err := document.NewBundleFromBytes([]byte(tt.documents))
+			require.NoError(t, err)
+			actual, err := i.ResolveEncrypter(bundle, tt.encrypter)
+			if tt.errString != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errString)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedEncrypter, actual)
+		})
+	}
+}
+
+// newTestInventory writes test inventory with credentials Secrets to a temporary target directory, the
+// inventory is used as phase bundle as well, encrypter GenericContainer is added to it if not empty and
+// the files override the default ones
+func newTestInventory(t *testing.T, encrypter string, files map[string]string) (ifc.Inventory, string, func()) {
+	t.Helper()
+	targetPath, err := ioutil.TempDir("", "airship-inventory-")
+	require.NoError(t, err)
+
+	inventoryPath := filepath.Join(targetPath, "testdata")
+	require.NoError(t, os.MkdirAll(inventoryPath, 0755))
+	inventoryFiles := map[string]string{
+		"metadata.yaml":      `inventory: {path: "."}`,
+		"kustomization.yaml": "resources:\n  - secrets.yaml\n  - encrypted.yaml\n",
+		"secrets.yaml":       testSecrets,
+		"encrypted.yaml":     testEncryptedSecret,
+	}
+	if encrypter != "" {
+		inventoryFiles["kustomization.yaml"] += "  - encrypter.yaml\n"
+		inventoryFiles["encrypter.yaml"] = encrypter
+	}
+	for name, content := range files {
+		inventoryFiles[name] = content
+	}
+	for name, content := range inventoryFiles {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(inventoryPath, name), []byte(content), 0600))
+	}
+
+	i := inventory.NewInventory(func() (*config.Config, error) {
+		cfg := config.NewConfig()
+		manifest, cfgErr := cfg.CurrentContextManifest()
+		require.NoError(t, cfgErr)
+		manifest.MetadataPath = "metadata.yaml"
+		manifest.PhaseRepositoryName = "testdata"
+		manifest.InventoryRepositoryName = "testdata"
+		manifest.Repositories["testdata"] = &config.Repository{
+			URLString: "/myrepo/testdata",
+		}
+		manifest.TargetPath = targetPath
+		return cfg, nil
+	})
+	return i, inventoryPath, func() { os.RemoveAll(targetPath) }
+}
@@ -0,0 +1,125 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
+	"opendev.org/airship/airshipctl/pkg/log"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+// HostPowerStatus is power status of single baremetal host
+type HostPowerStatus struct {
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace"`
+	NodeID     string `json:"nodeID"`
+	BMCAddress string `json:"bmcAddress"`
+	PowerState string `json:"powerState"`
+	Error      string `json:"error,omitempty"`
+}
+
+// PowerStatusReport is power status of the selected baremetal hosts
+type PowerStatusReport struct {
+	Hosts []HostPowerStatus `json:"hosts"`
+}
+
+func (o *CommandOptions) validatePowerStatus() error {
+	if err := o.validateBMHAction(); err != nil {
+		return err
+	}
+	switch o.OutputFormat {
+	case OutputFormatTable, OutputFormatYAML, OutputFormatJSON:
+	default:
+		return ErrInvalidOptions{Message: fmt.Sprintf("output format must be one of '%s', '%s' or '%s', got '%s'",
+			OutputFormatTable, OutputFormatYAML, OutputFormatJSON, o.OutputFormat)}
+	}
+	return nil
+}
+
+// PowerStatus queries power status of the selected hosts from their BMCs and prints it in the requested
+// format. Hosts that power status failed to be retrieved for are reported with unknown power state and
+// the error, they don't fail the command.
+func (o *CommandOptions) PowerStatus(w io.Writer) error {
+	if err := o.validatePowerStatus(); err != nil {
+		return err
+	}
+
+	bmhInventory, err := o.Inventory.BaremetalInventory()
+	if err != nil {
+		return err
+	}
+
+	hosts, err := bmhInventory.Select(o.selector())
+	if err != nil {
+		return err
+	}
+	if len(hosts) == 0 {
+		return baremetal.ErrNoBaremetalHostsFound{Selector: o.selector()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Timeout)
+	defer cancel()
+	report := o.collectPowerStatus(ctx, hosts)
+
+	if o.OutputFormat == OutputFormatTable {
+		return printPowerStatusTable(w, report)
+	}
+	return writeObject(w, report, o.OutputFormat)
+}
+
+// collectPowerStatus queries BMCs of the hosts, at most o.MaxConcurrency at a time
+func (o *CommandOptions) collectPowerStatus(ctx context.Context, hosts []remoteifc.Client) PowerStatusReport {
+	report := PowerStatusReport{Hosts: make([]HostPowerStatus, len(hosts))}
+	for idx, host := range hosts {
+		report.Hosts[idx] = HostPowerStatus{NodeID: host.NodeID(), PowerState: power.StatusUnknown.String()}
+		if bmh, ok := host.(baremetal.Host); ok {
+			report.Hosts[idx].Name = bmh.Name
+			report.Hosts[idx].Namespace = bmh.Namespace
+			report.Hosts[idx].BMCAddress = bmh.BMCAddress
+		}
+	}
+
+	baremetal.RunBatch(hosts, func(idx int, host remoteifc.Client) error {
+		defer baremetal.CloseSession(ctx, host)
+		result := &report.Hosts[idx]
+
+		status, statusErr := host.SystemPowerStatus(ctx)
+		if statusErr != nil {
+			log.Debugf("Failed to retrieve power status of host with node id '%s': %v", result.NodeID, statusErr)
+			result.Error = statusErr.Error()
+			return statusErr
+		}
+		result.PowerState = status.String()
+		return nil
+	}, ifc.BaremetalBatchRunOptions{MaxConcurrency: o.MaxConcurrency})
+
+	return report
+}
+
+func printPowerStatusTable(w io.Writer, report PowerStatusReport) error {
+	tw := util.NewTabWriter(w)
+	fmt.Fprintln(tw, "NAMESPACE\tNAME\tBMC ADDRESS\tPOWER STATE\tERROR")
+	for _, host := range report.Hosts {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", host.Namespace, host.Name, host.BMCAddress, host.PowerState, host.Error)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,160 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package inventory_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/inventory"
+	"opendev.org/airship/airshipctl/pkg/inventory/baremetal"
+	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+	mockinventory "opendev.org/airship/airshipctl/testutil/inventory"
+	"opendev.org/airship/airshipctl/testutil/redfishutils"
+)
+
+func newPowerStatusHost(name, nodeID string, status power.Status, err error) remoteifc.Client {
+	client := &redfishutils.MockClient{}
+	client.On("NodeID").Return(nodeID)
+	client.On("SystemPowerStatus").Once().Return(status, err)
+	return baremetal.Host{
+		Client:     client,
+		Name:       name,
+		Namespace:  "metal3",
+		BMCAddress: "redfish+https://10.23.25.1/redfish/v1/Systems/" + nodeID,
+	}
+}
+
+func TestPowerStatus(t *testing.T) {
+	newHosts := func() []remoteifc.Client {
+		return []remoteifc.Client{
+			newPowerStatusHost("master-0", "node-0", power.StatusOn, nil),
+			newPowerStatusHost("master-1", "node-1", power.StatusUnknown, fmt.Errorf("BMC is unreachable")),
+		}
+	}
+
+	t.Run("success table", func(t *testing.T) {
+		co := newSelectOptions(newHosts()...)
+		co.OutputFormat = inventory.OutputFormatTable
+		co.MaxConcurrency = 2
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.PowerStatus(buf))
+
+		expected := "NAMESPACE   NAME       BMC ADDRESS                                            POWER STATE   ERROR\n" +
+			"metal3      master-0   redfish+https://10.23.25.1/redfish/v1/Systems/node-0   ON            \n" +
+			"metal3      master-1   redfish+https://10.23.25.1/redfish/v1/Systems/node-1   UNKNOWN       " +
+			"BMC is unreachable\n"
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("success json", func(t *testing.T) {
+		co := newSelectOptions(newHosts()...)
+		co.OutputFormat = inventory.OutputFormatJSON
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.PowerStatus(buf))
+
+		expected := `{
+  "hosts": [
+    {
+      "name": "master-0",
+      "namespace": "metal3",
+      "nodeID": "node-0",
+      "bmcAddress": "redfish+https://10.23.25.1/redfish/v1/Systems/node-0",
+      "powerState": "ON"
+    },
+    {
+      "name": "master-1",
+      "namespace": "metal3",
+      "nodeID": "node-1",
+      "bmcAddress": "redfish+https://10.23.25.1/redfish/v1/Systems/node-1",
+      "powerState": "UNKNOWN",
+      "error": "BMC is unreachable"
+    }
+  ]
+}
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("success yaml", func(t *testing.T) {
+		co := newSelectOptions(newHosts()[:1]...)
+		co.OutputFormat = inventory.OutputFormatYAML
+		buf := bytes.NewBuffer(nil)
+		require.NoError(t, co.PowerStatus(buf))
+
+		expected := `hosts:
+- bmcAddress: redfish+https://10.23.25.1/redfish/v1/Systems/node-0
+  name: master-0
+  namespace: metal3
+  nodeID: node-0
+  powerState: "ON"
+`
+		assert.Equal(t, expected, buf.String())
+	})
+
+	t.Run("error Select", func(t *testing.T) {
+		expectedErr := fmt.Errorf("Select inventory error")
+		bmhInv := &mockinventory.MockBMHInventory{}
+		bmhInv.On("Select").Once().Return(nil, expectedErr)
+
+		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(bmhInv, nil)
+
+		co := inventory.NewOptions(inv)
+		co.Name = testNode
+		co.OutputFormat = inventory.OutputFormatTable
+		buf := bytes.NewBuffer(nil)
+		assert.Equal(t, expectedErr, co.PowerStatus(buf))
+		assert.Len(t, buf.Bytes(), 0)
+	})
+
+	t.Run("error BMHInventory", func(t *testing.T) {
+		expectedErr := fmt.Errorf("bmh inventory error")
+		inv := &mockinventory.MockInventory{}
+		inv.On("BaremetalInventory").Once().Return(nil, expectedErr)
+
+		co := inventory.NewOptions(inv)
+		co.Name = testNode
+		co.OutputFormat = inventory.OutputFormatTable
+		buf := bytes.NewBuffer(nil)
+		assert.Equal(t, expectedErr, co.PowerStatus(buf))
+		assert.Len(t, buf.Bytes(), 0)
+	})
+
+	t.Run("error no hosts", func(t *testing.T) {
+		co := newSelectOptions()
+		co.OutputFormat = inventory.OutputFormatTable
+		assert.IsType(t, baremetal.ErrNoBaremetalHostsFound{}, co.PowerStatus(bytes.NewBuffer(nil)))
+	})
+
+	t.Run("error invalid options", func(t *testing.T) {
+		co := inventory.NewOptions(&mockinventory.MockInventory{})
+		co.OutputFormat = inventory.OutputFormatTable
+		err := co.PowerStatus(bytes.NewBuffer(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), (inventory.ErrInvalidOptions{}).Error())
+
+		co.All = true
+		co.OutputFormat = "xml"
+		err = co.PowerStatus(bytes.NewBuffer(nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "output format must be one of 'table', 'yaml' or 'json', got 'xml'")
+	})
+}
@@ -72,27 +72,53 @@ func NewApplier(eventCh chan events.Event, f cmdutil.Factory) *Applier {
 // ApplyBundle apply bundle to kubernetes cluster
 func (a *Applier) ApplyBundle(bundle document.Bundle, ao ApplyOptions) {
 	defer close(a.eventChannel)
+	if ao.ServerSideApply && ao.Prune {
+		// objects applied server-side are not recorded in the inventory, so there is nothing to prune by
+		handleError(a.eventChannel, ErrServerSidePrune{})
+		return
+	}
 	log.Debugf("Getting infos for bundle, inventory id is %s", ao.BundleName)
-	objects, err := a.getObjects(ao.BundleName, bundle, ao.DryRunStrategy)
+	objects, err := a.getObjects(bundle, ao)
 	if err != nil {
 		handleError(a.eventChannel, err)
 		return
 	}
 
-	ctx := context.Background()
-	ch := a.Driver.Run(ctx, objects, cliApplyOptions(ao))
-	for e := range ch {
-		a.eventChannel <- events.Event{
-			Type:         events.ApplierType,
-			ApplierEvent: e,
+	waves, err := splitWaves(objects)
+	if err != nil {
+		handleError(a.eventChannel, err)
+		return
+	}
+
+	var plan *prunePlan
+	if ao.Prune {
+		log.Debug("Looking for objects to prune")
+		plan, err = a.planPrune(objects, ao)
+		if err != nil {
+			handleError(a.eventChannel, err)
+			return
 		}
 	}
+
+	ctx := context.Background()
+	var succeeded bool
+	switch {
+	case len(waves) > 1:
+		log.Debugf("Applying bundle in %d waves", len(waves))
+		succeeded = a.applyWaves(ctx, waves, ao)
+	case ao.ServerSideApply:
+		a.applyServerSide(ctx, objects, ao)
+		return
+	default:
+		succeeded = a.runDriver(ctx, objects, cliApplyOptions(ao))
+	}
+	// objects are pruned only if all resources are applied successfully
+	if succeeded && plan != nil {
+		a.prune(plan, ao)
+	}
 }
 
-func (a *Applier) getObjects(
-	bundleName string,
-	bundle document.Bundle,
-	dryRun clicommon.DryRunStrategy) ([]*unstructured.Unstructured, error) {
+func (a *Applier) getObjects(bundle document.Bundle, ao ApplyOptions) ([]*unstructured.Unstructured, error) {
 	if bundle == nil {
 		return nil, ErrNilBundle{}
 	}
@@ -106,7 +132,7 @@ func (a *Applier) getObjects(
 	// now we need to generate and inject one at runtime
 	if err != nil && errors.As(err, &document.ErrDocNotFound{}) {
 		log.Debug("Inventory Object config Map not found, auto generating Inventory object")
-		invDoc, innerErr := NewInventoryDocument(bundleName)
+		invDoc, innerErr := NewInventoryDocument(ao.BundleName, ao.InventoryName, ao.InventoryNamespace)
 		if innerErr != nil {
 			// this should never happen
 			log.Debug("Failed to create new inventory document")
@@ -119,7 +145,7 @@ func (a *Applier) getObjects(
 			return nil, innerErr
 		}
 		log.Debugf("Making sure that inventory object namespace %s exists", invDoc.GetNamespace())
-		innerErr = a.ensureNamespaceExists(invDoc.GetNamespace(), dryRun)
+		innerErr = a.ensureNamespaceExists(invDoc.GetNamespace(), ao.DryRunStrategy)
 		if innerErr != nil {
 			return nil, innerErr
 		}
@@ -172,8 +198,9 @@ func cliApplyOptions(ao ApplyOptions) cliapply.Options {
 	return cliapply.Options{
 		EmitStatusEvents: emitStatusEvents,
 		ReconcileTimeout: ao.WaitTimeout,
-		NoPrune:          !ao.Prune,
-		DryRunStrategy:   ao.DryRunStrategy,
+		// pruning is performed by airshipctl itself, see prune.go
+		NoPrune:        true,
+		DryRunStrategy: ao.DryRunStrategy,
 	}
 }
 
@@ -208,20 +235,25 @@ func (a *Adaptor) Run(ctx context.Context, objects []*unstructured.Unstructured,
 	return a.CliUtilsApplier.Run(ctx, objects, options)
 }
 
-// NewInventoryDocument returns default config map with inventory Id to group up the objects
-func NewInventoryDocument(inventoryID string) (document.Document, error) {
+// NewInventoryDocument returns config map with inventory Id to group up the objects, if name or
+// namespace are empty, airshipit-<inventoryID> name and default namespace are used
+func NewInventoryDocument(inventoryID, name, namespace string) (document.Document, error) {
+	if name == "" {
+		name = fmt.Sprintf("%s-%s", "airshipit", inventoryID)
+	}
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
 	cm := v1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       document.ConfigMapKind,
 			APIVersion: document.ConfigMapVersion,
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			// name here is a dummy name, cli utils won't use this name, this config map simply parsed
-			// for inventory ID from label, and then ignores this config map
-			Name: fmt.Sprintf("%s-%s", "airshipit", inventoryID),
-			// TODO this limits us to single namespace. research passing this as parameter from
-			// somewhere higher level package that uses this module
-			Namespace: DefaultNamespace,
+			// cli utils uses this name as a prefix of inventory object name, inventory ID from the
+			// label is used to find the inventory object in the namespace
+			Name:      name,
+			Namespace: namespace,
 			Labels: map[string]string{
 				clicommon.InventoryLabel: inventoryID,
 			},
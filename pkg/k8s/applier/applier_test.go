@@ -15,13 +15,24 @@
 package applier_test
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	cliapply "sigs.k8s.io/cli-utils/pkg/apply"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/poller"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -151,6 +162,417 @@ func TestApplierRun(t *testing.T) {
 	}
 }
 
+func TestApplierServerSide(t *testing.T) {
+	bundle := testutil.NewTestBundle(t, "testdata/source_bundle")
+	f := k8stest.FakeFactory(t,
+		[]k8stest.ClientHandler{
+			&k8stest.InventoryObjectHandler{},
+			&k8stest.NamespaceHandler{},
+		})
+	defer f.Cleanup()
+	conflictErr := apierror.NewApplyConflict([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kube-controller-manager" using v1`,
+			Field:   ".spec.replicas",
+		},
+	}, "Apply failed with 1 conflict")
+	tests := []struct {
+		name             string
+		dryRun           common.DryRunStrategy
+		patchErr         error
+		prune            bool
+		expectedApplied  int
+		expectedErr      error
+		expectedConflict []applier.FieldConflict
+	}{
+		{
+			name:            "success",
+			dryRun:          common.DryRunNone,
+			expectedApplied: 2,
+		},
+		{
+			name:            "client dry run",
+			dryRun:          common.DryRunClient,
+			patchErr:        fmt.Errorf("patch must not be called"),
+			expectedApplied: 2,
+		},
+		{
+			name:        "prune is not supported",
+			dryRun:      common.DryRunNone,
+			patchErr:    fmt.Errorf("patch must not be called"),
+			prune:       true,
+			expectedErr: applier.ErrServerSidePrune{},
+		},
+		{
+			name:     "conflict",
+			dryRun:   common.DryRunNone,
+			patchErr: conflictErr,
+			expectedConflict: []applier.FieldConflict{
+				{
+					Object:  "ConfigMap airshipit/airshipit-test-bundle",
+					Manager: "kube-controller-manager",
+					Field:   ".spec.replicas",
+				},
+				{
+					Object:  "ReplicationController test/test-rc",
+					Manager: "kube-controller-manager",
+					Field:   ".spec.replicas",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var patchTypes []string
+			f.FakeDynamicClient.PrependReactor("patch", "*",
+				func(action clienttesting.Action) (bool, runtime.Object, error) {
+					patchAction, ok := action.(clienttesting.PatchAction)
+					require.True(t, ok)
+					patchTypes = append(patchTypes, string(patchAction.GetPatchType()))
+					if tt.patchErr != nil {
+						return true, nil, tt.patchErr
+					}
+					return true, &unstructured.Unstructured{Object: map[string]interface{}{}}, nil
+				})
+			eventChan := make(chan events.Event)
+			a := applier.NewApplier(eventChan, f)
+			a.Driver = applier.NewFakeAdaptor()
+			go a.ApplyBundle(bundle, applier.ApplyOptions{
+				BundleName:      "test-bundle",
+				DryRunStrategy:  tt.dryRun,
+				ServerSideApply: true,
+				FieldManager:    "test-manager",
+				Prune:           tt.prune,
+			})
+			var errs []error
+			applied := 0
+			for e := range eventChan {
+				switch {
+				case e.Type == events.ErrorType:
+					errs = append(errs, e.ErrorEvent.Error)
+				case e.ApplierEvent.Type == event.ApplyType &&
+					e.ApplierEvent.ApplyEvent.Operation == event.ServersideApplied:
+					applied++
+				}
+			}
+			assert.Equal(t, tt.expectedApplied, applied)
+			if tt.dryRun == common.DryRunClient {
+				assert.Empty(t, patchTypes)
+			} else {
+				for _, pt := range patchTypes {
+					assert.Equal(t, "application/apply-patch+yaml", pt)
+				}
+			}
+			if tt.expectedErr != nil {
+				assert.Equal(t, []error{tt.expectedErr}, errs)
+				assert.Empty(t, patchTypes)
+				return
+			}
+			if tt.expectedConflict == nil {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			conflictErr, ok := errs[0].(applier.ErrApplyConflict)
+			require.True(t, ok)
+			assert.ElementsMatch(t, tt.expectedConflict, conflictErr.Conflicts)
+		})
+	}
+}
+
+func TestApplierWaves(t *testing.T) {
+	f := k8stest.FakeFactory(t,
+		[]k8stest.ClientHandler{
+			&k8stest.InventoryObjectHandler{},
+			&k8stest.NamespaceHandler{},
+		})
+	defer f.Cleanup()
+	tests := []struct {
+		name           string
+		bundlePath     string
+		opts           applier.ApplyOptions
+		expectedWaves  []events.ApplyWaveEvent
+		expectedString string
+	}{
+		{
+			name:       "waves are applied in ascending order",
+			bundlePath: "testdata/waves_bundle",
+			opts: applier.ApplyOptions{
+				BundleName:     "test-bundle",
+				DryRunStrategy: common.DryRunClient,
+				WaitTimeout:    time.Second * 5,
+			},
+			expectedWaves: []events.ApplyWaveEvent{
+				{Operation: events.ApplyWaveStart, Wave: 0},
+				{Operation: events.ApplyWaveComplete, Wave: 0},
+				{Operation: events.ApplyWaveStart, Wave: 1},
+				{Operation: events.ApplyWaveComplete, Wave: 1},
+				{Operation: events.ApplyWaveStart, Wave: 2},
+				{Operation: events.ApplyWaveComplete, Wave: 2},
+			},
+		},
+		{
+			name:       "wave wait timeout",
+			bundlePath: "testdata/waves_bundle",
+			opts: applier.ApplyOptions{
+				BundleName:     "test-bundle",
+				DryRunStrategy: common.DryRunNone,
+				WaitTimeout:    time.Second * 5,
+				WaveTimeouts:   map[int]time.Duration{0: time.Second},
+			},
+			expectedWaves: []events.ApplyWaveEvent{
+				{Operation: events.ApplyWaveStart, Wave: 0},
+			},
+			expectedString: "timeout 1s waiting for resources to become Current",
+		},
+		{
+			name:       "invalid wave annotation",
+			bundlePath: "testdata/invalid_wave_bundle",
+			opts: applier.ApplyOptions{
+				BundleName:     "test-bundle",
+				DryRunStrategy: common.DryRunClient,
+			},
+			expectedString: `invalid value "first" of airshipit.org/apply-wave annotation`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			eventChan := make(chan events.Event)
+			a := applier.NewApplier(eventChan, f)
+			a.Driver = applier.NewFakeAdaptor().WithEvents(k8stest.SuccessEvents())
+			a.Poller = &applier.FakePoller{}
+			go a.ApplyBundle(newBundle(tt.bundlePath, t), tt.opts)
+			var errs []error
+			var waves []events.ApplyWaveEvent
+			for e := range eventChan {
+				switch e.Type {
+				case events.ErrorType:
+					errs = append(errs, e.ErrorEvent.Error)
+				case events.ApplyWaveType:
+					waves = append(waves, events.ApplyWaveEvent{
+						Operation: e.ApplyWaveEvent.Operation,
+						Wave:      e.ApplyWaveEvent.Wave,
+					})
+				}
+			}
+			assert.Equal(t, tt.expectedWaves, waves)
+			if tt.expectedString == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), tt.expectedString)
+		})
+	}
+}
+
+func TestApplierWavesObjects(t *testing.T) {
+	f := k8stest.FakeFactory(t,
+		[]k8stest.ClientHandler{
+			&k8stest.InventoryObjectHandler{},
+			&k8stest.NamespaceHandler{},
+		})
+	defer f.Cleanup()
+
+	eventChan := make(chan events.Event)
+	a := applier.NewApplier(eventChan, f)
+	driver := &recordingDriver{}
+	a.Driver = driver
+	go a.ApplyBundle(newBundle("testdata/waves_bundle", t), applier.ApplyOptions{
+		BundleName:     "test-bundle",
+		DryRunStrategy: common.DryRunClient,
+	})
+	for e := range eventChan {
+		if e.Type == events.ErrorType {
+			t.Errorf("unexpected error: %v", e.ErrorEvent.Error)
+		}
+	}
+	// every wave applies its own objects together with the inventory object
+	assert.Equal(t, [][]string{{"default-wave-map"}, {"first-wave-map"}, {"test-rc"}}, driver.applied)
+	assert.Equal(t, []int{1, 1, 1}, driver.inventories)
+}
+
+func TestApplierPrune(t *testing.T) {
+	inventoryCM := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "airshipit-test-bundle-4bf1e4a",
+			Namespace: "airshipit",
+			Labels:    map[string]string{common.InventoryLabel: "test-bundle"},
+		},
+		Data: map[string]string{
+			"test_test-rc__ReplicationController": "",
+			"test_old-1__ConfigMap":               "",
+			"test_old-2__ConfigMap":               "",
+			"test_old-3__ConfigMap":               "",
+		},
+	}
+	tests := []struct {
+		name           string
+		dryRun         common.DryRunStrategy
+		maxPruneCount  int
+		expectedErr    error
+		expectedEvents map[events.PruneOperation]int
+		expectedDelete []string
+	}{
+		{
+			name:          "prune limit exceeded",
+			dryRun:        common.DryRunNone,
+			maxPruneCount: 1,
+			expectedErr: applier.ErrPruneLimitExceeded{
+				Limit:   1,
+				Objects: []string{"ConfigMap test/old-1", "ConfigMap test/old-3"},
+			},
+			expectedEvents: map[events.PruneOperation]int{events.PrunePreview: 2, events.PruneProtected: 1},
+		},
+		{
+			name:           "dry run",
+			dryRun:         common.DryRunClient,
+			expectedEvents: map[events.PruneOperation]int{events.PrunePreview: 2, events.PruneProtected: 1},
+		},
+		{
+			name:          "success",
+			dryRun:        common.DryRunNone,
+			maxPruneCount: 2,
+			expectedEvents: map[events.PruneOperation]int{
+				events.PrunePreview:   2,
+				events.PruneProtected: 1,
+				events.Pruned:         2,
+			},
+			expectedDelete: []string{"old-1", "old-3"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			invHandler := &pruneInventoryHandler{cm: inventoryCM.DeepCopy()}
+			f := k8stest.FakeFactory(t,
+				[]k8stest.ClientHandler{
+					invHandler,
+					&k8stest.NamespaceHandler{},
+				})
+			defer f.Cleanup()
+			f.FakeDynamicClient.PrependReactor("get", "configmaps",
+				func(action clienttesting.Action) (bool, runtime.Object, error) {
+					name := action.(clienttesting.GetAction).GetName()
+					obj := &unstructured.Unstructured{}
+					obj.SetAPIVersion("v1")
+					obj.SetKind("ConfigMap")
+					obj.SetName(name)
+					obj.SetNamespace("test")
+					if name == "old-2" {
+						obj.SetAnnotations(map[string]string{applier.PruneProtectAnnotation: "true"})
+					}
+					return true, obj, nil
+				})
+			var deleted []string
+			f.FakeDynamicClient.PrependReactor("delete", "configmaps",
+				func(action clienttesting.Action) (bool, runtime.Object, error) {
+					deleted = append(deleted, action.(clienttesting.DeleteAction).GetName())
+					return true, nil, nil
+				})
+
+			eventChan := make(chan events.Event)
+			a := applier.NewApplier(eventChan, f)
+			a.Driver = applier.NewFakeAdaptor()
+			go a.ApplyBundle(newBundle("testdata/source_bundle", t), applier.ApplyOptions{
+				BundleName:     "test-bundle",
+				DryRunStrategy: tt.dryRun,
+				Prune:          true,
+				MaxPruneCount:  tt.maxPruneCount,
+			})
+			var errs []error
+			pruneEvents := map[events.PruneOperation]int{}
+			for e := range eventChan {
+				switch e.Type {
+				case events.ErrorType:
+					errs = append(errs, e.ErrorEvent.Error)
+				case events.PruneType:
+					pruneEvents[e.PruneEvent.Operation]++
+				}
+			}
+			if tt.expectedErr != nil {
+				require.Len(t, errs, 1)
+				assert.Equal(t, tt.expectedErr, errs[0])
+			} else {
+				assert.Empty(t, errs)
+			}
+			assert.Equal(t, tt.expectedEvents, pruneEvents)
+			assert.Equal(t, tt.expectedDelete, deleted)
+			if len(tt.expectedDelete) > 0 {
+				require.NotNil(t, invHandler.updated)
+				assert.Equal(t, map[string]string{
+					"test_test-rc__ReplicationController": "",
+					"test_old-2__ConfigMap":               "",
+				}, invHandler.updated.Data)
+			} else {
+				assert.Nil(t, invHandler.updated)
+			}
+		})
+	}
+}
+
+// recordingDriver records objects passed to every apply
+type recordingDriver struct {
+	applied     [][]string
+	inventories []int
+}
+
+func (d *recordingDriver) Initialize(poller.Poller) error {
+	return nil
+}
+
+func (d *recordingDriver) Run(
+	_ context.Context,
+	objects []*unstructured.Unstructured,
+	_ cliapply.Options) <-chan event.Event {
+	names := []string{}
+	inventories := 0
+	for _, obj := range objects {
+		if _, ok := obj.GetLabels()[common.InventoryLabel]; ok {
+			inventories++
+			continue
+		}
+		names = append(names, obj.GetName())
+	}
+	d.applied = append(d.applied, names)
+	d.inventories = append(d.inventories, inventories)
+	ch := make(chan event.Event)
+	close(ch)
+	return ch
+}
+
+// pruneInventoryHandler serves inventory config map to kubernetes client set and records its updates
+type pruneInventoryHandler struct {
+	cm      *corev1.ConfigMap
+	updated *corev1.ConfigMap
+}
+
+func (h *pruneInventoryHandler) Handle(t *testing.T, req *http.Request) (*http.Response, bool, error) {
+	c := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+	listPath := "/api/v1/namespaces/airshipit/configmaps"
+	switch {
+	case req.Method == http.MethodGet && req.URL.Path == listPath:
+		list := &corev1.ConfigMapList{
+			TypeMeta: metav1.TypeMeta{Kind: "ConfigMapList", APIVersion: "v1"},
+			Items:    []corev1.ConfigMap{*h.cm},
+		}
+		return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(),
+			Body: cmdtesting.ObjBody(c, list)}, true, nil
+	case req.Method == http.MethodPut && req.URL.Path == listPath+"/"+h.cm.Name:
+		b, err := ioutil.ReadAll(req.Body)
+		require.NoError(t, err)
+		h.updated = &corev1.ConfigMap{}
+		require.NoError(t, runtime.DecodeInto(c, b, h.updated))
+		return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(),
+			Body: cmdtesting.ObjBody(c, h.updated)}, true, nil
+	}
+	return nil, false, nil
+}
+
 func newBundle(path string, t *testing.T) document.Bundle {
 	t.Helper()
 	b, err := document.NewBundleByPath(path)
@@ -25,5 +25,28 @@ type ApplyOptions struct {
 	WaitTimeout    time.Duration
 	DryRunStrategy common.DryRunStrategy
 	Prune          bool
-	BundleName     string
+	// MaxPruneCount aborts apply if more objects are going to be pruned, 0 means no limit
+	MaxPruneCount int
+	// BundleName is used as inventory ID
+	BundleName string
+	// InventoryName is the name of inventory object, defaults to airshipit-<BundleName>
+	InventoryName string
+	// InventoryNamespace is the namespace of inventory object, defaults to DefaultNamespace
+	InventoryNamespace string
+	// ServerSideApply makes applier use server-side apply instead of client-side apply
+	ServerSideApply bool
+	// FieldManager is the manager name used to track ownership of fields during server-side apply
+	FieldManager string
+	// ForceConflicts allows server-side apply to take ownership of the fields owned by other managers
+	ForceConflicts bool
+	// WaveTimeouts overrides WaitTimeout for particular apply waves
+	WaveTimeouts map[int]time.Duration
+}
+
+// waveTimeout returns wait timeout for the given apply wave
+func (ao ApplyOptions) waveTimeout(wave int) time.Duration {
+	if timeout, ok := ao.WaveTimeouts[wave]; ok {
+		return timeout
+	}
+	return ao.WaitTimeout
 }
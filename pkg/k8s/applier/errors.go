@@ -16,6 +16,8 @@ package applier
 
 import (
 	"fmt"
+	"strings"
+	"time"
 )
 
 // ErrApply returned for not implemented features
@@ -35,3 +37,88 @@ type ErrNilBundle struct {
 func (e ErrNilBundle) Error() string {
 	return "nil bundle provided"
 }
+
+// FieldConflict describes a field of the object that is owned by another field manager
+type FieldConflict struct {
+	Object  string
+	Manager string
+	Field   string
+}
+
+// ErrApplyConflict returned when server-side apply conflicts with fields owned by other managers
+type ErrApplyConflict struct {
+	Conflicts []FieldConflict
+}
+
+func (e ErrApplyConflict) Error() string {
+	var b strings.Builder
+	b.WriteString("server-side apply conflicts with other field managers, set forceConflicts to take ownership:")
+	for _, c := range e.Conflicts {
+		fmt.Fprintf(&b, "\n%s: field %s is managed by %q", c.Object, c.Field, c.Manager)
+	}
+	return b.String()
+}
+
+// ErrServerSidePrune returned when prune is requested together with server-side apply
+type ErrServerSidePrune struct {
+}
+
+func (e ErrServerSidePrune) Error() string {
+	return "prune is not supported with server-side apply"
+}
+
+// ErrWaitTimeout returned when resources didn't reach Current status in time
+type ErrWaitTimeout struct {
+	Timeout   time.Duration
+	Resources []string
+}
+
+func (e ErrWaitTimeout) Error() string {
+	return fmt.Sprintf("timeout %v waiting for resources to become Current: %s",
+		e.Timeout, strings.Join(e.Resources, ", "))
+}
+
+// ErrInvalidApplyWave returned when apply wave annotation is not an integer
+type ErrInvalidApplyWave struct {
+	Object string
+	Value  string
+}
+
+func (e ErrInvalidApplyWave) Error() string {
+	return fmt.Sprintf("invalid value %q of %s annotation for %s, must be an integer",
+		e.Value, ApplyWaveAnnotation, e.Object)
+}
+
+// ErrInventoryNotFound returned when inventory object with the given ID is not found in the cluster
+type ErrInventoryNotFound struct {
+	ID        string
+	Namespace string
+}
+
+func (e ErrInventoryNotFound) Error() string {
+	if e.Namespace == "" {
+		return fmt.Sprintf("inventory %s is not found", e.ID)
+	}
+	return fmt.Sprintf("inventory %s is not found in namespace %s", e.ID, e.Namespace)
+}
+
+// ErrInventoryNotUnique returned when more than one inventory object has the same ID
+type ErrInventoryNotUnique struct {
+	ID    string
+	Found int
+}
+
+func (e ErrInventoryNotUnique) Error() string {
+	return fmt.Sprintf("found %d inventory objects with ID %s, specify the namespace", e.Found, e.ID)
+}
+
+// ErrPruneLimitExceeded returned when number of objects to prune exceeds the limit set for the phase
+type ErrPruneLimitExceeded struct {
+	Limit   int
+	Objects []string
+}
+
+func (e ErrPruneLimitExceeded) Error() string {
+	return fmt.Sprintf("%d objects are going to be pruned, which exceeds the limit of %d, aborting: %s",
+		len(e.Objects), e.Limit, strings.Join(e.Objects, ", "))
+}
@@ -0,0 +1,194 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"sort"
+
+	v1 "k8s.io/api/core/v1"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// Inventory describes inventory object stored in kubernetes cluster and objects that belong to it
+type Inventory struct {
+	ID        string               `json:"id"`
+	Name      string               `json:"name"`
+	Namespace string               `json:"namespace"`
+	Objects   []object.ObjMetadata `json:"objects,omitempty"`
+}
+
+// InventoryClient reads and moves inventory objects stored in kubernetes cluster
+type InventoryClient struct {
+	clientSet kubernetes.Interface
+}
+
+// NewInventoryClient returns instance of InventoryClient
+func NewInventoryClient(clientSet kubernetes.Interface) *InventoryClient {
+	return &InventoryClient{clientSet: clientSet}
+}
+
+// List returns inventories found in the namespace, all namespaces are searched if namespace is empty
+func (c *InventoryClient) List(namespace string) ([]Inventory, error) {
+	return c.list(namespace, clicommon.InventoryLabel)
+}
+
+// Get returns inventory with the given ID, all namespaces are searched if namespace is empty
+func (c *InventoryClient) Get(id, namespace string) (Inventory, error) {
+	inventories, err := c.list(namespace, clicommon.InventoryLabel+"="+id)
+	if err != nil {
+		return Inventory{}, err
+	}
+	switch len(inventories) {
+	case 0:
+		return Inventory{}, ErrInventoryNotFound{ID: id, Namespace: namespace}
+	case 1:
+		return inventories[0], nil
+	default:
+		return Inventory{}, ErrInventoryNotUnique{ID: id, Found: len(inventories)}
+	}
+}
+
+// Migrate moves inventory object with the given ID to a new namespace and name. The inventory
+// keeps the list of the objects it owns, so that prune and status keep working for them
+func (c *InventoryClient) Migrate(id, namespace string, to Inventory) (Inventory, error) {
+	cm, err := c.getConfigMap(id, namespace)
+	if err != nil {
+		return Inventory{}, err
+	}
+
+	if to.ID == "" {
+		to.ID = id
+	}
+	if to.Name == "" {
+		to.Name = cm.Name
+	}
+	if to.Namespace == "" {
+		to.Namespace = cm.Namespace
+	}
+	if to.Namespace == cm.Namespace && to.Name == cm.Name && to.ID == id {
+		log.Printf("Inventory %s is already stored in %s/%s", id, cm.Namespace, cm.Name)
+		return inventoryFromConfigMap(*cm), nil
+	}
+
+	nsClient := c.clientSet.CoreV1().Namespaces()
+	_, err = nsClient.Create(&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: to.Namespace}})
+	if err != nil && !apierror.IsAlreadyExists(err) {
+		return Inventory{}, err
+	}
+
+	labels := map[string]string{}
+	for k, v := range cm.Labels {
+		labels[k] = v
+	}
+	labels[clicommon.InventoryLabel] = to.ID
+	newCm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        to.Name,
+			Namespace:   to.Namespace,
+			Labels:      labels,
+			Annotations: cm.Annotations,
+		},
+		Data: cm.Data,
+	}
+	log.Printf("Creating inventory %s in %s/%s", to.ID, to.Namespace, to.Name)
+	created, err := c.clientSet.CoreV1().ConfigMaps(to.Namespace).Create(newCm)
+	if err != nil {
+		return Inventory{}, err
+	}
+
+	log.Printf("Deleting inventory %s from %s/%s", id, cm.Namespace, cm.Name)
+	err = c.clientSet.CoreV1().ConfigMaps(cm.Namespace).Delete(cm.Name, &metav1.DeleteOptions{})
+	if err != nil {
+		return Inventory{}, err
+	}
+	return inventoryFromConfigMap(*created), nil
+}
+
+// SetObjects replaces the list of the objects owned by the inventory with the given ID
+func (c *InventoryClient) SetObjects(id, namespace string, objects []object.ObjMetadata) error {
+	cm, err := c.getConfigMap(id, namespace)
+	if err != nil {
+		return err
+	}
+	data := make(map[string]string, len(objects))
+	for _, obj := range objects {
+		data[obj.String()] = ""
+	}
+	cm.Data = data
+	_, err = c.clientSet.CoreV1().ConfigMaps(cm.Namespace).Update(cm)
+	return err
+}
+
+func (c *InventoryClient) getConfigMap(id, namespace string) (*v1.ConfigMap, error) {
+	cms, err := c.clientSet.CoreV1().ConfigMaps(namespace).List(metav1.ListOptions{
+		LabelSelector: clicommon.InventoryLabel + "=" + id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(cms.Items) {
+	case 0:
+		return nil, ErrInventoryNotFound{ID: id, Namespace: namespace}
+	case 1:
+		return &cms.Items[0], nil
+	default:
+		return nil, ErrInventoryNotUnique{ID: id, Found: len(cms.Items)}
+	}
+}
+
+func (c *InventoryClient) list(namespace, selector string) ([]Inventory, error) {
+	cms, err := c.clientSet.CoreV1().ConfigMaps(namespace).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	inventories := make([]Inventory, 0, len(cms.Items))
+	for _, cm := range cms.Items {
+		inventories = append(inventories, inventoryFromConfigMap(cm))
+	}
+	sort.Slice(inventories, func(i, j int) bool {
+		if inventories[i].Namespace != inventories[j].Namespace {
+			return inventories[i].Namespace < inventories[j].Namespace
+		}
+		return inventories[i].Name < inventories[j].Name
+	})
+	return inventories, nil
+}
+
+func inventoryFromConfigMap(cm v1.ConfigMap) Inventory {
+	inv := Inventory{
+		ID:        cm.Labels[clicommon.InventoryLabel],
+		Name:      cm.Name,
+		Namespace: cm.Namespace,
+	}
+	// cli-utils stores identifiers of inventory objects as keys of config map data
+	for key := range cm.Data {
+		id, err := object.ParseObjMetadata(key)
+		if err != nil {
+			log.Debugf("Skipping malformed object identifier %q in inventory %s: %v", key, inv.ID, err)
+			continue
+		}
+		inv.Objects = append(inv.Objects, id)
+	}
+	sort.Slice(inv.Objects, func(i, j int) bool {
+		return inv.Objects[i].String() < inv.Objects[j].String()
+	})
+	return inv
+}
@@ -0,0 +1,271 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	// PruneProtectAnnotation protects the resource from being pruned when it is removed from the phase,
+	// resource is protected if the annotation value is "true"
+	PruneProtectAnnotation = "airshipit.org/prune-protect"
+)
+
+var (
+	// applyFirstKinds are applied in this order before all other kinds, the same order cli-utils uses
+	applyFirstKinds = []string{
+		"Namespace",
+		"ResourceQuota",
+		"StorageClass",
+		"CustomResourceDefinition",
+		"ServiceAccount",
+		"PodSecurityPolicy",
+		"Role",
+		"ClusterRole",
+		"RoleBinding",
+		"ClusterRoleBinding",
+		"ConfigMap",
+		"Secret",
+		"Endpoints",
+		"Service",
+		"LimitRange",
+		"PriorityClass",
+		"PersistentVolume",
+		"PersistentVolumeClaim",
+		"Deployment",
+		"StatefulSet",
+		"CronJob",
+		"PodDisruptionBudget",
+	}
+	// applyLastKinds are applied in this order after all other kinds
+	applyLastKinds = []string{
+		"MutatingWebhookConfiguration",
+		"ValidatingWebhookConfiguration",
+	}
+)
+
+// prunePlan holds objects of the inventory that are missing from the applied bundle
+type prunePlan struct {
+	inventory *unstructured.Unstructured
+	applied   []object.ObjMetadata
+	prune     []object.ObjMetadata
+	protected []object.ObjMetadata
+}
+
+// planPrune compares objects stored in the inventory with the objects of the bundle and reports
+// the ones that are going to be pruned before anything is applied
+func (a *Applier) planPrune(objects []*unstructured.Unstructured, ao ApplyOptions) (*prunePlan, error) {
+	plan := &prunePlan{}
+	applied := map[object.ObjMetadata]bool{}
+	for _, obj := range objects {
+		if _, ok := obj.GetLabels()[clicommon.InventoryLabel]; ok {
+			plan.inventory = obj
+			continue
+		}
+		id := object.ObjMetadata{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			GroupKind: obj.GroupVersionKind().GroupKind(),
+		}
+		applied[id] = true
+		plan.applied = append(plan.applied, id)
+	}
+	if plan.inventory == nil {
+		return plan, nil
+	}
+
+	clientSet, err := a.Factory.KubernetesClientSet()
+	if err != nil {
+		return nil, err
+	}
+	inv, err := NewInventoryClient(clientSet).Get(ao.BundleName, plan.inventory.GetNamespace())
+	if errors.As(err, &ErrInventoryNotFound{}) {
+		log.Debugf("Inventory %s is not found, nothing to prune", ao.BundleName)
+		return plan, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := a.Factory.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := a.Factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range inv.Objects {
+		if applied[id] {
+			continue
+		}
+		obj, getErr := getObject(dynamicClient, mapper, id)
+		if apierror.IsNotFound(getErr) {
+			log.Debugf("%s is already removed from the cluster", pruneObjectID(id))
+			continue
+		}
+		if getErr != nil {
+			return nil, getErr
+		}
+		if obj.GetAnnotations()[PruneProtectAnnotation] == "true" {
+			plan.protected = append(plan.protected, id)
+			a.sendPruneEvent(events.PruneProtected, id,
+				fmt.Sprintf("%s is protected by %s annotation", pruneObjectID(id), PruneProtectAnnotation))
+			continue
+		}
+		plan.prune = append(plan.prune, id)
+		a.sendPruneEvent(events.PrunePreview, id, fmt.Sprintf("%s is going to be pruned", pruneObjectID(id)))
+	}
+
+	if ao.MaxPruneCount > 0 && len(plan.prune) > ao.MaxPruneCount {
+		objs := make([]string, 0, len(plan.prune))
+		for _, id := range plan.prune {
+			objs = append(objs, pruneObjectID(id))
+		}
+		return nil, ErrPruneLimitExceeded{Limit: ao.MaxPruneCount, Objects: objs}
+	}
+	return plan, nil
+}
+
+// prune deletes objects of the plan from the cluster and updates the inventory once all the objects
+// are applied, so that it contains applied objects and the ones that are protected from pruning
+func (a *Applier) prune(plan *prunePlan, ao ApplyOptions) {
+	if plan.inventory == nil {
+		return
+	}
+	if ao.DryRunStrategy != clicommon.DryRunNone {
+		log.Printf("Dryrun strategy is specified, otherwise %d objects would have been pruned", len(plan.prune))
+		return
+	}
+
+	dynamicClient, err := a.Factory.DynamicClient()
+	if err != nil {
+		handleError(a.eventChannel, err)
+		return
+	}
+	mapper, err := a.Factory.ToRESTMapper()
+	if err != nil {
+		handleError(a.eventChannel, err)
+		return
+	}
+	sortPruneOrder(plan.prune)
+	for _, id := range plan.prune {
+		log.Debugf("Pruning %s", pruneObjectID(id))
+		if err = deleteObject(dynamicClient, mapper, id); err != nil {
+			handleError(a.eventChannel, err)
+			return
+		}
+		a.sendPruneEvent(events.Pruned, id, fmt.Sprintf("%s is pruned", pruneObjectID(id)))
+	}
+
+	clientSet, err := a.Factory.KubernetesClientSet()
+	if err != nil {
+		handleError(a.eventChannel, err)
+		return
+	}
+	// protected objects stay in the inventory, so that they are considered on the next apply too
+	inventoryObjects := append(append([]object.ObjMetadata{}, plan.applied...), plan.protected...)
+	err = NewInventoryClient(clientSet).SetObjects(ao.BundleName, plan.inventory.GetNamespace(), inventoryObjects)
+	if err != nil {
+		handleError(a.eventChannel, err)
+	}
+}
+
+// sortPruneOrder sorts objects in reverse apply order, so that objects are deleted before namespaces and
+// custom resource definitions they depend on
+func sortPruneOrder(ids []object.ObjMetadata) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		return applyRank(ids[i].GroupKind.Kind) > applyRank(ids[j].GroupKind.Kind)
+	})
+}
+
+// applyRank returns position of the kind in the apply order, kinds with lower rank are applied first
+func applyRank(kind string) int {
+	for idx, k := range applyFirstKinds {
+		if k == kind {
+			return idx
+		}
+	}
+	for idx, k := range applyLastKinds {
+		if k == kind {
+			return len(applyFirstKinds) + 1 + idx
+		}
+	}
+	return len(applyFirstKinds)
+}
+
+func (a *Applier) sendPruneEvent(operation events.PruneOperation, id object.ObjMetadata, message string) {
+	a.eventChannel <- events.NewEvent().WithPruneEvent(events.PruneEvent{
+		Operation: operation,
+		Object:    pruneObjectID(id),
+		Message:   message,
+	})
+}
+
+func getObject(
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
+	id object.ObjMetadata) (*unstructured.Unstructured, error) {
+	ri, err := idResourceInterface(dynamicClient, mapper, id)
+	if err != nil {
+		return nil, err
+	}
+	return ri.Get(id.Name, metav1.GetOptions{})
+}
+
+func deleteObject(dynamicClient dynamic.Interface, mapper meta.RESTMapper, id object.ObjMetadata) error {
+	ri, err := idResourceInterface(dynamicClient, mapper, id)
+	if err != nil {
+		return err
+	}
+	propagation := metav1.DeletePropagationBackground
+	err = ri.Delete(id.Name, &metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if apierror.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+func idResourceInterface(
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
+	id object.ObjMetadata) (dynamic.ResourceInterface, error) {
+	mapping, err := mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dynamicClient.Resource(mapping.Resource).Namespace(id.Namespace), nil
+	}
+	return dynamicClient.Resource(mapping.Resource), nil
+}
+
+func pruneObjectID(id object.ObjMetadata) string {
+	return fmt.Sprintf("%s %s/%s", id.GroupKind, id.Namespace, id.Name)
+}
@@ -0,0 +1,50 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestSortPruneOrder(t *testing.T) {
+	id := func(group, kind, name string) object.ObjMetadata {
+		return object.ObjMetadata{Name: name, GroupKind: schema.GroupKind{Group: group, Kind: kind}}
+	}
+	ids := []object.ObjMetadata{
+		id("", "Namespace", "test"),
+		id("apiextensions.k8s.io", "CustomResourceDefinition", "widgets.example.com"),
+		id("", "ConfigMap", "config-1"),
+		id("apps", "Deployment", "app"),
+		id("example.com", "Widget", "widget"),
+		id("admissionregistration.k8s.io", "ValidatingWebhookConfiguration", "webhook"),
+		id("", "ConfigMap", "config-2"),
+	}
+
+	sortPruneOrder(ids)
+
+	assert.Equal(t, []object.ObjMetadata{
+		id("admissionregistration.k8s.io", "ValidatingWebhookConfiguration", "webhook"),
+		id("example.com", "Widget", "widget"),
+		id("apps", "Deployment", "app"),
+		id("", "ConfigMap", "config-1"),
+		id("", "ConfigMap", "config-2"),
+		id("apiextensions.k8s.io", "CustomResourceDefinition", "widgets.example.com"),
+		id("", "Namespace", "test"),
+	}, ids)
+}
@@ -0,0 +1,259 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/poller"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	// DefaultFieldManager is the field manager name used for server-side apply if none is specified
+	DefaultFieldManager = "airshipctl"
+	// DefaultPollInterval is the interval between status checks when waiting for resources
+	DefaultPollInterval = 2 * time.Second
+)
+
+// conflictManagerRegex extracts the name of the manager from the message of the field manager conflict cause,
+// such as: conflict with "kube-controller-manager" using apps/v1
+var conflictManagerRegex = regexp.MustCompile(`conflict with "([^"]*)"`)
+
+// applyServerSide sends objects to kubernetes using server-side apply and waits for them to
+// become Current if wait timeout is specified
+func (a *Applier) applyServerSide(ctx context.Context, objects []*unstructured.Unstructured, ao ApplyOptions) {
+	if err := a.patchServerSide(objects, ao); err != nil {
+		handleError(a.eventChannel, err)
+		return
+	}
+	a.eventChannel <- events.Event{
+		Type: events.ApplierType,
+		ApplierEvent: applyevent.Event{
+			Type: applyevent.ApplyType,
+			ApplyEvent: applyevent.ApplyEvent{
+				Type: applyevent.ApplyEventCompleted,
+			},
+		},
+	}
+	if ao.WaitTimeout == time.Duration(0) || ao.DryRunStrategy != clicommon.DryRunNone {
+		return
+	}
+	if err := a.waitForCurrent(ctx, objMetadataSet(objects), ao.WaitTimeout); err != nil {
+		handleError(a.eventChannel, err)
+	}
+}
+
+func (a *Applier) patchServerSide(objects []*unstructured.Unstructured, ao ApplyOptions) error {
+	dynamicClient, err := a.Factory.DynamicClient()
+	if err != nil {
+		return err
+	}
+	mapper, err := a.Factory.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+
+	fieldManager := ao.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	force := ao.ForceConflicts
+	patchOpts := metav1.PatchOptions{
+		FieldManager: fieldManager,
+		Force:        &force,
+	}
+	if ao.DryRunStrategy == clicommon.DryRunServer {
+		patchOpts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	var conflicts []FieldConflict
+	for _, obj := range objects {
+		if ao.DryRunStrategy == clicommon.DryRunClient {
+			log.Printf("Dryrun strategy is specified, otherwise %s would have been server-side applied", objectID(obj))
+			a.sendServerSideEvent(obj)
+			continue
+		}
+		ri, err := resourceInterface(dynamicClient, mapper, obj)
+		if err != nil {
+			return err
+		}
+		data, err := obj.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		log.Debugf("Server-side applying %s with field manager %s", objectID(obj), fieldManager)
+		if _, err = ri.Patch(obj.GetName(), types.ApplyPatchType, data, patchOpts); err != nil {
+			if objConflicts := fieldConflicts(obj, err); len(objConflicts) > 0 {
+				// collect conflicts for all objects, so that they are reported at once
+				conflicts = append(conflicts, objConflicts...)
+				continue
+			}
+			return err
+		}
+		a.sendServerSideEvent(obj)
+	}
+	if len(conflicts) > 0 {
+		return ErrApplyConflict{Conflicts: conflicts}
+	}
+	return nil
+}
+
+func (a *Applier) sendServerSideEvent(obj *unstructured.Unstructured) {
+	a.eventChannel <- events.Event{
+		Type: events.ApplierType,
+		ApplierEvent: applyevent.Event{
+			Type: applyevent.ApplyType,
+			ApplyEvent: applyevent.ApplyEvent{
+				Type:      applyevent.ApplyEventResourceUpdate,
+				Operation: applyevent.ServersideApplied,
+				Object:    obj,
+			},
+		},
+	}
+}
+
+// waitForCurrent polls the cluster until all resources identified by ids have Current status
+func (a *Applier) waitForCurrent(ctx context.Context, ids []object.ObjMetadata, timeout time.Duration) error {
+	p, err := a.statusPoller()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	statuses := make(map[object.ObjMetadata]status.Status, len(ids))
+	for e := range p.Poll(ctx, ids, polling.Options{PollInterval: DefaultPollInterval, UseCache: true}) {
+		switch e.EventType {
+		case pollevent.ErrorEvent:
+			return e.Error
+		case pollevent.ResourceUpdateEvent:
+			statuses[e.Resource.Identifier] = e.Resource.Status
+			log.Debugf("Resource %s/%s of kind %s is %s", e.Resource.Identifier.Namespace,
+				e.Resource.Identifier.Name, e.Resource.Identifier.GroupKind.Kind, e.Resource.Status)
+			if notCurrent(ids, statuses) == nil {
+				return nil
+			}
+		}
+	}
+	return ErrWaitTimeout{Timeout: timeout, Resources: notCurrent(ids, statuses)}
+}
+
+// statusPoller returns poller set for the applier or creates default kstatus poller
+func (a *Applier) statusPoller() (poller.Poller, error) {
+	if a.Poller != nil {
+		return a.Poller, nil
+	}
+	restConfig, err := a.Factory.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := a.Factory.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	c, err := client.New(restConfig, client.Options{Mapper: mapper})
+	if err != nil {
+		return nil, err
+	}
+	return polling.NewStatusPoller(c, mapper), nil
+}
+
+func notCurrent(ids []object.ObjMetadata, statuses map[object.ObjMetadata]status.Status) []string {
+	var result []string
+	for _, id := range ids {
+		if statuses[id] != status.CurrentStatus {
+			result = append(result, fmt.Sprintf("%s %s/%s", id.GroupKind, id.Namespace, id.Name))
+		}
+	}
+	return result
+}
+
+func resourceInterface(
+	dynamicClient dynamic.Interface,
+	mapper meta.RESTMapper,
+	obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		return dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+	}
+	return dynamicClient.Resource(mapping.Resource), nil
+}
+
+// fieldConflicts extracts field manager conflicts from server-side apply error, returns nil if
+// error is not caused by the conflict
+func fieldConflicts(obj *unstructured.Unstructured, err error) []FieldConflict {
+	if !apierror.IsConflict(err) {
+		return nil
+	}
+	apiStatus, ok := err.(apierror.APIStatus)
+	if !ok || apiStatus.Status().Details == nil {
+		return nil
+	}
+	var conflicts []FieldConflict
+	for _, cause := range apiStatus.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		manager := cause.Message
+		if match := conflictManagerRegex.FindStringSubmatch(cause.Message); len(match) == 2 {
+			manager = match[1]
+		}
+		conflicts = append(conflicts, FieldConflict{
+			Object:  objectID(obj),
+			Manager: manager,
+			Field:   cause.Field,
+		})
+	}
+	return conflicts
+}
+
+func objMetadataSet(objects []*unstructured.Unstructured) []object.ObjMetadata {
+	ids := make([]object.ObjMetadata, 0, len(objects))
+	for _, obj := range objects {
+		ids = append(ids, object.ObjMetadata{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			GroupKind: obj.GroupVersionKind().GroupKind(),
+		})
+	}
+	return ids
+}
+
+func objectID(obj *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s %s/%s", obj.GroupVersionKind().GroupKind(), obj.GetNamespace(), obj.GetName())
+}
@@ -0,0 +1,157 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package applier
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	cliapply "sigs.k8s.io/cli-utils/pkg/apply"
+	applyevent "sigs.k8s.io/cli-utils/pkg/apply/event"
+	clicommon "sigs.k8s.io/cli-utils/pkg/common"
+
+	"opendev.org/airship/airshipctl/pkg/events"
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	// ApplyWaveAnnotation defines the wave the resource is applied in, waves are applied in ascending
+	// order, resources without the annotation belong to wave 0
+	ApplyWaveAnnotation = "airshipit.org/apply-wave"
+)
+
+// wave is a group of objects that are applied together
+type wave struct {
+	number  int
+	objects []*unstructured.Unstructured
+}
+
+// splitWaves groups objects by apply wave annotation and returns the waves in ascending order,
+// inventory object is always placed into the first wave because every apply needs it
+func splitWaves(objects []*unstructured.Unstructured) ([]wave, error) {
+	byNumber := map[int][]*unstructured.Unstructured{}
+	var inventory []*unstructured.Unstructured
+	for _, obj := range objects {
+		if _, ok := obj.GetLabels()[clicommon.InventoryLabel]; ok {
+			inventory = append(inventory, obj)
+			continue
+		}
+		number := 0
+		if value, ok := obj.GetAnnotations()[ApplyWaveAnnotation]; ok {
+			var err error
+			if number, err = strconv.Atoi(value); err != nil {
+				return nil, ErrInvalidApplyWave{Object: objectID(obj), Value: value}
+			}
+		}
+		byNumber[number] = append(byNumber[number], obj)
+	}
+
+	numbers := make([]int, 0, len(byNumber))
+	for number := range byNumber {
+		numbers = append(numbers, number)
+	}
+	sort.Ints(numbers)
+
+	waves := make([]wave, 0, len(numbers))
+	for _, number := range numbers {
+		waves = append(waves, wave{number: number, objects: byNumber[number]})
+	}
+	if len(waves) == 0 {
+		waves = append(waves, wave{})
+	}
+	inventory = append(inventory, waves[0].objects...)
+	waves[0].objects = inventory
+	return waves, nil
+}
+
+// applyWaves applies waves one by one, waiting for resources of each wave to become Current
+// before moving to the next one, returns true if all waves are applied
+func (a *Applier) applyWaves(ctx context.Context, waves []wave, ao ApplyOptions) bool {
+	// cli-utils requires the inventory object in every apply, since pruning is performed by airshipctl
+	// it adds objects of the wave to the inventory without removing objects of previous waves
+	inventory := inventoryObjects(waves[0].objects)
+	for idx, w := range waves {
+		timeout := ao.waveTimeout(w.number)
+		a.sendWaveEvent(events.ApplyWaveStart, w.number,
+			fmt.Sprintf("applying wave %d with %d resources", w.number, len(w.objects)))
+
+		if ao.ServerSideApply {
+			if err := a.patchServerSide(w.objects, ao); err != nil {
+				handleError(a.eventChannel, err)
+				return false
+			}
+		} else {
+			opts := cliApplyOptions(ao)
+			// waiting is performed by airshipctl for resources of current wave only
+			opts.EmitStatusEvents = false
+			opts.ReconcileTimeout = time.Duration(0)
+			objects := w.objects
+			if idx > 0 {
+				objects = append(append([]*unstructured.Unstructured{}, inventory...), w.objects...)
+			}
+			if !a.runDriver(ctx, objects, opts) {
+				return false
+			}
+		}
+
+		if timeout != time.Duration(0) && ao.DryRunStrategy == clicommon.DryRunNone {
+			log.Printf("Waiting %v for resources of wave %d to become Current", timeout, w.number)
+			if err := a.waitForCurrent(ctx, objMetadataSet(w.objects), timeout); err != nil {
+				handleError(a.eventChannel, err)
+				return false
+			}
+		}
+		a.sendWaveEvent(events.ApplyWaveComplete, w.number, fmt.Sprintf("wave %d is applied", w.number))
+	}
+	return true
+}
+
+// inventoryObjects returns inventory objects found among the objects
+func inventoryObjects(objects []*unstructured.Unstructured) []*unstructured.Unstructured {
+	var inventory []*unstructured.Unstructured
+	for _, obj := range objects {
+		if _, ok := obj.GetLabels()[clicommon.InventoryLabel]; ok {
+			inventory = append(inventory, obj)
+		}
+	}
+	return inventory
+}
+
+// runDriver forwards events of the driver to event channel, returns false if error event was received
+func (a *Applier) runDriver(ctx context.Context, objects []*unstructured.Unstructured, opts cliapply.Options) bool {
+	succeeded := true
+	for e := range a.Driver.Run(ctx, objects, opts) {
+		if e.Type == applyevent.ErrorType {
+			succeeded = false
+		}
+		a.eventChannel <- events.Event{
+			Type:         events.ApplierType,
+			ApplierEvent: e,
+		}
+	}
+	return succeeded
+}
+
+func (a *Applier) sendWaveEvent(operation events.ApplyWaveOperation, number int, message string) {
+	a.eventChannel <- events.NewEvent().WithApplyWaveEvent(events.ApplyWaveEvent{
+		Operation: operation,
+		Wave:      number,
+		Message:   message,
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 
 	"opendev.org/airship/airshipctl/pkg/k8s/kubectl"
 	k8sutils "opendev.org/airship/airshipctl/pkg/k8s/utils"
@@ -94,6 +95,35 @@ func NewClient(airshipConfigPath string, kubeconfig string) (Interface, error) {
 	return client, nil
 }
 
+// NewClientFromFactory creates a Client using the passed in kubectl factory, it allows to
+// create clients for contexts other than current one
+func NewClientFromFactory(f cmdutil.Factory) (Interface, error) {
+	client := &Client{kubectl: kubectl.NewKubectl(f)}
+	var err error
+
+	client.clientSet, err = f.KubernetesClientSet()
+	if err != nil {
+		return nil, err
+	}
+
+	client.dynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+
+	restConfig, err := f.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client.apixClient, err = apix.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
 // ClientSet returns the ClientSet interface
 func (c *Client) ClientSet() kubernetes.Interface {
 	return c.clientSet
@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"opendev.org/airship/airshipctl/pkg/k8s/client"
+	k8sutils "opendev.org/airship/airshipctl/pkg/k8s/utils"
 	"opendev.org/airship/airshipctl/testutil"
 )
 
@@ -50,3 +51,16 @@ func TestNewClient(t *testing.T) {
 	assert.NotNil(t, client.ApiextensionsClientSet())
 	assert.NotNil(t, client.Kubectl())
 }
+
+func TestNewClientFromFactory(t *testing.T) {
+	akp, err := filepath.Abs(kubeconfigPath)
+	require.NoError(t, err)
+
+	client, err := client.NewClientFromFactory(k8sutils.FactoryFromKubeConfig(akp, ""))
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+	assert.NotNil(t, client.ClientSet())
+	assert.NotNil(t, client.DynamicClient())
+	assert.NotNil(t, client.ApiextensionsClientSet())
+	assert.NotNil(t, client.Kubectl())
+}
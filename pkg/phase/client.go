@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"io"
 	"path/filepath"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -65,6 +66,21 @@ func (p *phase) defaultBundleFactory() document.BundleFactoryFunc {
 	return document.BundleFactoryFromDocRoot(p.DocumentRoot)
 }
 
+// inputsBundleFactory returns default bundle factory, which adds the document with phase inputs to the bundle
+func (p *phase) inputsBundleFactory(inputs document.Document) document.BundleFactoryFunc {
+	factory := p.defaultBundleFactory()
+	if inputs == nil {
+		return factory
+	}
+	return func() (document.Bundle, error) {
+		bundle, err := factory()
+		if err != nil {
+			return nil, err
+		}
+		return bundle, bundle.Append(inputs)
+	}
+}
+
 func (p *phase) defaultDocFactory() document.DocFactoryFunc {
 	return func() (document.Document, error) {
 		return p.helper.ExecutorDoc(ifc.ID{Name: p.apiObj.Name, Namespace: p.apiObj.Namespace})
@@ -132,7 +148,7 @@ func (p *phase) executor(docFactory document.DocFactoryFunc,
 // Run runs the phase via executor
 func (p *phase) Run(ro ifc.RunOptions) error {
 	defer p.processor.Close()
-	executor, err := p.Executor()
+	executor, err := p.executor(p.defaultDocFactory(), p.inputsBundleFactory(ro.Inputs))
 	if err != nil {
 		return err
 	}
@@ -266,6 +282,12 @@ func (p *plan) Validate() error {
 
 // Run function executes Run method for each phase
 func (p *plan) Run(ro ifc.RunOptions) error {
+	if !ro.DryRun {
+		if err := p.checkImages(); err != nil {
+			return err
+		}
+	}
+	state := &PlanRunState{Plan: p.apiObj.Name, Namespace: p.apiObj.Namespace, StartedAt: time.Now().UTC()}
 	for _, step := range p.apiObj.Phases {
 		phaseRunner, err := p.phaseClient.PhaseByID(ifc.ID{Name: step.Name})
 		if err != nil {
@@ -273,13 +295,79 @@ func (p *plan) Run(ro ifc.RunOptions) error {
 		}
 
 		log.Printf("executing phase: %s\n", step.Name)
-		if err = phaseRunner.Run(ro); err != nil {
+		if ro.DryRun {
+			if err = phaseRunner.Run(ro); err != nil {
+				return err
+			}
+			continue
+		}
+		if err = p.runPhase(phaseRunner, step, ro, state); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+// runPhase runs the phase with inputs produced by the previous phases, resolves phase outputs and
+// persists them together with the phase status in the plan run state
+func (p *plan) runPhase(phaseRunner ifc.Phase, step v1alpha1.PhaseStep, ro ifc.RunOptions,
+	state *PlanRunState) error {
+	phaseObj, err := p.helper.Phase(ifc.ID{Name: step.Name})
+	if err != nil {
+		return err
+	}
+	executorDoc, err := p.helper.ExecutorDoc(ifc.ID{Name: step.Name})
+	if err != nil {
+		return err
+	}
+	resultsDir, err := executors.ResultsDir(executorDoc, p.helper.PhaseEntryPointBasePath())
+	if err != nil {
+		return err
+	}
+	if err = checkDocumentOutputs(phaseObj, resultsDir); err != nil {
+		return err
+	}
+	envs, inputs, err := phaseInputs(phaseObj, state)
+	if err != nil {
+		return err
+	}
+	ro.Inputs = inputs
+	ro.Env = envs
+
+	err = phaseRunner.Run(ro)
+
+	phaseState := PhaseRunState{Name: step.Name, Status: PhaseSucceeded}
+	if err == nil {
+		phaseState.Outputs, err = phaseOutputs(phaseObj, resultsDir, p.helper.PhaseEntryPointBasePath())
+	}
+	if err != nil {
+		phaseState.Status = PhaseFailed
+	}
+	state.Phases = append(state.Phases, phaseState)
+
+	statePath := PlanRunStatePath(p.helper.WorkDir(), ifc.ID{Name: p.apiObj.Name, Namespace: p.apiObj.Namespace})
+	if saveErr := state.Save(statePath); saveErr != nil {
+		log.Printf("failed to save plan run state to %s: %v", statePath, saveErr)
+	}
+	return err
+}
+
+// checkImages makes sure that container images of all phases are available before the plan is started,
+// so that the plan doesn't fail in the middle because of the image that can't be pulled. The images are
+// read from executor documents, executors aren't built since their inputs may not exist yet
+func (p *plan) checkImages() error {
+	for _, step := range p.apiObj.Phases {
+		executorDoc, err := p.helper.ExecutorDoc(ifc.ID{Name: step.Name})
+		if err != nil {
+			return err
+		}
+		if err = executors.CheckImages(executorDoc); err != nil {
+			return errors.ErrImageCheckFailed{PhaseName: step.Name, Err: err}
+		}
+	}
+	return nil
+}
+
 var _ ifc.Client = &client{}
 
 type client struct {
@@ -17,6 +17,7 @@ package phase_test
 import (
 	"fmt"
 	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,10 +26,13 @@ import (
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/config"
+	"opendev.org/airship/airshipctl/pkg/container"
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/phase"
 	"opendev.org/airship/airshipctl/pkg/phase/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/testutil"
 )
 
 func TestClientPhaseExecutor(t *testing.T) {
@@ -381,6 +385,126 @@ func TestPlanRun(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanRunImageCheck(t *testing.T) {
+	var executorsBuilt int
+	registry := func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+		factory := func(_ ifc.ExecutorConfig) (ifc.Executor, error) {
+			executorsBuilt++
+			return fakeExecutor{}, nil
+		}
+		return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+			{Group: "airshipit.org", Version: "v1alpha1", Kind: "Clusterctl"}:       factory,
+			{Group: "airshipit.org", Version: "v1alpha1", Kind: "GenericContainer"}: factory,
+		}
+	}
+	cfg := testConfig(t)
+	cfg.Manifests["dummy_manifest"].MetadataPath = "outputs_site/metadata.yaml"
+	helper, err := phase.NewHelper(cfg)
+	require.NoError(t, err)
+	client := phase.NewClient(helper, phase.InjectRegistry(registry))
+	p, err := client.PlanByID(ifc.ID{Name: "images"})
+	require.NoError(t, err)
+
+	// images are not needed for dry run
+	require.NoError(t, p.Run(ifc.RunOptions{DryRun: true}))
+	executorsBuilt = 0
+	err = p.Run(ifc.RunOptions{})
+	assert.Equal(t, errors.ErrImageCheckFailed{
+		PhaseName: "images",
+		Err:       container.ErrUnknownPullPolicy{Policy: "Sometimes"},
+	}, err)
+	// images are read from executor documents, no phase is started
+	assert.Equal(t, 0, executorsBuilt)
+}
+
+func TestPlanRunOutputs(t *testing.T) {
+	home, cleanup := testutil.TempDir(t, "airship-plan-state")
+	defer cleanup(t)
+	oldHome := os.Getenv("HOME")
+	defer os.Setenv("HOME", oldHome)
+	require.NoError(t, os.Setenv("HOME", home))
+
+	var runOpts ifc.RunOptions
+	var inputsDoc document.Document
+	consumer := func(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
+		if cfg.PhaseName != "consume" {
+			return fakeExecutor{}, nil
+		}
+		bundle, err := cfg.BundleFactory()
+		if err != nil {
+			return nil, err
+		}
+		inputsDoc, err = bundle.SelectOne(document.NewSelector().ByName("consume" +
+			phase.PhaseInputsDocumentSuffix))
+		if err != nil {
+			return nil, err
+		}
+		return runOptionsExecutor{runOpts: &runOpts}, nil
+	}
+	// documents produced by the generator are already in its sink output directory
+	generator := func(_ ifc.ExecutorConfig) (ifc.Executor, error) {
+		return fakeExecutor{}, nil
+	}
+	registry := func() map[schema.GroupVersionKind]ifc.ExecutorFactory {
+		return map[schema.GroupVersionKind]ifc.ExecutorFactory{
+			{Group: "airshipit.org", Version: "v1alpha1", Kind: "Clusterctl"}:       consumer,
+			{Group: "airshipit.org", Version: "v1alpha1", Kind: "GenericContainer"}: generator,
+		}
+	}
+
+	cfg := testConfig(t)
+	cfg.Manifests["dummy_manifest"].MetadataPath = "outputs_site/metadata.yaml"
+	helper, err := phase.NewHelper(cfg)
+	require.NoError(t, err)
+	client := phase.NewClient(helper, phase.InjectRegistry(registry))
+
+	p, err := client.PlanByID(ifc.ID{Name: "outputs"})
+	require.NoError(t, err)
+	require.NoError(t, p.Run(ifc.RunOptions{}))
+
+	assert.Equal(t, []string{"PROVISIONING_IP=10.23.24.101"}, runOpts.Env)
+	_, ok := os.LookupEnv("PROVISIONING_IP")
+	assert.False(t, ok)
+	require.NotNil(t, inputsDoc)
+	token, err := inputsDoc.GetString("data.token")
+	require.NoError(t, err)
+	assert.Equal(t, "generated-token", token)
+
+	state, err := phase.ReadPlanRunState(phase.PlanRunStatePath(helper.WorkDir(), ifc.ID{Name: "outputs"}))
+	require.NoError(t, err)
+	assert.Equal(t, "outputs", state.Plan)
+	assert.Equal(t, []phase.PhaseRunState{
+		{
+			Name:    "generate",
+			Status:  phase.PhaseSucceeded,
+			Outputs: map[string]string{"ip": "10.23.24.101", "token": "generated-token"},
+		},
+		{
+			Name:   "consume",
+			Status: phase.PhaseSucceeded,
+		},
+	}, state.Phases)
+
+	p, err = client.PlanByID(ifc.ID{Name: "missing_output"})
+	require.NoError(t, err)
+	err = p.Run(ifc.RunOptions{})
+	assert.Equal(t, errors.ErrPhaseOutputNotFound{
+		PhaseName:       "consume_missing",
+		SourcePhaseName: "generate",
+		Output:          "nope",
+	}, err)
+
+	p, err = client.PlanByID(ifc.ID{Name: "no_results"})
+	require.NoError(t, err)
+	err = p.Run(ifc.RunOptions{})
+	assert.Equal(t, errors.ErrInvalidPhaseOutput{
+		PhaseName: "no_results",
+		Output:    "ip",
+		Reason:    "phase executor doesn't produce documents, only GenericContainer with sinkOutputDir does",
+	}, err)
+}
+
 func TestPlanValidate(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -456,3 +580,14 @@ func (e fakeExecutor) Run(ch chan events.Event, _ ifc.RunOptions) {
 func (e fakeExecutor) Validate() error {
 	return e.validate
 }
+
+// runOptionsExecutor records options of its run
+type runOptionsExecutor struct {
+	fakeExecutor
+	runOpts *ifc.RunOptions
+}
+
+func (e runOptionsExecutor) Run(ch chan events.Event, ro ifc.RunOptions) {
+	*e.runOpts = ro
+	e.fakeExecutor.Run(ch, ro)
+}
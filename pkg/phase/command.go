@@ -324,6 +324,7 @@ type StatusFlags struct {
 type StatusCommand struct {
 	Options StatusFlags
 	Factory config.Factory
+	Writer  io.Writer
 }
 
 // RunE returns the status of the given phase
@@ -343,7 +344,14 @@ func (s *StatusCommand) RunE() error {
 		return err
 	}
 
-	_, err = ph.Status()
+	status, err := ph.Status()
+	if err != nil {
+		return err
+	}
+	if status.ExecutorStatus.Message == "" || s.Writer == nil {
+		return nil
+	}
+	_, err = fmt.Fprintln(s.Writer, status.ExecutorStatus.Message)
 	return err
 }
 
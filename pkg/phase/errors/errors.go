@@ -78,3 +78,37 @@ type ErrInvalidOutputFormat struct {
 func (e ErrInvalidOutputFormat) Error() string {
 	return fmt.Sprintf("invalid output format specified %s. Allowed values are table|name", e.RequestedFormat)
 }
+
+// ErrImageCheckFailed is returned if container image of the phase is not available
+type ErrImageCheckFailed struct {
+	PhaseName string
+	Err       error
+}
+
+func (e ErrImageCheckFailed) Error() string {
+	return fmt.Sprintf("image check failed for the phase '%s': %v", e.PhaseName, e.Err)
+}
+
+// ErrInvalidPhaseOutput is returned when output of the phase can't be resolved
+type ErrInvalidPhaseOutput struct {
+	PhaseName string
+	Output    string
+	Reason    string
+}
+
+func (e ErrInvalidPhaseOutput) Error() string {
+	return fmt.Sprintf("failed to resolve output '%s' of the phase '%s': %s", e.Output, e.PhaseName, e.Reason)
+}
+
+// ErrPhaseOutputNotFound is returned when the phase consumes an output which is not produced
+// by any of the previous phases of the plan
+type ErrPhaseOutputNotFound struct {
+	PhaseName       string
+	SourcePhaseName string
+	Output          string
+}
+
+func (e ErrPhaseOutputNotFound) Error() string {
+	return fmt.Sprintf("phase '%s' consumes output '%s' of the phase '%s', which is not produced "+
+		"by the previous phases of the plan", e.PhaseName, e.Output, e.SourcePhaseName)
+}
@@ -67,8 +67,13 @@ func (e *BaremetalManagerExecutor) Run(evtCh chan events.Event, opts ifc.RunOpti
 	if !opts.DryRun {
 		switch e.options.Spec.Operation {
 		case airshipv1.BaremetalOperationPowerOn, airshipv1.BaremetalOperationPowerOff,
-			airshipv1.BaremetalOperationReboot, airshipv1.BaremetalOperationEjectVirtualMedia:
-			err = commandOptions.BMHAction(op)
+			airshipv1.BaremetalOperationReboot, airshipv1.BaremetalOperationEjectVirtualMedia,
+			airshipv1.BaremetalOperationPowerCycle, airshipv1.BaremetalOperationSetBootDevice,
+			airshipv1.BaremetalOperationWaitForPowerState, airshipv1.BaremetalOperationApplyBIOSSettings,
+			airshipv1.BaremetalOperationUpdateFirmware:
+			var result inventoryifc.BaremetalBatchResult
+			result, err = commandOptions.BMHAction(op)
+			e.sendHostEvents(evtCh, result)
 		case airshipv1.BaremetalOperationRemoteDirect:
 			err = commandOptions.RemoteDirect()
 		}
@@ -87,6 +92,30 @@ func (e *BaremetalManagerExecutor) Run(evtCh chan events.Event, opts ifc.RunOpti
 	})
 }
 
+func (e *BaremetalManagerExecutor) sendHostEvents(evtCh chan events.Event, result inventoryifc.BaremetalBatchResult) {
+	for _, host := range result.Hosts {
+		var msg string
+		switch {
+		case host.Skipped:
+			msg = fmt.Sprintf("Skipped operation '%s' against host '%s' in namespace '%s'",
+				result.Operation, host.Name, host.Namespace)
+		case host.Error != nil:
+			msg = fmt.Sprintf("Operation '%s' failed against host '%s' in namespace '%s': %v",
+				result.Operation, host.Name, host.Namespace, host.Error)
+		default:
+			msg = fmt.Sprintf("Successfully completed operation '%s' against host '%s' in namespace '%s'",
+				result.Operation, host.Name, host.Namespace)
+		}
+		evtCh <- events.NewEvent().WithBaremetalManagerEvent(events.BaremetalManagerEvent{
+			Step:          events.BaremetalManagerHost,
+			HostOperation: string(e.options.Spec.Operation),
+			HostName:      host.Name,
+			HostNamespace: host.Namespace,
+			Message:       msg,
+		})
+	}
+}
+
 // Validate executor configuration and documents
 func (e *BaremetalManagerExecutor) Validate() error {
 	_, err := e.validate()
@@ -105,6 +134,16 @@ func (e *BaremetalManagerExecutor) validate() (inventoryifc.BaremetalOperation,
 		result = inventoryifc.BaremetalOperationEjectVirtualMedia
 	case airshipv1.BaremetalOperationReboot:
 		result = inventoryifc.BaremetalOperationReboot
+	case airshipv1.BaremetalOperationPowerCycle:
+		result = inventoryifc.BaremetalOperationPowerCycle
+	case airshipv1.BaremetalOperationSetBootDevice:
+		result = inventoryifc.BaremetalOperationSetBootDevice
+	case airshipv1.BaremetalOperationWaitForPowerState:
+		result = inventoryifc.BaremetalOperationWaitForPowerState
+	case airshipv1.BaremetalOperationApplyBIOSSettings:
+		result = inventoryifc.BaremetalOperationApplyBIOSSettings
+	case airshipv1.BaremetalOperationUpdateFirmware:
+		result = inventoryifc.BaremetalOperationUpdateFirmware
 	case airshipv1.BaremetalOperationRemoteDirect:
 		// TODO add remote direct validation, make sure that ISO-URL is specified
 		result = ""
@@ -114,11 +153,28 @@ func (e *BaremetalManagerExecutor) validate() (inventoryifc.BaremetalOperation,
 	return result, err
 }
 
-// Render baremetal hosts
-func (e *BaremetalManagerExecutor) Render(w io.Writer, _ ifc.RenderOptions) error {
-	// add printing of baremetal hosts here
-	_, err := w.Write([]byte{})
-	return err
+// Render writes baremetal hosts that are going to be affected by the operation, with
+// resolved BMC address and driver and with BMC credentials redacted
+func (e *BaremetalManagerExecutor) Render(w io.Writer, o ifc.RenderOptions) error {
+	bmhInventory, err := e.inventory.BaremetalInventory()
+	if err != nil {
+		return err
+	}
+
+	hostSelector := e.options.Spec.HostSelector
+	bundle, err := bmhInventory.RenderHosts((inventoryifc.BaremetalHostSelector{}).
+		ByLabel(hostSelector.LabelSelector).
+		ByName(hostSelector.Name).
+		ByNamespace(hostSelector.Namespace))
+	if err != nil {
+		return err
+	}
+
+	bundle, err = bundle.SelectBundle(o.FilterSelector)
+	if err != nil {
+		return err
+	}
+	return bundle.Write(w)
 }
 
 func toCommandOptions(i inventoryifc.Inventory,
@@ -136,6 +192,21 @@ func toCommandOptions(i inventoryifc.Inventory,
 		Name:      spec.HostSelector.Name,
 		Namespace: spec.HostSelector.Namespace,
 		Timeout:   timeout,
+
+		MaxConcurrency:  spec.MaxConcurrency,
+		FailFast:        spec.FailFast,
+		ContinueOnError: spec.ContinueOnError,
+
+		BootDevice: spec.OperationOptions.SetBootDevice.Device,
+		Persistent: spec.OperationOptions.SetBootDevice.Persistent,
+		PowerState: spec.OperationOptions.WaitForPowerState.State,
+		SkipReboot: spec.OperationOptions.ApplyBIOSSettings.SkipReboot,
+
+		FirmwareImageURL: spec.OperationOptions.UpdateFirmware.ImageURL,
+
+		IsoFile:              spec.OperationOptions.RemoteDirect.ISOFile,
+		IsoServerAddress:     spec.OperationOptions.RemoteDirect.ISOServerAddress,
+		IsoServerIdleTimeout: time.Duration(spec.OperationOptions.RemoteDirect.ISOServerIdleTimeout) * time.Second,
 	}
 }
 
@@ -164,6 +164,47 @@ func TestBMHExecutorRun(t *testing.T) {
 	}
 }
 
+func TestBMHExecutorRunHostEvents(t *testing.T) {
+	result := inventoryifc.BaremetalBatchResult{
+		Operation: inventoryifc.BaremetalOperationReboot,
+		Hosts: []inventoryifc.BaremetalHostResult{
+			{Name: "node01", Namespace: "metal3"},
+			{Name: "node02", Namespace: "metal3", Error: fmt.Errorf("reboot failed")},
+		},
+	}
+	bmhi := &testinventory.MockBMHInventory{}
+	bmhi.On("RunOperation").Return(result, fmt.Errorf("operation failed"))
+	bi := &testinventory.MockInventory{}
+	bi.On("BaremetalInventory").Return(bmhi, nil)
+
+	executor, err := executors.NewBaremetalExecutor(ifc.ExecutorConfig{
+		ExecutorDocument: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "reboot", "")),
+		Inventory:        bi,
+	})
+	require.NoError(t, err)
+
+	ch := make(chan events.Event)
+	go executor.Run(ch, ifc.RunOptions{})
+
+	var hostEvents []events.BaremetalManagerEvent
+	var errEvents int
+	for evt := range ch {
+		switch {
+		case evt.Type == events.ErrorType:
+			errEvents++
+		case evt.BaremetalManagerEvent.Step == events.BaremetalManagerHost:
+			hostEvents = append(hostEvents, evt.BaremetalManagerEvent)
+		}
+	}
+	assert.Equal(t, 1, errEvents)
+	require.Len(t, hostEvents, 2)
+	assert.Equal(t, "node01", hostEvents[0].HostName)
+	assert.Contains(t, hostEvents[0].Message, "Successfully completed")
+	assert.Equal(t, "node02", hostEvents[1].HostName)
+	assert.Equal(t, "metal3", hostEvents[1].HostNamespace)
+	assert.Contains(t, hostEvents[1].Message, "reboot failed")
+}
+
 func TestBMHValidate(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -195,6 +236,26 @@ func TestBMHValidate(t *testing.T) {
 			name:    "success validate eject-virtual-media",
 			execDoc: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "eject-virtual-media", "/some/url")),
 		},
+		{
+			name:    "success validate power-cycle",
+			execDoc: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "power-cycle", "/some/url")),
+		},
+		{
+			name:    "success validate set-boot-device",
+			execDoc: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "set-boot-device", "/some/url")),
+		},
+		{
+			name:    "success validate wait-for-power-state",
+			execDoc: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "wait-for-power-state", "/some/url")),
+		},
+		{
+			name:    "success validate apply-bios-settings",
+			execDoc: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "apply-bios-settings", "/some/url")),
+		},
+		{
+			name:    "success validate update-firmware",
+			execDoc: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "update-firmware", "/some/url")),
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -216,15 +277,91 @@ func TestBMHValidate(t *testing.T) {
 	}
 }
 
-// Dummy test to keep up with coverage, develop better testcases when render is implemented
 func TestBMHManagerRender(t *testing.T) {
-	execDoc := executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "reboot", "/home/iso-url"))
-	executor, err := executors.NewBaremetalExecutor(ifc.ExecutorConfig{
-		ExecutorDocument: execDoc,
-	})
+	rendered, err := document.NewBundleFromBytes([]byte(`apiVersion: metal3.io/v1alpha1
+kind: BareMetalHost
+metadata:
+  name: node02
+  annotations:
+    airshipit.org/bmc-driver: redfish
+spec:
+  bmc:
+    address: redfish+https://10.23.25.1/redfish/v1/Systems/node02
+    credentialsName: node02-bmc-secret
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: node02-bmc-secret
+stringData:
+  password: REDACTED
+  username: REDACTED
+`))
 	require.NoError(t, err)
-	require.NotNil(t, executor)
 
-	err = executor.Render(bytes.NewBuffer([]byte{}), ifc.RenderOptions{})
-	assert.NoError(t, err)
+	tests := []struct {
+		name        string
+		inventory   inventoryifc.Inventory
+		selector    document.Selector
+		expectedErr string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:      "success all documents",
+			inventory: testBaremetalRenderInventory(rendered, nil),
+			contains:  []string{"kind: BareMetalHost", "kind: Secret", "redfish+https://10.23.25.1"},
+		},
+		{
+			name:        "success filter selector",
+			inventory:   testBaremetalRenderInventory(rendered, nil),
+			selector:    document.NewSelector().ByKind("BareMetalHost"),
+			contains:    []string{"name: node02"},
+			notContains: []string{"kind: Secret"},
+		},
+		{
+			name:        "error render hosts",
+			inventory:   testBaremetalRenderInventory(nil, errors.New("no hosts found")),
+			expectedErr: "no hosts found",
+		},
+		{
+			name:        "error no kustomization.yaml for inventory",
+			inventory:   testBaremetalInventoryNoKustomization(),
+			expectedErr: "kustomization.yaml",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			executor, err := executors.NewBaremetalExecutor(ifc.ExecutorConfig{
+				ExecutorDocument: executorDoc(t, fmt.Sprintf(bmhExecutorTemplate, "power-on", "")),
+				Inventory:        tt.inventory,
+			})
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			err = executor.Render(buf, ifc.RenderOptions{FilterSelector: tt.selector})
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, buf.String(), s)
+			}
+		})
+	}
+}
+
+func testBaremetalRenderInventory(bundle document.Bundle, err error) inventoryifc.Inventory {
+	bmhi := &testinventory.MockBMHInventory{}
+	bmhi.On("RenderHosts").Return(bundle, err)
+	bi := &testinventory.MockInventory{}
+	bi.On("BaremetalInventory").Return(bmhi, nil)
+	return bi
 }
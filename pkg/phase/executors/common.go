@@ -15,9 +15,11 @@
 package executors
 
 import (
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/phase/executors/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
@@ -65,6 +67,20 @@ func RegisterExecutor(executorName string, registry map[schema.GroupVersionKind]
 	return nil
 }
 
+// isKind returns true if the document has group, version and kind of the API object
+func isKind(doc document.Document, obj runtime.Object) bool {
+	gvks, _, err := airshipv1.Scheme.ObjectKinds(obj)
+	if err != nil {
+		return false
+	}
+	for _, gvk := range gvks {
+		if gvk.Group == doc.GetGroup() && gvk.Version == doc.GetVersion() && gvk.Kind == doc.GetKind() {
+			return true
+		}
+	}
+	return false
+}
+
 func handleError(ch chan<- events.Event, err error) {
 	ch <- events.NewEvent().WithErrorEvent(events.ErrorEvent{
 		Error: err,
@@ -20,6 +20,10 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/container"
@@ -29,11 +33,16 @@ import (
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
 	"opendev.org/airship/airshipctl/pkg/log"
 	"opendev.org/airship/airshipctl/pkg/phase/errors"
+	executorerrors "opendev.org/airship/airshipctl/pkg/phase/executors/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/util"
 )
 
 var _ ifc.Executor = &ContainerExecutor{}
 
+// envFromNameRegexp matches names allowed for keys of Secret and ConfigMap data
+var envFromNameRegexp = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
 // ContainerExecutor contains resources for generic container executor
 type ContainerExecutor struct {
 	ResultsDir    string
@@ -64,13 +73,8 @@ func NewContainerExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 		return nil, err
 	}
 
-	var resultsDir string
-	if apiObj.Spec.SinkOutputDir != "" {
-		resultsDir = filepath.Join(cfg.SinkBasePath, apiObj.Spec.SinkOutputDir)
-	}
-
 	return &ContainerExecutor{
-		ResultsDir:       resultsDir,
+		ResultsDir:       containerResultsDir(apiObj, cfg.SinkBasePath),
 		MountBasePath:    cfg.TargetPath,
 		ExecutorBundle:   bundle,
 		ExecutorDocument: cfg.ExecutorDocument,
@@ -81,6 +85,27 @@ func NewContainerExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 	}, nil
 }
 
+// ResultsDir returns the directory where the documents produced by the phase executor are written,
+// only generic containers with sink output directory produce documents, for other executors
+// empty string is returned
+func ResultsDir(executorDoc document.Document, sinkBasePath string) (string, error) {
+	if !isKind(executorDoc, v1alpha1.DefaultGenericContainer()) {
+		return "", nil
+	}
+	apiObj := v1alpha1.DefaultGenericContainer()
+	if err := executorDoc.ToAPIObject(apiObj, v1alpha1.Scheme); err != nil {
+		return "", err
+	}
+	return containerResultsDir(apiObj, sinkBasePath), nil
+}
+
+func containerResultsDir(apiObj *v1alpha1.GenericContainer, sinkBasePath string) string {
+	if apiObj.Spec.SinkOutputDir == "" {
+		return ""
+	}
+	return filepath.Join(sinkBasePath, apiObj.Spec.SinkOutputDir)
+}
+
 // Run generic container as a phase runner
 func (c *ContainerExecutor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 	defer close(evtCh)
@@ -124,7 +149,18 @@ func (c *ContainerExecutor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 		return
 	}
 
-	err = c.ClientFunc(c.ResultsDir, input, output, c.Container, c.MountBasePath).Run()
+	envs, err := c.envFrom()
+	if err != nil {
+		handleError(evtCh, err)
+		return
+	}
+	err = c.ClientFunc(c.ResultsDir, input, output, c.containerConf(opts.Env, envs), c.MountBasePath).Run()
+	if timeoutErr := (container.ErrContainerTimeout{}); goerrors.As(err, &timeoutErr) {
+		evtCh <- events.NewEvent().WithGenericContainerEvent(events.GenericContainerEvent{
+			Operation: events.GenericContainerTimeout,
+			Message:   timeoutErr.Error(),
+		})
+	}
 	if err != nil {
 		handleError(evtCh, err)
 		return
@@ -195,6 +231,171 @@ func (c *ContainerExecutor) setConfig() error {
 	return nil
 }
 
+// envFrom resolves env variables referenced by envFrom section of the container spec
+func (c *ContainerExecutor) envFrom() ([]util.EnvVar, error) {
+	// KRM functions get env variables on the command line of the container runtime
+	if len(c.Container.Spec.EnvFrom) != 0 && c.Container.Spec.Type == v1alpha1.GenericContainerTypeKrm {
+		return nil, executorerrors.ErrEnvFromKRMFunction{}
+	}
+	var envs []util.EnvVar
+	for _, src := range c.Container.Spec.EnvFrom {
+		data, err := c.envFromData(src)
+		if err != nil {
+			return nil, err
+		}
+		if src.Key != "" {
+			val, ok := data[src.Key]
+			if !ok {
+				return nil, executorerrors.ErrEnvFromKeyNotFound{Kind: src.Kind, Name: src.Name, Key: src.Key}
+			}
+			name := src.EnvVar
+			if name == "" {
+				name = src.Key
+			}
+			if !validEnvFromName(name) {
+				return nil, executorerrors.ErrEnvFromInvalidName{Name: name}
+			}
+			envs = append(envs, util.EnvVar{Key: name, Value: val})
+			continue
+		}
+		keys := make([]string, 0, len(data))
+		for key := range data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if !validEnvFromName(src.Prefix + key) {
+				return nil, executorerrors.ErrEnvFromInvalidName{Name: src.Prefix + key}
+			}
+			envs = append(envs, util.EnvVar{Key: src.Prefix + key, Value: data[key]})
+		}
+	}
+	return envs, nil
+}
+
+// envFromData returns data of the Secret or ConfigMap document referenced by envFrom source,
+// SOPS encrypted document is decrypted and data of the Secret is base64 decoded
+func (c *ContainerExecutor) envFromData(src v1alpha1.EnvFromSource) (map[string]string, error) {
+	if src.Kind != "Secret" && src.Kind != "ConfigMap" {
+		return nil, executorerrors.ErrEnvFromUnsupportedKind{Kind: src.Kind}
+	}
+	selector := document.NewSelector().
+		ByGvk("", "v1", src.Kind).
+		ByName(src.Name).
+		ByNamespace(src.Namespace)
+	if src.LabelSelector != "" {
+		selector = selector.ByLabel(src.LabelSelector)
+	}
+	doc, err := c.Options.PhaseConfigBundle.SelectOne(selector)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = doc.GetFieldValue("sops"); err == nil {
+		if doc, err = c.decrypt(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	data := map[string]string{}
+	if src.Kind == "ConfigMap" {
+		cm := &corev1.ConfigMap{}
+		if err = doc.ToObject(cm); err != nil {
+			return nil, err
+		}
+		for k, v := range cm.BinaryData {
+			data[k] = string(v)
+		}
+		for k, v := range cm.Data {
+			data[k] = v
+		}
+		return data, nil
+	}
+	secret := &corev1.Secret{}
+	if err = doc.ToObject(secret); err != nil {
+		return nil, err
+	}
+	for k, v := range secret.Data {
+		data[k] = string(v)
+	}
+	// stringData takes precedence over data, in the same way as in kubernetes API
+	for k, v := range secret.StringData {
+		data[k] = v
+	}
+	return data, nil
+}
+
+// decrypt decrypts SOPS encrypted document using decrypter KRM function of the phase config bundle,
+// output of the function is streamed to airshipctl, so decrypted document is kept in memory only
+func (c *ContainerExecutor) decrypt(doc document.Document) (document.Document, error) {
+	name := c.Container.Spec.EnvFromDecrypter
+	if name == "" {
+		name = v1alpha1.DefaultEnvFromDecrypter
+	}
+	selector, err := document.NewSelector().ByObject(&v1alpha1.GenericContainer{}, v1alpha1.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	decrypterDoc, err := c.Options.PhaseConfigBundle.SelectOne(selector.ByName(name))
+	if err != nil {
+		return nil, err
+	}
+	conf := v1alpha1.DefaultGenericContainer()
+	if err = decrypterDoc.ToAPIObject(conf, v1alpha1.Scheme); err != nil {
+		return nil, err
+	}
+	// output of airship type container is read from its logs, which are kept by the container runtime,
+	// while KRM function container is attached to airshipctl and removed by the runtime once it's finished
+	if conf.Spec.Type != v1alpha1.GenericContainerTypeKrm {
+		return nil, executorerrors.ErrEnvFromDecrypterType{Name: name}
+	}
+	// decrypted document must not be written to the container results directory
+	conf.Spec.SinkOutputDir = ""
+
+	input, err := doc.AsYAML()
+	if err != nil {
+		return nil, err
+	}
+	output := &bytes.Buffer{}
+	log.Debugf("Decrypting %s '%s' using GenericContainer '%s'", doc.GetKind(), doc.GetName(), name)
+	if err = c.ClientFunc("", bytes.NewReader(input), output, conf, c.MountBasePath).Run(); err != nil {
+		return nil, err
+	}
+	bundle, err := document.NewBundleFromBytes(output.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	docs, err := bundle.GetAllDocuments()
+	if err != nil {
+		return nil, err
+	}
+	if len(docs) != 1 {
+		return nil, executorerrors.ErrEnvFromNotDecrypted{Kind: doc.GetKind(), Name: doc.GetName()}
+	}
+	if _, err = docs[0].GetFieldValue("sops"); err == nil {
+		return nil, executorerrors.ErrEnvFromNotDecrypted{Kind: doc.GetKind(), Name: doc.GetName()}
+	}
+	return docs[0], nil
+}
+
+// containerConf returns the container spec with phase inputs and values resolved from envFrom section added
+// to its env variables, the values are passed to the container runtime only and the executor spec isn't modified
+func (c *ContainerExecutor) containerConf(inputs []string, envs []util.EnvVar) *v1alpha1.GenericContainer {
+	if len(inputs) == 0 && len(envs) == 0 {
+		return c.Container
+	}
+	conf := c.Container.DeepCopy()
+	conf.Spec.EnvVars = append(conf.Spec.EnvVars, inputs...)
+	for _, env := range envs {
+		conf.Spec.EnvVars = append(conf.Spec.EnvVars, env.Key+"="+env.Value)
+	}
+	return conf
+}
+
+// validEnvFromName checks that envFrom value may be passed to the container with the given name
+func validEnvFromName(name string) bool {
+	return envFromNameRegexp.MatchString(name)
+}
+
 // Status returns the status of the given phase
 func (c *ContainerExecutor) Status() (ifc.ExecutorStatus, error) {
 	return ifc.ExecutorStatus{}, commonerrors.ErrNotImplemented{What: GenericContainer}
@@ -15,7 +15,9 @@ package executors_test
 import (
 	"fmt"
 	"io"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -72,6 +74,7 @@ map:
   testCluster: {}
 `
 	singleExecutorBundlePath = "../../container/testdata/single"
+	envFromBundlePath        = "testdata/envfrom"
 )
 
 func testClusterMap(t *testing.T) clustermap.ClusterMap {
@@ -242,6 +245,197 @@ type: Opaque
 	}
 }
 
+func TestGenericContainerTimeout(t *testing.T) {
+	b, err := document.NewBundleByPath(singleExecutorBundlePath)
+	require.NoError(t, err)
+	timeoutErr := container.ErrContainerTimeout{ID: "testID", Timeout: time.Second, Logs: "still running"}
+	e := executors.ContainerExecutor{
+		ExecutorBundle: b,
+		Container:      &v1alpha1.GenericContainer{},
+		ClientFunc: func(string, io.Reader, io.Writer, *v1alpha1.GenericContainer, string) container.ClientV1Alpha1 {
+			return fakeContainerClient{err: timeoutErr}
+		},
+	}
+
+	ch := make(chan events.Event)
+	go e.Run(ch, ifc.RunOptions{})
+
+	actualEvt := make([]events.Event, 0)
+	for evt := range ch {
+		actualEvt = append(actualEvt, evt)
+	}
+	require.Len(t, actualEvt, 3)
+	assert.Equal(t, events.GenericContainerType, actualEvt[1].Type)
+	assert.Equal(t, events.GenericContainerTimeout, actualEvt[1].GenericContainerEvent.Operation)
+	assert.Contains(t, actualEvt[1].GenericContainerEvent.Message, "still running")
+	assert.Equal(t, timeoutErr, actualEvt[2].ErrorEvent.Error)
+}
+
+func TestGenericContainerEnvFrom(t *testing.T) {
+	decryptedSecret := `apiVersion: v1
+kind: Secret
+metadata:
+  name: encrypted
+data:
+  token: c2VjcmV0
+`
+	tests := []struct {
+		name        string
+		envFrom     []v1alpha1.EnvFromSource
+		inputs      []string
+		krm         v1alpha1.KRMContainerSpec
+		decrypter   string
+		decrypted   string
+		expectedErr string
+		expectedEnv map[string]string
+	}{
+		{
+			name: "secret key by label selector",
+			envFrom: []v1alpha1.EnvFromSource{
+				{Kind: "Secret", LabelSelector: "app=bmo", Key: "username", EnvVar: "BMC_USERNAME"},
+				{Kind: "Secret", Name: "bmc-credentials", Key: "password"},
+			},
+			expectedEnv: map[string]string{"BMC_USERNAME": "admin", "password": "secret-password"},
+		},
+		{
+			name: "all keys of config map with prefix",
+			envFrom: []v1alpha1.EnvFromSource{
+				{Kind: "ConfigMap", Name: "ironic-vars", Prefix: "IRONIC_"},
+			},
+			expectedEnv: map[string]string{
+				"IRONIC_DHCP_RANGE":      "10.23.24.200,10.23.24.250",
+				"IRONIC_PROVISIONING_IP": "10.23.24.101",
+			},
+		},
+		{
+			name:    "phase inputs",
+			envFrom: []v1alpha1.EnvFromSource{{Kind: "Secret", Name: "bmc-credentials", Key: "password"}},
+			inputs:  []string{"PROVISIONING_IP=10.23.24.101"},
+			expectedEnv: map[string]string{
+				"PROVISIONING_IP": "10.23.24.101",
+				"password":        "secret-password",
+			},
+		},
+		{
+			name:        "encrypted secret",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "Secret", Name: "encrypted", Key: "token"}},
+			decrypted:   decryptedSecret,
+			expectedEnv: map[string]string{"token": "secret"},
+		},
+		{
+			name:        "unsupported kind",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "Deployment", Name: "ironic-vars"}},
+			expectedErr: "envFrom supports Secret and ConfigMap documents only",
+		},
+		{
+			name:        "missing key",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "ConfigMap", Name: "ironic-vars", Key: "NOPE"}},
+			expectedErr: "key 'NOPE' is not found in ConfigMap 'ironic-vars'",
+		},
+		{
+			name:        "missing document",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "Secret", Name: "no-such-secret"}},
+			expectedErr: "found no documents",
+		},
+		{
+			name: "invalid name",
+			envFrom: []v1alpha1.EnvFromSource{
+				{Kind: "Secret", Name: "bmc-credentials", Key: "password", EnvVar: "../password"},
+			},
+			expectedErr: "invalid envFrom name '../password'",
+		},
+		{
+			name:        "secret is not decrypted",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "Secret", Name: "encrypted"}},
+			expectedErr: "Secret 'encrypted' referenced by envFrom is not decrypted",
+		},
+		{
+			name:        "airship decrypter",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "Secret", Name: "encrypted", Key: "token"}},
+			decrypter:   "airship-decrypter",
+			expectedErr: "decrypter GenericContainer 'airship-decrypter' must be of krm type",
+		},
+		{
+			name:        "exec function",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "ConfigMap", Name: "ironic-vars"}},
+			krm:         v1alpha1.KRMContainerSpec{ExecPath: "/bin/function"},
+			expectedErr: "envFrom is supported for airship type containers only",
+		},
+		{
+			name:        "krm function",
+			envFrom:     []v1alpha1.EnvFromSource{{Kind: "ConfigMap", Name: "ironic-vars"}},
+			krm:         v1alpha1.KRMContainerSpec{Image: "quay.io/airshipit/function:latest"},
+			expectedErr: "envFrom is supported for airship type containers only",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := document.NewBundleFromBytes(nil)
+			require.NoError(t, err)
+			phaseConfigBundle, err := document.NewBundleByPath(envFromBundlePath)
+			require.NoError(t, err)
+
+			var specEnv []string
+			containerType := v1alpha1.GenericContainerTypeAirship
+			if tt.krm.ExecPath != "" || tt.krm.Image != "" {
+				containerType = v1alpha1.GenericContainerTypeKrm
+			}
+			e := executors.ContainerExecutor{
+				ExecutorBundle: b,
+				Container: &v1alpha1.GenericContainer{
+					Spec: v1alpha1.GenericContainerSpec{
+						Type:             containerType,
+						KRM:              tt.krm,
+						EnvFrom:          tt.envFrom,
+						EnvFromDecrypter: tt.decrypter,
+					},
+				},
+				ClientFunc: func(_ string, _ io.Reader, out io.Writer, conf *v1alpha1.GenericContainer,
+					_ string) container.ClientV1Alpha1 {
+					if conf.Spec.Type == v1alpha1.GenericContainerTypeKrm {
+						decrypted := tt.decrypted
+						if decrypted == "" {
+							decrypted = "kind: Secret\nmetadata:\n  name: encrypted\nsops: {}\n"
+						}
+						_, writeErr := out.Write([]byte(decrypted))
+						require.NoError(t, writeErr)
+						return fakeContainerClient{}
+					}
+					specEnv = conf.Spec.EnvVars
+					return fakeContainerClient{}
+				},
+				Options: ifc.ExecutorConfig{PhaseConfigBundle: phaseConfigBundle},
+			}
+
+			ch := make(chan events.Event)
+			go e.Run(ch, ifc.RunOptions{Env: tt.inputs})
+
+			actualEvt := make([]events.Event, 0)
+			for evt := range ch {
+				actualEvt = append(actualEvt, evt)
+			}
+			lastEvt := actualEvt[len(actualEvt)-1]
+			if tt.expectedErr != "" {
+				require.Error(t, lastEvt.ErrorEvent.Error)
+				assert.Contains(t, lastEvt.ErrorEvent.Error.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, lastEvt.ErrorEvent.Error)
+			assert.Len(t, specEnv, len(tt.expectedEnv))
+			for key, val := range tt.expectedEnv {
+				// values are passed to the container only
+				assert.Contains(t, specEnv, key+"="+val)
+				_, ok := os.LookupEnv(key)
+				assert.False(t, ok)
+			}
+			// executor spec is not modified
+			assert.Empty(t, e.Container.Spec.EnvVars)
+		})
+	}
+}
+
 func TestSetKubeConfig(t *testing.T) {
 	getFileErr := fmt.Errorf("failed to get file")
 	testCases := []struct {
@@ -299,3 +493,9 @@ func (k fakeKubeConfig) WriteFile(_ string) error                     { return n
 func (k fakeKubeConfig) WriteTempFile(_ string) (string, kubeconfig.Cleanup, error) {
 	return k.getFile()
 }
+
+type fakeContainerClient struct {
+	err error
+}
+
+func (c fakeContainerClient) Run() error { return c.err }
@@ -16,17 +16,37 @@ package executors
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/bootstrap/ephemeral"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/errors"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/log"
+	executorerrors "opendev.org/airship/airshipctl/pkg/phase/executors/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+	"opendev.org/airship/airshipctl/pkg/util"
+)
+
+const (
+	// apiServerTimeout is a timeout of the request checking that ephemeral API server is reachable
+	apiServerTimeout = 30 * time.Second
+	// bootstrapContainerPrefix is prepended to the name of BootConfiguration to form bootstrap container name
+	bootstrapContainerPrefix = "airshipctl-"
+	// bootstrapContainerRemoved is the status of bootstrap container which isn't found
+	bootstrapContainerRemoved container.Status = "removed"
 )
 
 var _ ifc.Executor = &EphemeralExecutor{}
@@ -38,6 +58,11 @@ type EphemeralExecutor struct {
 
 	BootConf  *v1alpha1.BootConfiguration
 	Container container.Container
+	// GetContainerFunc looks up existing bootstrap container by its name
+	GetContainerFunc func(ctx context.Context, driver string, id string) (container.Container, error)
+
+	ClusterName string
+	ClusterMap  clustermap.ClusterMap
 }
 
 // NewEphemeralExecutor creates instance of phase executor
@@ -52,6 +77,9 @@ func NewEphemeralExecutor(cfg ifc.ExecutorConfig) (ifc.Executor, error) {
 	return &EphemeralExecutor{
 		ExecutorDocument: cfg.ExecutorDocument,
 		BootConf:         apiObj,
+		GetContainerFunc: container.GetContainer,
+		ClusterName:      cfg.ClusterName,
+		ClusterMap:       cfg.ClusterMap,
 	}, nil
 }
 
@@ -77,18 +105,21 @@ func (c *EphemeralExecutor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 		builder, err := container.NewContainer(
 			ctx,
 			c.BootConf.BootstrapContainer.ContainerRuntime,
-			c.BootConf.BootstrapContainer.Image)
+			c.BootConf.BootstrapContainer.Image,
+			c.BootConf.BootstrapContainer.ImagePullPolicy)
 		if err != nil {
 			handleError(evtCh, err)
 			return
 		}
 		c.Container = builder
+		c.removeStaleContainer()
 	}
 
 	bootstrapOpts := ephemeral.BootstrapContainerOptions{
-		Container: c.Container,
-		Cfg:       c.BootConf,
-		Sleep:     time.Sleep,
+		Container:     c.Container,
+		Cfg:           c.BootConf,
+		Sleep:         time.Sleep,
+		ContainerName: c.containerName(),
 	}
 
 	evtCh <- events.NewEvent().WithBootstrapEvent(events.BootstrapEvent{
@@ -104,7 +135,8 @@ func (c *EphemeralExecutor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 
 	evtCh <- events.NewEvent().WithBootstrapEvent(events.BootstrapEvent{
 		Operation: events.BootstrapRun,
-		Message:   "Creating and starting the Bootstrap Container ...",
+		Message: "Creating and starting the Bootstrap Container to " +
+			c.BootConf.EphemeralCluster.BootstrapCommand + " Ephemeral cluster ...",
 	})
 
 	err = bootstrapOpts.CreateBootstrapContainer()
@@ -113,20 +145,32 @@ func (c *EphemeralExecutor) Run(evtCh chan events.Event, opts ifc.RunOptions) {
 		return
 	}
 
-	evtCh <- events.NewEvent().WithBootstrapEvent(events.BootstrapEvent{
-		Operation: events.BootstrapValidation,
-		Message:   "Verifying generation of kubeconfig file ...",
-	})
+	message := "Ephemeral cluster operation has completed successfully"
+	// kubeconfig is generated only when the cluster is created
+	if c.BootConf.EphemeralCluster.BootstrapCommand != ephemeral.BootCmdDelete {
+		evtCh <- events.NewEvent().WithBootstrapEvent(events.BootstrapEvent{
+			Operation: events.BootstrapValidation,
+			Message:   "Verifying generation of kubeconfig file ...",
+		})
 
-	err = bootstrapOpts.VerifyArtifacts()
-	if err != nil {
-		handleError(evtCh, err)
-		return
+		err = bootstrapOpts.VerifyArtifacts()
+		if err != nil {
+			handleError(evtCh, err)
+			return
+		}
+		message += " and artifacts verified"
+	}
+
+	if c.BootConf.EphemeralCluster.WriteKubeconfig {
+		if err = c.syncKubeconfig(evtCh); err != nil {
+			handleError(evtCh, err)
+			return
+		}
 	}
 
 	evtCh <- events.NewEvent().WithBootstrapEvent(events.BootstrapEvent{
 		Operation: events.BootstrapEnd,
-		Message:   "Ephemeral cluster operation has completed successfully and artifacts verified",
+		Message:   message,
 	})
 }
 
@@ -141,7 +185,150 @@ func (c *EphemeralExecutor) Render(w io.Writer, _ ifc.RenderOptions) error {
 	return nil
 }
 
-// Status returns the status of the given phase
+// Status returns the status of the given phase, it inspects the bootstrap container and makes sure
+// that API server of the ephemeral cluster is reachable with generated kubeconfig
 func (c *EphemeralExecutor) Status() (ifc.ExecutorStatus, error) {
-	return ifc.ExecutorStatus{}, errors.ErrNotImplemented{What: Ephemeral}
+	containerStatus, err := c.checkContainer()
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+
+	kubeconfigPath := c.generatedKubeconfig()
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfigPath)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	restConfig.Timeout = apiServerTimeout
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return ifc.ExecutorStatus{}, err
+	}
+	version, err := clientSet.Discovery().ServerVersion()
+	if err != nil {
+		return ifc.ExecutorStatus{}, executorerrors.ErrEphemeralAPIServerUnreachable{
+			Kubeconfig: kubeconfigPath,
+			Err:        err,
+		}
+	}
+	return ifc.ExecutorStatus{
+		Message: fmt.Sprintf("Bootstrap container %s is %s, ephemeral cluster API server %s is reachable, version %s",
+			c.containerName(), containerStatus, restConfig.Host, version.GitVersion),
+	}, nil
+}
+
+// checkContainer inspects the bootstrap container and returns its status, it's looked up by its name
+// if it isn't created by the executor, e.g. when the status is requested by another airshipctl process
+func (c *EphemeralExecutor) checkContainer() (container.Status, error) {
+	cont := c.Container
+	if cont == nil || cont.GetID() == "" {
+		var err error
+		if cont, err = c.getContainer(); err != nil {
+			return "", err
+		}
+	}
+	bootstrapOpts := ephemeral.BootstrapContainerOptions{
+		Container: cont,
+		Cfg:       c.BootConf,
+	}
+	status, err := bootstrapOpts.GetContainerStatus()
+	switch {
+	case container.IsErrNotFound(err):
+		// bootstrap container is removed once it's successfully finished
+		return bootstrapContainerRemoved, nil
+	case err != nil:
+		return "", err
+	}
+	return status, nil
+}
+
+// removeStaleContainer removes bootstrap container left by the previous run, so that the new one
+// can be created with the same name
+func (c *EphemeralExecutor) removeStaleContainer() {
+	cont, err := c.getContainer()
+	if err == nil {
+		err = cont.RmContainer()
+	}
+	switch {
+	case err == nil:
+		log.Printf("Removed bootstrap container %s left by the previous run", c.containerName())
+	case !container.IsErrNotFound(err):
+		log.Debugf("Failed to remove bootstrap container %s: %v", c.containerName(), err)
+	}
+}
+
+// getContainer looks up existing bootstrap container by its name
+func (c *EphemeralExecutor) getContainer() (container.Container, error) {
+	getContainer := c.GetContainerFunc
+	if getContainer == nil {
+		getContainer = container.GetContainer
+	}
+	return getContainer(context.Background(), c.BootConf.BootstrapContainer.ContainerRuntime, c.containerName())
+}
+
+// containerName returns name of the bootstrap container
+func (c *EphemeralExecutor) containerName() string {
+	name := c.BootConf.Name
+	if name == "" {
+		name = "ephemeral"
+	}
+	return bootstrapContainerPrefix + name
+}
+
+// generatedKubeconfig returns path to the kubeconfig generated by bootstrap container on the host
+func (c *EphemeralExecutor) generatedKubeconfig() string {
+	hostVol := strings.Split(c.BootConf.BootstrapContainer.Volume, ephemeral.BootVolumeSeparator)[0]
+	return filepath.Join(hostVol, c.BootConf.BootstrapContainer.Kubeconfig)
+}
+
+// syncKubeconfig writes generated kubeconfig to the filesystem kubeconfig source of the cluster
+// when ephemeral cluster is created and removes it when the cluster is deleted
+func (c *EphemeralExecutor) syncKubeconfig(evtCh chan events.Event) error {
+	dst, err := c.filesystemKubeconfigPath()
+	if err != nil {
+		return err
+	}
+
+	switch c.BootConf.EphemeralCluster.BootstrapCommand {
+	case ephemeral.BootCmdCreate:
+		var data []byte
+		data, err = ioutil.ReadFile(c.generatedKubeconfig())
+		if err != nil {
+			return err
+		}
+		if err = os.MkdirAll(filepath.Dir(dst), 0700); err != nil {
+			return err
+		}
+		if err = ioutil.WriteFile(dst, data, 0600); err != nil {
+			return err
+		}
+		evtCh <- events.NewEvent().WithBootstrapEvent(events.BootstrapEvent{
+			Operation: events.BootstrapKubeconfig,
+			Message:   "Kubeconfig of Ephemeral cluster is written to " + dst,
+		})
+	case ephemeral.BootCmdDelete:
+		if err = os.Remove(dst); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		evtCh <- events.NewEvent().WithBootstrapEvent(events.BootstrapEvent{
+			Operation: events.BootstrapKubeconfig,
+			Message:   "Kubeconfig of Ephemeral cluster is removed from " + dst,
+		})
+	}
+	return nil
+}
+
+func (c *EphemeralExecutor) filesystemKubeconfigPath() (string, error) {
+	if c.ClusterMap == nil {
+		return "", executorerrors.ErrNoFilesystemKubeconfigSource{ClusterName: c.ClusterName}
+	}
+	sources, err := c.ClusterMap.Sources(c.ClusterName)
+	if err != nil {
+		return "", err
+	}
+	for _, source := range sources {
+		if source.Type == v1alpha1.KubeconfigSourceTypeFilesystem && source.FileSystem.Path != "" {
+			return util.ExpandTilde(source.FileSystem.Path), nil
+		}
+	}
+	return "", executorerrors.ErrNoFilesystemKubeconfigSource{ClusterName: c.ClusterName}
 }
@@ -16,21 +16,31 @@ package executors_test
 
 import (
 	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/docker/docker/errdefs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
 	"opendev.org/airship/airshipctl/pkg/bootstrap/ephemeral"
+	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/container"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/errors"
 	"opendev.org/airship/airshipctl/pkg/events"
 	"opendev.org/airship/airshipctl/pkg/phase/executors"
+	executorerrors "opendev.org/airship/airshipctl/pkg/phase/executors/errors"
 	"opendev.org/airship/airshipctl/pkg/phase/ifc"
 	"opendev.org/airship/airshipctl/testutil"
 	testcontainer "opendev.org/airship/airshipctl/testutil/container"
@@ -174,6 +184,187 @@ func TestExecutorEphemeralRun(t *testing.T) {
 	}
 }
 
+func TestExecutorEphemeralWriteKubeconfig(t *testing.T) {
+	tempVol, cleanup := testutil.TempDir(t, "bootstrap-test")
+	defer cleanup(t)
+	require.NoError(t, testConfigFile(filepath.Join(tempVol, "dummy-config.yaml")))
+	kubeconfigData := []byte("apiVersion: v1\nkind: Config\n")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tempVol, "dummy.kubeconfig"), kubeconfigData, 0600))
+	dst := filepath.Join(tempVol, "site", "kubeconfig")
+
+	cMap := v1alpha1.DefaultClusterMap()
+	cMap.Map["ephemeral-cluster"] = &v1alpha1.Cluster{
+		Sources: []v1alpha1.KubeconfigSource{
+			{Type: v1alpha1.KubeconfigSourceTypeBundle},
+			{
+				Type:       v1alpha1.KubeconfigSourceTypeFilesystem,
+				FileSystem: v1alpha1.KubeconfigSourceFilesystem{Path: dst},
+			},
+		},
+	}
+	cMap.Map["target-cluster"] = &v1alpha1.Cluster{}
+
+	mockContainer := &testcontainer.MockContainer{
+		MockRunCommand:  func() error { return nil },
+		MockRmContainer: func() error { return nil },
+		MockInspectContainer: func() (container.State, error) {
+			return container.State{Status: "exited"}, nil
+		},
+	}
+	run := func(command, clusterName string) []events.Event {
+		executor := &executors.EphemeralExecutor{
+			BootConf: &v1alpha1.BootConfiguration{
+				BootstrapContainer: v1alpha1.BootstrapContainer{
+					Volume:           tempVol + ":/dst",
+					ContainerRuntime: "docker",
+					Image:            "quay.io/sshiba/capz-bootstrap:latest",
+					Kubeconfig:       "dummy.kubeconfig",
+				},
+				EphemeralCluster: v1alpha1.EphemeralCluster{
+					BootstrapCommand: command,
+					ConfigFilename:   "dummy-config.yaml",
+					WriteKubeconfig:  true,
+				},
+			},
+			Container:   mockContainer,
+			ClusterName: clusterName,
+			ClusterMap:  clustermap.NewClusterMap(cMap),
+		}
+		ch := make(chan events.Event)
+		go executor.Run(ch, ifc.RunOptions{})
+		var actualEvt []events.Event
+		for evt := range ch {
+			actualEvt = append(actualEvt, evt)
+		}
+		return actualEvt
+	}
+
+	evts := run(ephemeral.BootCmdCreate, "ephemeral-cluster")
+	require.Len(t, evts, 6)
+	assert.Equal(t, events.BootstrapKubeconfig, evts[4].BootstrapEvent.Operation)
+	data, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, kubeconfigData, data)
+
+	// kubeconfig is not verified when the cluster is deleted
+	evts = run(ephemeral.BootCmdDelete, "ephemeral-cluster")
+	require.Len(t, evts, 5)
+	assert.Equal(t, events.BootstrapRun, evts[2].BootstrapEvent.Operation)
+	assert.Equal(t, events.BootstrapKubeconfig, evts[3].BootstrapEvent.Operation)
+	_, err = os.Stat(dst)
+	assert.True(t, os.IsNotExist(err))
+
+	evts = run(ephemeral.BootCmdCreate, "target-cluster")
+	assert.Equal(t, executorerrors.ErrNoFilesystemKubeconfigSource{ClusterName: "target-cluster"},
+		evts[len(evts)-1].ErrorEvent.Error)
+}
+
+func TestEphemeralStatus(t *testing.T) {
+	tempVol, cleanup := testutil.TempDir(t, "bootstrap-test")
+	defer cleanup(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"major": "1", "minor": "19", "gitVersion": "v1.19.1"}`)
+	}))
+	defer server.Close()
+	kubeconfig := fmt.Sprintf(`apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: %s
+  name: ephemeral
+contexts:
+- context:
+    cluster: ephemeral
+    user: admin
+  name: ephemeral
+current-context: ephemeral
+users:
+- name: admin
+  user:
+    token: token
+`, server.URL)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(tempVol, "dummy.kubeconfig"), []byte(kubeconfig), 0600))
+
+	containerErr := ephemeral.ErrBootstrapContainerRun{
+		ExitCode: 5,
+		ErrMsg:   ephemeral.ContainerCreationEphemeralFailedError,
+	}
+	notFound := &testcontainer.MockContainer{
+		MockInspectContainer: func() (container.State, error) {
+			return container.State{}, errdefs.NotFound(fmt.Errorf("no such container"))
+		},
+	}
+	testCases := []struct {
+		name            string
+		container       *testcontainer.MockContainer
+		volume          string
+		expectedErr     error
+		expectedMessage string
+	}{
+		{
+			name:      "API server is reachable",
+			volume:    tempVol + ":/dst",
+			container: notFound,
+			expectedMessage: "Bootstrap container airshipctl-ephemeral-az-genesis is removed, " +
+				"ephemeral cluster API server " + server.URL + " is reachable, version v1.19.1",
+		},
+		{
+			name:   "Bootstrap container failed",
+			volume: tempVol + ":/dst",
+			container: &testcontainer.MockContainer{
+				MockGetID: func() string { return "bootstrap" },
+				MockInspectContainer: func() (container.State, error) {
+					return container.State{Status: "exited", ExitCode: 5}, nil
+				},
+				MockGetContainerLogs: func() (io.ReadCloser, error) { return nil, nil },
+			},
+			expectedErr: containerErr,
+		},
+	}
+	for _, test := range testCases {
+		tt := test
+		t.Run(tt.name, func(t *testing.T) {
+			var containerName string
+			executor := &executors.EphemeralExecutor{
+				BootConf: &v1alpha1.BootConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "ephemeral-az-genesis"},
+					BootstrapContainer: v1alpha1.BootstrapContainer{
+						ContainerRuntime: "docker",
+						Volume:           tt.volume,
+						Kubeconfig:       "dummy.kubeconfig",
+					},
+				},
+				// bootstrap container is looked up by its name, since it's created by another executor
+				GetContainerFunc: func(_ context.Context, _ string, name string) (container.Container, error) {
+					containerName = name
+					return tt.container, nil
+				},
+			}
+			status, err := executor.Status()
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedMessage, status.Message)
+			assert.Equal(t, "airshipctl-ephemeral-az-genesis", containerName)
+		})
+	}
+
+	server.Close()
+	executor := &executors.EphemeralExecutor{
+		BootConf: &v1alpha1.BootConfiguration{
+			BootstrapContainer: v1alpha1.BootstrapContainer{
+				Volume:     tempVol,
+				Kubeconfig: "dummy.kubeconfig",
+			},
+		},
+		GetContainerFunc: func(context.Context, string, string) (container.Container, error) {
+			return notFound, nil
+		},
+	}
+	_, err := executor.Status()
+	assert.IsType(t, executorerrors.ErrEphemeralAPIServerUnreachable{}, err)
+}
+
 func testConfigFile(path string) error {
 	_, err := os.Create(path)
 	return err
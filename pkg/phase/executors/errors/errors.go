@@ -91,3 +91,64 @@ type ErrExecutorRegistration struct {
 func (e ErrExecutorRegistration) Error() string {
 	return fmt.Sprintf("failed to register executor %s, registration function returned %s", e.ExecutorName, e.Err.Error())
 }
+
+// ErrEnvFromUnsupportedKind is returned when envFrom of generic container references
+// a document which is neither Secret nor ConfigMap
+type ErrEnvFromUnsupportedKind struct {
+	Kind string
+}
+
+func (e ErrEnvFromUnsupportedKind) Error() string {
+	return fmt.Sprintf("envFrom supports Secret and ConfigMap documents only, got '%s'", e.Kind)
+}
+
+// ErrEnvFromKeyNotFound is returned when the key referenced by envFrom is missing
+// in the data of the document
+type ErrEnvFromKeyNotFound struct {
+	Kind string
+	Name string
+	Key  string
+}
+
+func (e ErrEnvFromKeyNotFound) Error() string {
+	return fmt.Sprintf("key '%s' is not found in %s '%s'", e.Key, e.Kind, e.Name)
+}
+
+// ErrEnvFromNotDecrypted is returned when decrypter GenericContainer doesn't return
+// decrypted document referenced by envFrom
+type ErrEnvFromNotDecrypted struct {
+	Kind string
+	Name string
+}
+
+func (e ErrEnvFromNotDecrypted) Error() string {
+	return fmt.Sprintf("%s '%s' referenced by envFrom is not decrypted, make sure decrypter "+
+		"GenericContainer is configured properly", e.Kind, e.Name)
+}
+
+// ErrEnvFromInvalidName is returned when envFrom value is injected with the name
+// that can't be used as a file name
+type ErrEnvFromInvalidName struct {
+	Name string
+}
+
+func (e ErrEnvFromInvalidName) Error() string {
+	return fmt.Sprintf("invalid envFrom name '%s', only alphanumeric characters, '-', '_' and '.' are allowed",
+		e.Name)
+}
+
+// ErrEnvFromKRMFunction is returned when envFrom is used with KRM function
+type ErrEnvFromKRMFunction struct{}
+
+func (e ErrEnvFromKRMFunction) Error() string {
+	return "envFrom is supported for airship type containers only"
+}
+
+// ErrEnvFromDecrypterType is returned when decrypter of envFrom documents is not a KRM function
+type ErrEnvFromDecrypterType struct {
+	Name string
+}
+
+func (e ErrEnvFromDecrypterType) Error() string {
+	return fmt.Sprintf("decrypter GenericContainer '%s' must be of krm type", e.Name)
+}
@@ -0,0 +1,61 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package executors
+
+import (
+	"context"
+
+	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/container"
+	"opendev.org/airship/airshipctl/pkg/document"
+)
+
+// CheckImages makes sure that container images of the executor document are available according to their
+// pull policy. Images are read from the executor document, so that phase documents are not rendered before
+// the phase is run, documents of executors that don't run containers are ignored
+func CheckImages(executorDoc document.Document) error {
+	switch {
+	case isKind(executorDoc, airshipv1.DefaultGenericContainer()):
+		apiObj := airshipv1.DefaultGenericContainer()
+		if err := executorDoc.ToAPIObject(apiObj, airshipv1.Scheme); err != nil {
+			return err
+		}
+		return checkContainerImage(apiObj)
+	case isKind(executorDoc, airshipv1.DefaultBootConfiguration()):
+		apiObj := airshipv1.DefaultBootConfiguration()
+		if err := executorDoc.ToAPIObject(apiObj, airshipv1.Scheme); err != nil {
+			return err
+		}
+		return container.CheckImage(
+			context.Background(),
+			apiObj.BootstrapContainer.ContainerRuntime,
+			apiObj.BootstrapContainer.Image,
+			apiObj.BootstrapContainer.ImagePullPolicy)
+	default:
+		return nil
+	}
+}
+
+// checkContainerImage checks image of airship type container, images of KRM functions are managed by kyaml
+func checkContainerImage(apiObj *airshipv1.GenericContainer) error {
+	if apiObj.Spec.Type == airshipv1.GenericContainerTypeKrm {
+		return nil
+	}
+	driver := apiObj.Spec.Airship.ContainerRuntime
+	if driver == "" {
+		driver = container.ContainerDriverDocker
+	}
+	return container.CheckImage(context.Background(), driver, apiObj.Spec.Image, apiObj.Spec.ImagePullPolicy)
+}
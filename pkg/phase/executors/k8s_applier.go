@@ -15,21 +15,28 @@
 package executors
 
 import (
+	"fmt"
 	"io"
 	"time"
 
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/aggregator"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/provider"
+	crclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	airshipv1 "opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/cluster"
 	"opendev.org/airship/airshipctl/pkg/cluster/clustermap"
 	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/events"
 	k8sapplier "opendev.org/airship/airshipctl/pkg/k8s/applier"
+	"opendev.org/airship/airshipctl/pkg/k8s/client"
 	"opendev.org/airship/airshipctl/pkg/k8s/kubeconfig"
+	"opendev.org/airship/airshipctl/pkg/k8s/poller"
 	"opendev.org/airship/airshipctl/pkg/k8s/utils"
 	"opendev.org/airship/airshipctl/pkg/log"
 	"opendev.org/airship/airshipctl/pkg/phase/errors"
@@ -85,6 +92,14 @@ func (e *KubeApplierExecutor) Run(ch chan events.Event, runOpts ifc.RunOptions)
 	dryRunStrategy := common.DryRunNone
 	if runOpts.DryRun {
 		dryRunStrategy = common.DryRunClient
+	} else {
+		log.Debug("Setting up status poller aware of status checks")
+		applier.Poller, err = e.statusPoller(applier.Factory)
+		if err != nil {
+			handleError(ch, err)
+			close(ch)
+			return
+		}
 	}
 	timeout := time.Second * time.Duration(e.apiObject.Config.WaitOptions.Timeout)
 	if int64(runOpts.Timeout/time.Second) != 0 {
@@ -92,15 +107,49 @@ func (e *KubeApplierExecutor) Run(ch chan events.Event, runOpts ifc.RunOptions)
 	}
 
 	log.Debugf("WaitTimeout: %v", timeout)
+	waveTimeouts := map[int]time.Duration{}
+	for _, w := range e.apiObject.Config.WaitOptions.Waves {
+		waveTimeouts[w.Wave] = time.Second * time.Duration(w.Timeout)
+	}
 	applyOptions := k8sapplier.ApplyOptions{
 		DryRunStrategy: dryRunStrategy,
 		Prune:          e.apiObject.Config.PruneOptions.Prune,
-		BundleName:     e.BundleName,
+		MaxPruneCount:  e.apiObject.Config.PruneOptions.MaxPruneCount,
+		BundleName:     e.inventoryID(),
 		WaitTimeout:    timeout,
+
+		InventoryName:      e.apiObject.Config.InventoryOptions.Name,
+		InventoryNamespace: e.apiObject.Config.InventoryOptions.Namespace,
+
+		ServerSideApply: e.apiObject.Config.ServerSideOptions.ServerSideApply,
+		FieldManager:    e.apiObject.Config.ServerSideOptions.FieldManager,
+		ForceConflicts:  e.apiObject.Config.ServerSideOptions.ForceConflicts,
+		WaveTimeouts:    waveTimeouts,
 	}
 	applier.ApplyBundle(filteredBundle, applyOptions)
 }
 
+// inventoryID returns ID of the inventory object according to inventory ID strategy
+func (e *KubeApplierExecutor) inventoryID() string {
+	invOpts := e.apiObject.Config.InventoryOptions
+	switch invOpts.IDStrategy {
+	case airshipv1.InventoryIDStrategyStatic:
+		return invOpts.ID
+	case airshipv1.InventoryIDStrategyNamespacedName:
+		namespace := invOpts.Namespace
+		if namespace == "" {
+			namespace = k8sapplier.DefaultNamespace
+		}
+		name := invOpts.Name
+		if name == "" {
+			name = e.BundleName
+		}
+		return fmt.Sprintf("%s-%s", namespace, name)
+	default:
+		return e.BundleName
+	}
+}
+
 func (e *KubeApplierExecutor) prepareApplier(ch chan events.Event) (*k8sapplier.Applier, document.Bundle, error) {
 	log.Debug("Getting kubeconfig context name from cluster map")
 	context, err := e.clusterMap.ClusterKubeconfigContext(e.clusterName)
@@ -125,11 +174,73 @@ func (e *KubeApplierExecutor) prepareApplier(ch chan events.Event) (*k8sapplier.
 	return k8sapplier.NewApplier(ch, factory), bundle, nil
 }
 
+// statusPoller returns poller which computes status of the resources according to status checks
+// from airshipit.org/status-check annotations of CRDs and health checks of the executor document,
+// generic kstatus rules are used for the rest of the resources
+func (e *KubeApplierExecutor) statusPoller(f cmdutil.Factory) (*poller.StatusPoller, error) {
+	restConfig, err := f.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	restMapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	kclient, err := client.NewClientFromFactory(f)
+	if err != nil {
+		return nil, err
+	}
+	statusMap, err := cluster.NewStatusMap(kclient)
+	if err != nil {
+		return nil, err
+	}
+	for _, hc := range e.apiObject.Config.WaitOptions.HealthChecks {
+		mapping, mapErr := restMapper.RESTMapping(schema.GroupKind{Group: hc.Group, Kind: hc.Kind}, hc.Version)
+		if mapErr != nil {
+			return nil, mapErr
+		}
+		statusChecks := make(map[status.Status]cluster.Expression, len(hc.StatusChecks))
+		for _, sc := range hc.StatusChecks {
+			statusChecks[status.Status(sc.Status)] = cluster.Expression{Condition: sc.Condition}
+		}
+		log.Debugf("Using custom status checks for %s", mapping.GroupVersionKind)
+		statusMap.AddStatusChecks(mapping, statusChecks)
+	}
+	reader, err := crclient.New(restConfig, crclient.Options{Mapper: restMapper})
+	if err != nil {
+		return nil, err
+	}
+	return poller.NewStatusPoller(reader, restMapper, statusMap), nil
+}
+
 // Validate document set
 func (e *KubeApplierExecutor) Validate() error {
 	if e.BundleName == "" {
 		return errors.ErrInvalidPhase{Reason: "k8s applier BundleName is empty"}
 	}
+	invOpts := e.apiObject.Config.InventoryOptions
+	switch invOpts.IDStrategy {
+	case "", airshipv1.InventoryIDStrategyPhase, airshipv1.InventoryIDStrategyNamespacedName:
+	case airshipv1.InventoryIDStrategyStatic:
+		if invOpts.ID == "" {
+			return errors.ErrInvalidPhase{Reason: "k8s applier inventory ID must be set for static ID strategy"}
+		}
+	default:
+		return errors.ErrInvalidPhase{
+			Reason: fmt.Sprintf("k8s applier inventory ID strategy %q is not supported", invOpts.IDStrategy),
+		}
+	}
+	if e.apiObject.Config.PruneOptions.MaxPruneCount < 0 {
+		return errors.ErrInvalidPhase{Reason: "k8s applier maxPruneCount must not be negative"}
+	}
+	for _, hc := range e.apiObject.Config.WaitOptions.HealthChecks {
+		if err := validateHealthCheck(hc); err != nil {
+			return err
+		}
+	}
+	if e.apiObject.Config.ServerSideOptions.ServerSideApply && e.apiObject.Config.PruneOptions.Prune {
+		return errors.ErrInvalidPhase{Reason: "k8s applier prune is not supported with server-side apply"}
+	}
 	docs, err := e.ExecutorBundle.GetAllDocuments()
 	if err != nil {
 		return err
@@ -141,6 +252,25 @@ func (e *KubeApplierExecutor) Validate() error {
 	return nil
 }
 
+func validateHealthCheck(hc airshipv1.ApplyHealthCheck) error {
+	if hc.Kind == "" || hc.Version == "" {
+		return errors.ErrInvalidPhase{Reason: "k8s applier health check must define version and kind"}
+	}
+	if len(hc.StatusChecks) == 0 {
+		return errors.ErrInvalidPhase{
+			Reason: fmt.Sprintf("k8s applier health check for kind %s has no status checks", hc.Kind),
+		}
+	}
+	for _, sc := range hc.StatusChecks {
+		if sc.Status == "" || sc.Condition == "" {
+			return errors.ErrInvalidPhase{
+				Reason: fmt.Sprintf("k8s applier health check for kind %s must define status and condition", hc.Kind),
+			}
+		}
+	}
+	return nil
+}
+
 // Render document set
 func (e *KubeApplierExecutor) Render(w io.Writer, o ifc.RenderOptions) error {
 	bundle, err := e.ExecutorBundle.SelectBundle(o.FilterSelector)
@@ -58,6 +58,46 @@ config:
     timeout: 600
   pruneOptions:
     prune: false
+`
+	ServerSideExecutorDocWithPrune = `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  labels:
+    airshipit.org/deploy-k8s: "false"
+  name: kubernetes-apply-server-side
+config:
+  pruneOptions:
+    prune: true
+  serverSideOptions:
+    serverSideApply: true
+    fieldManager: airshipctl
+`
+	StaticInventoryExecutorDocWithoutID = `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  labels:
+    airshipit.org/deploy-k8s: "false"
+  name: kubernetes-apply-static-inventory
+config:
+  inventoryOptions:
+    namespace: target-infra
+    idStrategy: static
+`
+	HealthCheckExecutorDocWithoutCondition = `apiVersion: airshipit.org/v1alpha1
+kind: KubernetesApply
+metadata:
+  labels:
+    airshipit.org/deploy-k8s: "false"
+  name: kubernetes-apply-health-check
+config:
+  waitOptions:
+    timeout: 600
+    healthChecks:
+      - group: helm.toolkit.fluxcd.io
+        version: v2beta1
+        kind: HelmRelease
+        statusChecks:
+          - status: Current
 `
 	testValidKubeconfig = `apiVersion: v1
 clusters:
@@ -250,6 +290,7 @@ func TestKubeApplierExecutor_Validate(t *testing.T) {
 	type fields struct {
 		BundleName string
 		path       string
+		execDoc    string
 	}
 	tests := []struct {
 		name    string
@@ -270,6 +311,30 @@ func TestKubeApplierExecutor_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Error prune with server-side apply",
+			fields: fields{BundleName: "some name",
+				path:    "../../k8s/applier/testdata/source_bundle",
+				execDoc: ServerSideExecutorDocWithPrune,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error static inventory ID is empty",
+			fields: fields{BundleName: "some name",
+				path:    "../../k8s/applier/testdata/source_bundle",
+				execDoc: StaticInventoryExecutorDocWithoutID,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Error health check without conditions",
+			fields: fields{BundleName: "some name",
+				path:    "../../k8s/applier/testdata/source_bundle",
+				execDoc: HealthCheckExecutorDocWithoutCondition,
+			},
+			wantErr: true,
+		},
 		{
 			name: "Success case",
 			fields: fields{BundleName: "some name",
@@ -281,7 +346,11 @@ func TestKubeApplierExecutor_Validate(t *testing.T) {
 	for _, test := range tests {
 		tt := test
 		t.Run(tt.name, func(t *testing.T) {
-			execDoc, err := document.NewDocumentFromBytes([]byte(ValidExecutorDoc))
+			doc := tt.fields.execDoc
+			if doc == "" {
+				doc = ValidExecutorDoc
+			}
+			execDoc, err := document.NewDocumentFromBytes([]byte(doc))
 			require.NoError(t, err)
 			require.NotNil(t, execDoc)
 
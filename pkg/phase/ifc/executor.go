@@ -34,7 +34,10 @@ type Executor interface {
 }
 
 // ExecutorStatus is a struct which defines the status
-type ExecutorStatus struct{}
+type ExecutorStatus struct {
+	// Message describes the status computed by the executor
+	Message string
+}
 
 // RunOptions holds options for run method
 type RunOptions struct {
@@ -42,6 +45,13 @@ type RunOptions struct {
 	Progress bool
 
 	Timeout time.Duration
+
+	// Inputs is a document with outputs of the previous phases of the plan consumed by the phase,
+	// it's added to the executor bundle
+	Inputs document.Document
+	// Env holds env variables in KEY=VALUE format set to the outputs of the previous phases of the plan
+	// consumed by the phase, generic container executor adds them to the container env
+	Env []string
 }
 
 // RenderOptions holds options for render method
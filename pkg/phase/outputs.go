@@ -0,0 +1,180 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/api/v1alpha1"
+	"opendev.org/airship/airshipctl/pkg/document"
+	"opendev.org/airship/airshipctl/pkg/phase/errors"
+)
+
+const (
+	// PhaseInputsDocumentSuffix is appended to the phase name to form the name of the ConfigMap
+	// document with the phase inputs, the document is added to the executor bundle
+	PhaseInputsDocumentSuffix = "-inputs"
+)
+
+// phaseOutputs resolves outputs declared by the phase, documents are selected from the documents
+// produced by the phase in results directory and files are read relative to site root
+func phaseOutputs(phaseObj *v1alpha1.Phase, resultsDir string, siteRoot string) (map[string]string, error) {
+	if len(phaseObj.Config.Outputs) == 0 {
+		return nil, nil
+	}
+
+	var bundle document.Bundle
+	outputs := make(map[string]string, len(phaseObj.Config.Outputs))
+	for _, out := range phaseObj.Config.Outputs {
+		invalidOutput := func(reason string) error {
+			return errors.ErrInvalidPhaseOutput{PhaseName: phaseObj.Name, Output: out.Name, Reason: reason}
+		}
+		switch {
+		case out.Document != nil && out.File != "":
+			return nil, invalidOutput("only one of document and file must be set")
+		case out.File != "":
+			data, err := ioutil.ReadFile(filepath.Join(siteRoot, out.File))
+			if err != nil {
+				return nil, invalidOutput(err.Error())
+			}
+			outputs[out.Name] = strings.TrimRight(string(data), "\r\n")
+		case out.Document != nil:
+			if bundle == nil {
+				var err error
+				if bundle, err = resultsBundle(resultsDir); err != nil {
+					return nil, invalidOutput(err.Error())
+				}
+			}
+			val, err := documentOutput(bundle, out.Document)
+			if err != nil {
+				return nil, invalidOutput(err.Error())
+			}
+			outputs[out.Name] = val
+		default:
+			return nil, invalidOutput("one of document and file must be set")
+		}
+	}
+	return outputs, nil
+}
+
+// checkDocumentOutputs makes sure that the phase declaring document outputs produces documents
+func checkDocumentOutputs(phaseObj *v1alpha1.Phase, resultsDir string) error {
+	if resultsDir != "" {
+		return nil
+	}
+	for _, out := range phaseObj.Config.Outputs {
+		if out.Document != nil {
+			return errors.ErrInvalidPhaseOutput{
+				PhaseName: phaseObj.Name,
+				Output:    out.Name,
+				Reason:    "phase executor doesn't produce documents, only GenericContainer with sinkOutputDir does",
+			}
+		}
+	}
+	return nil
+}
+
+// resultsBundle reads the documents written by the phase executor to results directory
+func resultsBundle(resultsDir string) (document.Bundle, error) {
+	buf := &bytes.Buffer{}
+	err := kio.Pipeline{
+		Inputs:  []kio.Reader{kio.LocalPackageReader{PackagePath: resultsDir}},
+		Outputs: []kio.Writer{kio.ByteWriter{Writer: buf}},
+	}.Execute()
+	if err != nil {
+		return nil, err
+	}
+	return document.NewBundleFromBytes(buf.Bytes())
+}
+
+func documentOutput(bundle document.Bundle, out *v1alpha1.PhaseOutputDocument) (string, error) {
+	doc, err := bundle.SelectOne(document.Selector{Selector: out.Selector})
+	if err != nil {
+		return "", err
+	}
+	obj := map[string]interface{}{}
+	if err = doc.ToObject(&obj); err != nil {
+		return "", err
+	}
+
+	path := out.JSONPath
+	if !strings.HasPrefix(path, "{") {
+		path = fmt.Sprintf("{%s}", path)
+	}
+	jp := jsonpath.New("output")
+	if err = jp.Parse(path); err != nil {
+		return "", err
+	}
+	buf := &bytes.Buffer{}
+	if err = jp.Execute(buf, obj); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// phaseInputs returns env variables in KEY=VALUE format and ConfigMap document holding the values of the outputs
+// of the previous phases consumed by the phase, the document is nil if no document keys are requested
+func phaseInputs(phaseObj *v1alpha1.Phase, state *PlanRunState) ([]string, document.Document, error) {
+	var envs []string
+	data := map[string]string{}
+	for _, in := range phaseObj.Config.Inputs {
+		val, ok := state.output(in.Phase, in.Output)
+		if !ok {
+			return nil, nil, errors.ErrPhaseOutputNotFound{
+				PhaseName:       phaseObj.Name,
+				SourcePhaseName: in.Phase,
+				Output:          in.Output,
+			}
+		}
+		if in.EnvVar != "" {
+			envs = append(envs, in.EnvVar+"="+val)
+		}
+		if in.DocumentKey != "" {
+			data[in.DocumentKey] = val
+		}
+	}
+	if len(data) == 0 {
+		return envs, nil, nil
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      phaseObj.Name + PhaseInputsDocumentSuffix,
+			Namespace: phaseObj.Namespace,
+			Labels:    map[string]string{"airshipit.org/deploy-k8s": "false"},
+		},
+		Data: data,
+	}
+	b, err := yaml.Marshal(cm)
+	if err != nil {
+		return nil, nil, err
+	}
+	doc, err := document.NewDocumentFromBytes(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return envs, doc, nil
+}
@@ -0,0 +1,96 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package phase
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"opendev.org/airship/airshipctl/pkg/phase/ifc"
+)
+
+const (
+	// PhaseSucceeded is a status of the phase which is finished successfully
+	PhaseSucceeded = "Succeeded"
+	// PhaseFailed is a status of the phase which is finished with error
+	PhaseFailed = "Failed"
+
+	planStateDir  = "plans"
+	planStateFile = "state.yaml"
+)
+
+// PlanRunState holds results of the last run of the plan, it is stored in airshipctl
+// working directory, so that phase outputs are available after the plan is finished
+type PlanRunState struct {
+	Plan      string          `json:"plan"`
+	Namespace string          `json:"namespace,omitempty"`
+	StartedAt time.Time       `json:"startedAt"`
+	Phases    []PhaseRunState `json:"phases,omitempty"`
+}
+
+// PhaseRunState holds status and outputs of the phase of the plan run
+type PhaseRunState struct {
+	Name    string            `json:"name"`
+	Status  string            `json:"status"`
+	Outputs map[string]string `json:"outputs,omitempty"`
+}
+
+// PlanRunStatePath returns path to the file with the state of the last run of the plan
+func PlanRunStatePath(workDir string, planID ifc.ID) string {
+	name := planID.Name
+	if planID.Namespace != "" {
+		name = planID.Namespace + "_" + name
+	}
+	return filepath.Join(workDir, planStateDir, name, planStateFile)
+}
+
+// ReadPlanRunState reads the state of the plan run from the file
+func ReadPlanRunState(path string) (*PlanRunState, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &PlanRunState{}
+	return state, yaml.Unmarshal(data, state)
+}
+
+// Save writes the state to the file, the file is readable by the owner only since
+// phase outputs may contain sensitive data
+func (s *PlanRunState) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// output returns the value of the output of the phase if the phase is finished successfully
+func (s *PlanRunState) output(phaseName, output string) (string, bool) {
+	for _, phase := range s.Phases {
+		if phase.Name != phaseName || phase.Status != PhaseSucceeded {
+			continue
+		}
+		val, ok := phase.Outputs[output]
+		return val, ok
+	}
+	return "", false
+}
@@ -0,0 +1,63 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ifc
+
+import (
+	"context"
+)
+
+// CredentialsManager is implemented by clients that are able to change password of the BMC account
+type CredentialsManager interface {
+	// UpdatePassword changes password of the BMC account the client authenticates with,
+	// the client uses the new password for all subsequent requests
+	UpdatePassword(ctx context.Context, newPassword string) error
+	// VerifyCredentials makes sure that the BMC accepts given credentials
+	VerifyCredentials(ctx context.Context, username, password string) error
+}
+
+// PasswordLengthLimiter is implemented by clients that are able to authenticate only with BMC passwords
+// of limited length
+type PasswordLengthLimiter interface {
+	// MaxPasswordLength returns the maximum length of BMC password
+	MaxPasswordLength() int
+}
+
+// UpdatePassword changes password of the BMC account of the host if the client supports it
+func UpdatePassword(ctx context.Context, c Client, newPassword string) error {
+	manager, ok := c.(CredentialsManager)
+	if !ok {
+		return ErrCredentialsManagementNotSupported{NodeID: c.NodeID()}
+	}
+	return manager.UpdatePassword(ctx, newPassword)
+}
+
+// VerifyCredentials makes sure that the BMC of the host accepts given credentials if the client supports it
+func VerifyCredentials(ctx context.Context, c Client, username, password string) error {
+	manager, ok := c.(CredentialsManager)
+	if !ok {
+		return ErrCredentialsManagementNotSupported{NodeID: c.NodeID()}
+	}
+	return manager.VerifyCredentials(ctx, username, password)
+}
+
+// MaxPasswordLength returns the maximum length of BMC password the client is able to authenticate with,
+// zero means that the length is not limited
+func MaxPasswordLength(c Client) int {
+	limiter, ok := c.(PasswordLengthLimiter)
+	if !ok {
+		return 0
+	}
+	return limiter.MaxPasswordLength()
+}
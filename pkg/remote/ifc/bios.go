@@ -0,0 +1,46 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ifc
+
+import (
+	"context"
+)
+
+// BIOSManager is implemented by clients that are able to read and change BIOS settings of the host
+type BIOSManager interface {
+	// BIOSAttributes returns current BIOS attributes of the host
+	BIOSAttributes(context.Context) (map[string]interface{}, error)
+	// SetBIOSAttributes schedules BIOS attributes to be changed, new values are applied
+	// by the host firmware on the next reboot
+	SetBIOSAttributes(context.Context, map[string]interface{}) error
+}
+
+// GetBIOSAttributes returns current BIOS attributes of the host if the client supports it
+func GetBIOSAttributes(ctx context.Context, c Client) (map[string]interface{}, error) {
+	manager, ok := c.(BIOSManager)
+	if !ok {
+		return nil, ErrBIOSSettingsNotSupported{NodeID: c.NodeID()}
+	}
+	return manager.BIOSAttributes(ctx)
+}
+
+// SetBIOSAttributes schedules BIOS attributes of the host to be changed if the client supports it
+func SetBIOSAttributes(ctx context.Context, c Client, attributes map[string]interface{}) error {
+	manager, ok := c.(BIOSManager)
+	if !ok {
+		return ErrBIOSSettingsNotSupported{NodeID: c.NodeID()}
+	}
+	return manager.SetBIOSAttributes(ctx, attributes)
+}
@@ -0,0 +1,67 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ifc
+
+import (
+	"fmt"
+)
+
+// ErrHardwareInspectionNotSupported is returned if remote client is not able to collect hardware inventory
+type ErrHardwareInspectionNotSupported struct {
+	NodeID string
+}
+
+func (e ErrHardwareInspectionNotSupported) Error() string {
+	return fmt.Sprintf("hardware inventory is not supported by the remote driver of host with node id '%s'", e.NodeID)
+}
+
+// ErrBIOSSettingsNotSupported is returned if remote client is not able to manage BIOS settings
+type ErrBIOSSettingsNotSupported struct {
+	NodeID string
+}
+
+func (e ErrBIOSSettingsNotSupported) Error() string {
+	return fmt.Sprintf("BIOS settings are not supported by the remote driver of host with node id '%s'", e.NodeID)
+}
+
+// ErrFirmwareManagementNotSupported is returned if remote client is not able to list or update firmware
+type ErrFirmwareManagementNotSupported struct {
+	NodeID string
+}
+
+func (e ErrFirmwareManagementNotSupported) Error() string {
+	return fmt.Sprintf("firmware management is not supported by the remote driver of host with node id '%s'", e.NodeID)
+}
+
+// ErrTLSConfigurationNotSupported is returned if remote client doesn't allow to configure trusted certificate
+// authorities
+type ErrTLSConfigurationNotSupported struct {
+	NodeID string
+}
+
+func (e ErrTLSConfigurationNotSupported) Error() string {
+	return fmt.Sprintf("custom certificate authorities are not supported by the remote driver of host with node id '%s'",
+		e.NodeID)
+}
+
+// ErrCredentialsManagementNotSupported is returned if remote client is not able to change BMC credentials
+type ErrCredentialsManagementNotSupported struct {
+	NodeID string
+}
+
+func (e ErrCredentialsManagementNotSupported) Error() string {
+	return fmt.Sprintf("credentials management is not supported by the remote driver of host with node id '%s'",
+		e.NodeID)
+}
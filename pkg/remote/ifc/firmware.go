@@ -0,0 +1,55 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ifc
+
+import (
+	"context"
+)
+
+// FirmwareComponent describes firmware of the single component of the host, e.g. BMC, BIOS or NIC
+type FirmwareComponent struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Updateable is false if the component firmware can't be updated by the BMC
+	Updateable bool `json:"updateable"`
+}
+
+// FirmwareManager is implemented by clients that are able to list and update firmware of the host
+type FirmwareManager interface {
+	// FirmwareInventory returns firmware components installed on the host
+	FirmwareInventory(context.Context) ([]FirmwareComponent, error)
+	// UpdateFirmware makes the BMC download firmware image from the URL and install it,
+	// it waits until the update is finished
+	UpdateFirmware(ctx context.Context, imageURL string) error
+}
+
+// GetFirmwareInventory returns firmware components installed on the host if the client supports it
+func GetFirmwareInventory(ctx context.Context, c Client) ([]FirmwareComponent, error) {
+	manager, ok := c.(FirmwareManager)
+	if !ok {
+		return nil, ErrFirmwareManagementNotSupported{NodeID: c.NodeID()}
+	}
+	return manager.FirmwareInventory(ctx)
+}
+
+// UpdateFirmware installs firmware image from the URL on the host if the client supports it
+func UpdateFirmware(ctx context.Context, c Client, imageURL string) error {
+	manager, ok := c.(FirmwareManager)
+	if !ok {
+		return ErrFirmwareManagementNotSupported{NodeID: c.NodeID()}
+	}
+	return manager.UpdateFirmware(ctx, imageURL)
+}
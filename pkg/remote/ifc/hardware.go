@@ -0,0 +1,93 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ifc
+
+import (
+	"context"
+)
+
+// HardwareInspector is implemented by clients that are able to collect hardware inventory of the host from the BMC
+type HardwareInspector interface {
+	HardwareDetails(context.Context) (*HardwareDetails, error)
+}
+
+// GetHardwareDetails collects hardware inventory of the host if the client supports it
+func GetHardwareDetails(ctx context.Context, c Client) (*HardwareDetails, error) {
+	inspector, ok := c.(HardwareInspector)
+	if !ok {
+		return nil, ErrHardwareInspectionNotSupported{NodeID: c.NodeID()}
+	}
+	return inspector.HardwareDetails(ctx)
+}
+
+// HardwareDetails is normalized hardware inventory of the host, its layout follows the hardware
+// details reported by metal3 BareMetalHost status so that it can be used as a BMH annotation
+type HardwareDetails struct {
+	SystemVendor HardwareSystemVendor `json:"systemVendor"`
+	Firmware     HardwareFirmware     `json:"firmware"`
+	RAMMebibytes int                  `json:"ramMebibytes"`
+	NIC          []HardwareNIC        `json:"nics"`
+	Storage      []HardwareStorage    `json:"storage"`
+	Hostname     string               `json:"hostname"`
+	CPU          HardwareCPU          `json:"cpu"`
+}
+
+// HardwareSystemVendor stores details about the whole hardware system
+type HardwareSystemVendor struct {
+	Manufacturer string `json:"manufacturer,omitempty"`
+	ProductName  string `json:"productName,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+// HardwareFirmware describes the firmware on the host
+type HardwareFirmware struct {
+	BIOS HardwareBIOS `json:"bios,omitempty"`
+}
+
+// HardwareBIOS describes the BIOS version on the host
+type HardwareBIOS struct {
+	Date    string `json:"date,omitempty"`
+	Vendor  string `json:"vendor,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// HardwareNIC describes one network interface on the host
+type HardwareNIC struct {
+	Name      string `json:"name,omitempty"`
+	Model     string `json:"model,omitempty"`
+	MAC       string `json:"mac,omitempty"`
+	IP        string `json:"ip,omitempty"`
+	SpeedGbps int    `json:"speedGbps,omitempty"`
+	PXE       bool   `json:"pxe,omitempty"`
+}
+
+// HardwareStorage describes one storage device (disk, SSD, etc.) on the host
+type HardwareStorage struct {
+	Name         string `json:"name,omitempty"`
+	Rotational   bool   `json:"rotational,omitempty"`
+	SizeBytes    int64  `json:"sizeBytes"`
+	Vendor       string `json:"vendor,omitempty"`
+	Model        string `json:"model,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+// HardwareCPU describes CPUs of the host, count is the number of logical CPUs
+type HardwareCPU struct {
+	Arch           string   `json:"arch,omitempty"`
+	Model          string   `json:"model,omitempty"`
+	ClockMegahertz float64  `json:"clockMegahertz,omitempty"`
+	Flags          []string `json:"flags,omitempty"`
+	Count          int      `json:"count,omitempty"`
+}
@@ -30,6 +30,9 @@ type Client interface {
 	SystemPowerOff(context.Context) error
 	SystemPowerOn(context.Context) error
 	SystemPowerStatus(context.Context) (power.Status, error)
+	SystemPowerCycle(context.Context) error
+	SetBootDevice(context.Context, BootDevice, bool) error
+	WaitForPowerState(context.Context, power.Status) error
 	RemoteDirect(context.Context, string) error
 
 	// TODO(drewwalters96): This function is tightly coupled to Redfish. It should be combined with the
@@ -37,6 +40,57 @@ type Client interface {
 	SetVirtualMedia(context.Context, string) error
 }
 
+// SessionCloser is implemented by clients that keep an authenticated session with the BMC across requests.
+// The session should be closed once the operation against the host is finished.
+type SessionCloser interface {
+	CloseSession(context.Context) error
+}
+
+// CloseSession closes the session of the client if it keeps one
+func CloseSession(ctx context.Context, c Client) error {
+	closer, ok := c.(SessionCloser)
+	if !ok {
+		return nil
+	}
+	return closer.CloseSession(ctx)
+}
+
+// BootDevice is a device that host is going to boot from
+type BootDevice string
+
+const (
+	// BootDevicePXE boot from network using PXE
+	BootDevicePXE BootDevice = "pxe"
+	// BootDeviceDisk boot from local disk
+	BootDeviceDisk BootDevice = "disk"
+	// BootDeviceCD boot from CD or virtual media
+	BootDeviceCD BootDevice = "cd"
+)
+
+// BootMode is a firmware boot mode of the host, values match bootMode of BareMetalHost
+type BootMode string
+
+const (
+	// BootModeUEFI boot using UEFI firmware
+	BootModeUEFI BootMode = "UEFI"
+	// BootModeUEFISecureBoot boot using UEFI firmware with secure boot enabled
+	BootModeUEFISecureBoot BootMode = "UEFISecureBoot"
+	// BootModeLegacy boot using legacy BIOS
+	BootModeLegacy BootMode = "legacy"
+)
+
+// BootModeConfigurer is implemented by clients that have to know the boot mode of the host to set its boot device
+type BootModeConfigurer interface {
+	SetBootMode(BootMode)
+}
+
+// SetBootMode sets the boot mode of the host if the client needs it, other clients are left untouched
+func SetBootMode(c Client, mode BootMode) {
+	if configurer, ok := c.(BootModeConfigurer); ok {
+		configurer.SetBootMode(mode)
+	}
+}
+
 // ClientFactory is a function to be used
 type ClientFactory func(
 	redfishURL string,
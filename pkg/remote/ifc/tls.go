@@ -0,0 +1,35 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ifc
+
+import (
+	"crypto/x509"
+)
+
+// TLSConfigurer is implemented by clients that communicate with the BMC over TLS and are able to verify
+// BMC certificate against custom certificate authorities instead of the system ones
+type TLSConfigurer interface {
+	SetRootCAs(*x509.CertPool) error
+}
+
+// SetRootCAs makes the client trust BMC certificates issued by certificate authorities from the pool
+// if the client supports it
+func SetRootCAs(c Client, pool *x509.CertPool) error {
+	configurer, ok := c.(TLSConfigurer)
+	if !ok {
+		return ErrTLSConfigurationNotSupported{NodeID: c.NodeID()}
+	}
+	return configurer.SetRootCAs(pool)
+}
@@ -0,0 +1,227 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ipmi
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	simulatedBMCSessionID = 0x0a0b0c0d
+
+	ccInvalidCommand  = 0xc1
+	ccCommandNotValid = 0xd5
+
+	statusUnauthorizedName    = 0x0d
+	statusInvalidIntegrityVal = 0x0f
+)
+
+// simulatedBMC is a minimal RMCP+ responder implementing BMC side of cipher suite 3 session
+// establishment and the chassis commands used by the client
+type simulatedBMC struct {
+	conn     *net.UDPConn
+	username string
+	password string
+	guid     []byte
+
+	mu sync.Mutex
+	// powerOn is the chassis power state
+	powerOn bool
+	// ignorePower makes chassis control requests succeed without changing the power state
+	ignorePower bool
+	// rejectChassisControl makes chassis control requests fail with non-zero completion code
+	rejectChassisControl bool
+	// dropPackets is the number of received packets ignored before BMC starts responding
+	dropPackets int
+	// actions are the chassis control actions received
+	actions []byte
+	// bootOptions is the data of the last set system boot options request
+	bootOptions []byte
+	// sessionsClosed is the number of close session requests received
+	sessionsClosed int
+
+	consoleID   []byte
+	consoleRand []byte
+	bmcRand     []byte
+	role        byte
+	name        []byte
+	k1          []byte
+	aesKey      []byte
+	seq         uint32
+}
+
+func newSimulatedBMC(t *testing.T, username, password string, opts ...func(*simulatedBMC)) *simulatedBMC {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	require.NoError(t, err)
+
+	b := &simulatedBMC{
+		conn:     conn,
+		username: username,
+		password: password,
+		guid:     bytes.Repeat([]byte{0x42}, guidLength),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	go b.serve()
+	return b
+}
+
+// URL returns BMC address as expected by the client
+func (b *simulatedBMC) URL() string {
+	return ClientType + "://" + b.conn.LocalAddr().String()
+}
+
+func (b *simulatedBMC) Close() {
+	b.conn.Close()
+}
+
+func (b *simulatedBMC) serve() {
+	buf := make([]byte, 1024)
+	for {
+		n, addr, err := b.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		if resp := b.handle(buf[:n]); resp != nil {
+			b.conn.WriteToUDP(resp, addr) //nolint:errcheck
+		}
+	}
+}
+
+func (b *simulatedBMC) handle(packet []byte) []byte {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.dropPackets > 0 {
+		b.dropPackets--
+		return nil
+	}
+
+	payloadType, sessionID, payload, ok := decodePacket(packet, b.k1)
+	if !ok {
+		return nil
+	}
+
+	switch payloadType {
+	case payloadOpenSessionRequest:
+		b.consoleID = append([]byte{}, payload[4:8]...)
+		resp := []byte{payload[0], 0x00, privAdmin, 0x00}
+		resp = append(resp, b.consoleID...)
+		resp = appendUint32(resp, simulatedBMCSessionID)
+		resp = append(resp, payload[8:32]...)
+		return encodePacket(payloadOpenSessionResponse, 0, 0, resp, nil)
+	case payloadRAKP1:
+		b.consoleRand = append([]byte{}, payload[8:24]...)
+		b.role = payload[24]
+		b.name = append([]byte{}, payload[28:28+int(payload[27])]...)
+		if string(b.name) != b.username {
+			resp := append([]byte{payload[0], statusUnauthorizedName, 0x00, 0x00}, b.consoleID...)
+			return encodePacket(payloadRAKP2, 0, 0, resp, nil)
+		}
+		b.bmcRand = make([]byte, randomLength)
+		rand.Read(b.bmcRand) //nolint:errcheck
+		resp := append([]byte{payload[0], 0x00, 0x00, 0x00}, b.consoleID...)
+		resp = append(resp, b.bmcRand...)
+		resp = append(resp, b.guid...)
+		resp = append(resp, hmacSHA1([]byte(b.password), b.consoleID, payload[4:8], b.consoleRand, b.bmcRand,
+			b.guid, []byte{b.role, byte(len(b.name))}, b.name)...)
+		return encodePacket(payloadRAKP2, 0, 0, resp, nil)
+	case payloadRAKP3:
+		nameAndRole := []byte{b.role, byte(len(b.name))}
+		expected := hmacSHA1([]byte(b.password), b.bmcRand, b.consoleID, nameAndRole, b.name)
+		if !bytes.Equal(expected, payload[8:8+sha1Length]) {
+			resp := append([]byte{payload[0], statusInvalidIntegrityVal, 0x00, 0x00}, b.consoleID...)
+			return encodePacket(payloadRAKP4, 0, 0, resp, nil)
+		}
+		sik := hmacSHA1([]byte(b.password), b.consoleRand, b.bmcRand, nameAndRole, b.name)
+		b.k1 = hmacSHA1(sik, bytes.Repeat([]byte{0x01}, sha1Length))
+		b.aesKey = hmacSHA1(sik, bytes.Repeat([]byte{0x02}, sha1Length))[:16]
+		resp := append([]byte{payload[0], 0x00, 0x00, 0x00}, b.consoleID...)
+		resp = append(resp, hmacSHA1(sik, b.consoleRand, payload[4:8], b.guid)[:authCodeLength]...)
+		return encodePacket(payloadRAKP4, 0, 0, resp, nil)
+	case payloadEncrypt | payloadAuth | payloadIPMI:
+		if sessionID != simulatedBMCSessionID {
+			return nil
+		}
+		msg, err := decrypt(b.aesKey, payload)
+		if err != nil || len(msg) < 7 {
+			return nil
+		}
+		netFn, rqSeq, cmd := msg[1]>>2, msg[4]>>2, msg[5]
+		code, data := b.command(netFn, cmd, msg[6:len(msg)-1])
+
+		resp := []byte{consoleSoftwareID, (netFn | 1) << 2}
+		resp = append(resp, checksum(resp), bmcSlaveAddress, rqSeq<<2, cmd, code)
+		resp = append(resp, data...)
+		resp = append(resp, checksum(resp[3:]))
+		encrypted, err := encrypt(b.aesKey, resp)
+		if err != nil {
+			return nil
+		}
+		b.seq++
+		return encodePacket(payloadEncrypt|payloadAuth|payloadIPMI, binary.LittleEndian.Uint32(b.consoleID),
+			b.seq, encrypted, b.k1)
+	}
+	return nil
+}
+
+func (b *simulatedBMC) command(netFn, cmd byte, data []byte) (byte, []byte) {
+	switch {
+	case netFn == netFnApp && cmd == cmdSetSessionPrivilege:
+		return completionCodeOK, []byte{privAdmin}
+	case netFn == netFnApp && cmd == cmdCloseSession:
+		b.sessionsClosed++
+		return completionCodeOK, nil
+	case netFn == netFnChassis && cmd == cmdGetChassisStatus:
+		var state byte
+		if b.powerOn {
+			state = 0x01
+		}
+		return completionCodeOK, []byte{state, 0x00, 0x00}
+	case netFn == netFnChassis && cmd == cmdChassisControl:
+		if b.rejectChassisControl {
+			return ccCommandNotValid, nil
+		}
+		b.actions = append(b.actions, data[0])
+		if b.ignorePower {
+			return completionCodeOK, nil
+		}
+		switch data[0] {
+		case chassisPowerDown:
+			b.powerOn = false
+		case chassisPowerUp, chassisPowerCycle:
+			b.powerOn = true
+		}
+		return completionCodeOK, nil
+	case netFn == netFnChassis && cmd == cmdSetSystemBootOptions:
+		b.bootOptions = append([]byte{}, data...)
+		return completionCodeOK, nil
+	}
+	return ccInvalidCommand, nil
+}
+
+func (b *simulatedBMC) state() (powerOn bool, actions []byte, bootOptions []byte, sessionsClosed int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.powerOn, b.actions, b.bootOptions, b.sessionsClosed
+}
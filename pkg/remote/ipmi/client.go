@@ -0,0 +1,351 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipmi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+)
+
+const (
+	// ClientType is used by other packages as the identifier of the IPMI client.
+	ClientType string = "ipmi"
+
+	// DefaultPort is the RMCP+ port used when BMC address doesn't specify one
+	DefaultPort = "623"
+
+	// MaxPasswordLength is the maximum length of BMC password supported by IPMI v2.0
+	MaxPasswordLength = 20
+
+	netFnChassis            = 0x00
+	netFnApp                = 0x06
+	cmdGetChassisStatus     = 0x01
+	cmdChassisControl       = 0x02
+	cmdSetSystemBootOptions = 0x08
+	cmdSetSessionPrivilege  = 0x3b
+	cmdCloseSession         = 0x3c
+
+	chassisPowerDown  = 0x00
+	chassisPowerUp    = 0x01
+	chassisPowerCycle = 0x02
+
+	bootParamBootFlags = 0x05
+	bootFlagsValid     = 0x80
+	bootFlagsPersist   = 0x40
+	bootFlagsEFI       = 0x20
+	bootDevicePXE      = 0x04
+	bootDeviceDisk     = 0x08
+	bootDeviceCD       = 0x14
+)
+
+// Client holds details about an IPMI out-of-band system required for out-of-band management.
+type Client struct {
+	nodeID              string
+	address             string
+	username            string
+	password            string
+	systemActionRetries int
+	systemRebootDelay   int
+	uefi                bool
+
+	// PacketTimeout is the time to wait for BMC response before the request is resent
+	PacketTimeout time.Duration
+	// Sleep is meant to be mocked out for tests
+	Sleep func(d time.Duration)
+}
+
+// NodeID retrieves the ephemeral node ID, which is the BMC address for IPMI.
+func (c *Client) NodeID() string {
+	return c.nodeID
+}
+
+// MaxPasswordLength returns the maximum length of BMC password the client is able to authenticate with
+func (c *Client) MaxPasswordLength() int {
+	return MaxPasswordLength
+}
+
+// SystemActionRetries returns number of attempts to reach host during reboot process
+func (c *Client) SystemActionRetries() int {
+	return c.systemActionRetries
+}
+
+// SystemRebootDelay returns number of seconds to wait after reboot if host isn't available
+func (c *Client) SystemRebootDelay() int {
+	return c.systemRebootDelay
+}
+
+// EjectVirtualMedia is not supported by IPMI.
+func (c *Client) EjectVirtualMedia(ctx context.Context) error {
+	return ErrVirtualMediaNotSupported{Operation: "eject virtual media"}
+}
+
+// RebootSystem power cycles a host by sending a shutdown signal followed by a power on signal.
+func (c *Client) RebootSystem(ctx context.Context) error {
+	return c.withSession(ctx, func(s *session) error {
+		log.Debugf("Rebooting node '%s': powering off.", c.nodeID)
+		if err := c.chassisControl(ctx, s, chassisPowerDown); err != nil {
+			log.Debugf("Failed to reboot node '%s': shutdown failure.", c.nodeID)
+			return err
+		}
+		if err := c.waitForPowerState(ctx, s, power.StatusOff); err != nil {
+			return err
+		}
+
+		log.Debugf("Rebooting node '%s': powering on.", c.nodeID)
+		if err := c.chassisControl(ctx, s, chassisPowerUp); err != nil {
+			log.Debugf("Failed to reboot node '%s': startup failure.", c.nodeID)
+			return err
+		}
+		return c.waitForPowerState(ctx, s, power.StatusOn)
+	})
+}
+
+// SetBootSourceByType is not supported by IPMI, since it requires virtual media.
+func (c *Client) SetBootSourceByType(ctx context.Context) error {
+	return ErrVirtualMediaNotSupported{Operation: "set boot source to virtual media"}
+}
+
+// SetVirtualMedia is not supported by IPMI.
+func (c *Client) SetVirtualMedia(ctx context.Context, isoPath string) error {
+	return ErrVirtualMediaNotSupported{Operation: "insert virtual media"}
+}
+
+// SystemPowerOff shuts down a host.
+func (c *Client) SystemPowerOff(ctx context.Context) error {
+	return c.withSession(ctx, func(s *session) error {
+		if err := c.chassisControl(ctx, s, chassisPowerDown); err != nil {
+			return err
+		}
+		return c.waitForPowerState(ctx, s, power.StatusOff)
+	})
+}
+
+// SystemPowerOn powers on a host.
+func (c *Client) SystemPowerOn(ctx context.Context) error {
+	return c.withSession(ctx, func(s *session) error {
+		if err := c.chassisControl(ctx, s, chassisPowerUp); err != nil {
+			return err
+		}
+		return c.waitForPowerState(ctx, s, power.StatusOn)
+	})
+}
+
+// SystemPowerStatus retrieves the power status of a host as a human-readable string.
+func (c *Client) SystemPowerStatus(ctx context.Context) (power.Status, error) {
+	status := power.StatusUnknown
+	err := c.withSession(ctx, func(s *session) error {
+		var err error
+		status, err = c.powerStatus(ctx, s)
+		return err
+	})
+	return status, err
+}
+
+// SystemPowerCycle power cycles a host using single chassis control request and waits for it to power on.
+// Power cycle has no effect if host is powered off, so the host is powered on instead.
+func (c *Client) SystemPowerCycle(ctx context.Context) error {
+	log.Debugf("Power cycling node '%s'.", c.nodeID)
+	return c.withSession(ctx, func(s *session) error {
+		status, err := c.powerStatus(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		action := byte(chassisPowerCycle)
+		if status == power.StatusOff {
+			action = chassisPowerUp
+		}
+		if err = c.chassisControl(ctx, s, action); err != nil {
+			log.Debugf("Failed to power cycle node '%s'.", c.nodeID)
+			return err
+		}
+		return c.waitForPowerState(ctx, s, power.StatusOn)
+	})
+}
+
+// SetBootMode sets the boot mode of the host, it's needed to request UEFI boot when boot device is set.
+// Legacy BIOS boot is requested if the boot mode is not set.
+func (c *Client) SetBootMode(mode ifc.BootMode) {
+	c.uefi = mode == ifc.BootModeUEFI || mode == ifc.BootModeUEFISecureBoot
+}
+
+// SetBootDevice sets the device host boots from, if persistent is false the host boots from
+// the device only once, after that boot order defined in host BIOS is used.
+func (c *Client) SetBootDevice(ctx context.Context, device ifc.BootDevice, persistent bool) error {
+	var selector byte
+	switch device {
+	case ifc.BootDevicePXE:
+		selector = bootDevicePXE
+	case ifc.BootDeviceDisk:
+		selector = bootDeviceDisk
+	case ifc.BootDeviceCD:
+		selector = bootDeviceCD
+	default:
+		return ErrIPMIClient{Message: fmt.Sprintf("unknown boot device '%s'", device)}
+	}
+
+	flags := byte(bootFlagsValid)
+	if persistent {
+		flags |= bootFlagsPersist
+	}
+	if c.uefi {
+		flags |= bootFlagsEFI
+	}
+
+	log.Debugf("Setting boot device of node '%s' to '%s' (persistent: %t, UEFI: %t).",
+		c.nodeID, device, persistent, c.uefi)
+	return c.withSession(ctx, func(s *session) error {
+		_, err := s.command(ctx, netFnChassis, cmdSetSystemBootOptions,
+			[]byte{bootParamBootFlags, flags, selector, 0x00, 0x00, 0x00})
+		if err != nil {
+			return err
+		}
+		log.Debug("Successfully set boot device.")
+		return nil
+	})
+}
+
+// WaitForPowerState waits until host reaches desired power state, only on and off states are supported.
+func (c *Client) WaitForPowerState(ctx context.Context, desiredState power.Status) error {
+	if desiredState != power.StatusOn && desiredState != power.StatusOff {
+		return ErrIPMIClient{Message: fmt.Sprintf("unable to wait for power state '%s'", desiredState)}
+	}
+	return c.withSession(ctx, func(s *session) error {
+		return c.waitForPowerState(ctx, s, desiredState)
+	})
+}
+
+// RemoteDirect is not supported by IPMI, since it requires virtual media.
+func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
+	return ErrVirtualMediaNotSupported{Operation: "perform remote direct"}
+}
+
+// withSession establishes RMCP+ session with BMC, runs f and closes the session
+func (c *Client) withSession(ctx context.Context, f func(s *session) error) error {
+	s, err := newSession(ctx, c.address, c.username, c.password, c.PacketTimeout)
+	if err != nil {
+		return err
+	}
+	defer s.close(ctx)
+	return f(s)
+}
+
+func (c *Client) chassisControl(ctx context.Context, s *session, action byte) error {
+	_, err := s.command(ctx, netFnChassis, cmdChassisControl, []byte{action})
+	return err
+}
+
+func (c *Client) powerStatus(ctx context.Context, s *session) (power.Status, error) {
+	resp, err := s.command(ctx, netFnChassis, cmdGetChassisStatus, nil)
+	if err != nil {
+		return power.StatusUnknown, err
+	}
+	if len(resp) < 1 {
+		return power.StatusUnknown, ErrIPMIClient{Message: "malformed chassis status response"}
+	}
+	if resp[0]&0x01 != 0 {
+		return power.StatusOn, nil
+	}
+	return power.StatusOff, nil
+}
+
+func (c *Client) waitForPowerState(ctx context.Context, s *session, desiredState power.Status) error {
+	log.Debugf("Waiting for node '%s' to reach power state '%s'.", c.nodeID, desiredState)
+
+	for retry := 0; retry <= c.systemActionRetries; retry++ {
+		status, err := c.powerStatus(ctx, s)
+		if err != nil {
+			return err
+		}
+
+		if status == desiredState {
+			log.Debugf("Node '%s' reached power state '%s'.", c.nodeID, desiredState)
+			return nil
+		}
+
+		c.Sleep(time.Duration(c.systemRebootDelay) * time.Second)
+	}
+
+	return ErrOperationRetriesExceeded{
+		What:    fmt.Sprintf("reach desired power state %s", desiredState),
+		Retries: c.systemActionRetries,
+	}
+}
+
+// NewClient returns a client with the capability to make IPMI requests. BMC address is expected
+// in the form ipmi://host[:port], port 623 is used if omitted.
+func NewClient(bmcURL string,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (*Client, error) {
+	if bmcURL == "" {
+		return nil, ErrIPMIMissingConfig{What: "IPMI address"}
+	}
+
+	if !strings.Contains(bmcURL, "://") {
+		bmcURL = ClientType + "://" + bmcURL
+	}
+	parsedURL, err := url.Parse(bmcURL)
+	if err != nil {
+		return nil, ErrIPMIClient{Message: err.Error()}
+	}
+	if parsedURL.Scheme != ClientType {
+		return nil, ErrIPMIClient{Message: fmt.Sprintf("unsupported scheme '%s' of IPMI address", parsedURL.Scheme)}
+	}
+	if parsedURL.Hostname() == "" {
+		return nil, ErrIPMIMissingConfig{What: "IPMI host"}
+	}
+
+	port := parsedURL.Port()
+	if port == "" {
+		port = DefaultPort
+	}
+	address := net.JoinHostPort(parsedURL.Hostname(), port)
+
+	c := &Client{
+		nodeID:              address,
+		address:             address,
+		username:            username,
+		password:            password,
+		systemActionRetries: systemActionRetries,
+		systemRebootDelay:   systemRebootDelay,
+		PacketTimeout:       defaultPacketTimeout,
+
+		Sleep: func(d time.Duration) {
+			time.Sleep(d)
+		},
+	}
+
+	return c, nil
+}
+
+// ClientFactory is a constructor for ipmi ifc.Client implementation, TLS and proxy
+// settings are not applicable to IPMI and ignored
+var ClientFactory ifc.ClientFactory = func(bmcURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (ifc.Client, error) {
+	return NewClient(bmcURL, username, password, systemActionRetries, systemRebootDelay)
+}
@@ -0,0 +1,372 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ipmi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/power"
+)
+
+const (
+	username            = "admin"
+	password            = "secret"
+	systemActionRetries = 1
+	systemRebootDelay   = 0
+)
+
+func newTestClient(t *testing.T, bmcURL string) *Client {
+	c, err := NewClient(bmcURL, username, password, systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+	c.PacketTimeout = 100 * time.Millisecond
+	c.Sleep = func(_ time.Duration) {}
+	return c
+}
+
+func poweredOn(b *simulatedBMC) {
+	b.powerOn = true
+}
+
+func TestNewClient(t *testing.T) {
+	tests := []struct {
+		name           string
+		bmcURL         string
+		expectedNodeID string
+		expectedErr    error
+	}{
+		{
+			name:           "with port",
+			bmcURL:         "ipmi://192.168.1.10:6230",
+			expectedNodeID: "192.168.1.10:6230",
+		},
+		{
+			name:           "default port",
+			bmcURL:         "ipmi://192.168.1.10",
+			expectedNodeID: "192.168.1.10:623",
+		},
+		{
+			name:           "no scheme",
+			bmcURL:         "bmc.example.com",
+			expectedNodeID: "bmc.example.com:623",
+		},
+		{
+			name:           "ipv6",
+			bmcURL:         "ipmi://[fd00::10]",
+			expectedNodeID: "[fd00::10]:623",
+		},
+		{
+			name:        "empty url",
+			bmcURL:      "",
+			expectedErr: ErrIPMIMissingConfig{What: "IPMI address"},
+		},
+		{
+			name:        "unsupported scheme",
+			bmcURL:      "redfish+https://192.168.1.10/redfish/v1/Systems/1",
+			expectedErr: ErrIPMIClient{Message: "unsupported scheme 'redfish+https' of IPMI address"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClient(tt.bmcURL, username, password, systemActionRetries, systemRebootDelay)
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedNodeID, c.NodeID())
+			assert.Equal(t, systemActionRetries, c.SystemActionRetries())
+			assert.Equal(t, systemRebootDelay, c.SystemRebootDelay())
+		})
+	}
+}
+
+func TestClientFactory(t *testing.T) {
+	c, err := ClientFactory("ipmi://192.168.1.10", true, true, username, password,
+		systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+	_, ok := c.(ifc.Client)
+	assert.True(t, ok)
+	assert.Equal(t, MaxPasswordLength, ifc.MaxPasswordLength(c))
+}
+
+func TestVirtualMediaNotSupported(t *testing.T) {
+	c := newTestClient(t, "ipmi://192.168.1.10")
+	ctx := context.Background()
+
+	assert.Equal(t, ErrVirtualMediaNotSupported{Operation: "eject virtual media"}, c.EjectVirtualMedia(ctx))
+	assert.Equal(t, ErrVirtualMediaNotSupported{Operation: "insert virtual media"},
+		c.SetVirtualMedia(ctx, "http://localhost/ephemeral.iso"))
+	assert.Equal(t, ErrVirtualMediaNotSupported{Operation: "set boot source to virtual media"},
+		c.SetBootSourceByType(ctx))
+	assert.Equal(t, ErrVirtualMediaNotSupported{Operation: "perform remote direct"},
+		c.RemoteDirect(ctx, "http://localhost/ephemeral.iso"))
+}
+
+func TestSystemPowerStatus(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password)
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+
+	status, err := c.SystemPowerStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, power.StatusOff, status)
+
+	bmcOn := newSimulatedBMC(t, username, password, poweredOn)
+	defer bmcOn.Close()
+	c = newTestClient(t, bmcOn.URL())
+
+	status, err = c.SystemPowerStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, power.StatusOn, status)
+
+	_, _, _, sessionsClosed := bmcOn.state()
+	assert.Equal(t, 1, sessionsClosed)
+}
+
+func TestSystemPowerOnOff(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password)
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+
+	require.NoError(t, c.SystemPowerOn(context.Background()))
+	powerOn, _, _, _ := bmc.state()
+	assert.True(t, powerOn)
+
+	require.NoError(t, c.SystemPowerOff(context.Background()))
+	powerOn, actions, _, sessionsClosed := bmc.state()
+	assert.False(t, powerOn)
+	assert.Equal(t, []byte{chassisPowerUp, chassisPowerDown}, actions)
+	assert.Equal(t, 2, sessionsClosed)
+}
+
+func TestRebootSystem(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password, poweredOn)
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+
+	require.NoError(t, c.RebootSystem(context.Background()))
+	powerOn, actions, _, _ := bmc.state()
+	assert.True(t, powerOn)
+	assert.Equal(t, []byte{chassisPowerDown, chassisPowerUp}, actions)
+}
+
+func TestRebootSystemShutdownError(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password, poweredOn, func(b *simulatedBMC) {
+		b.rejectChassisControl = true
+	})
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+
+	err := c.RebootSystem(context.Background())
+	assert.Equal(t, ErrCompletionCode{NetFn: netFnChassis, Command: cmdChassisControl, Code: ccCommandNotValid}, err)
+}
+
+func TestSystemPowerCycle(t *testing.T) {
+	tests := []struct {
+		name            string
+		opts            []func(*simulatedBMC)
+		expectedActions []byte
+	}{
+		{
+			name:            "powered on host is power cycled",
+			opts:            []func(*simulatedBMC){poweredOn},
+			expectedActions: []byte{chassisPowerCycle},
+		},
+		{
+			name:            "powered off host is powered on",
+			expectedActions: []byte{chassisPowerUp},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			bmc := newSimulatedBMC(t, username, password, tt.opts...)
+			defer bmc.Close()
+			c := newTestClient(t, bmc.URL())
+
+			require.NoError(t, c.SystemPowerCycle(context.Background()))
+			powerOn, actions, _, _ := bmc.state()
+			assert.True(t, powerOn)
+			assert.Equal(t, tt.expectedActions, actions)
+		})
+	}
+}
+
+func TestSetBootDevice(t *testing.T) {
+	tests := []struct {
+		name        string
+		device      ifc.BootDevice
+		persistent  bool
+		bootMode    ifc.BootMode
+		expectedReq []byte
+		expectedErr error
+	}{
+		{
+			name:        "pxe once",
+			device:      ifc.BootDevicePXE,
+			expectedReq: []byte{bootParamBootFlags, 0x80, 0x04, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "disk persistent",
+			device:      ifc.BootDeviceDisk,
+			persistent:  true,
+			expectedReq: []byte{bootParamBootFlags, 0xc0, 0x08, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "cd once",
+			device:      ifc.BootDeviceCD,
+			expectedReq: []byte{bootParamBootFlags, 0x80, 0x14, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "pxe once uefi",
+			device:      ifc.BootDevicePXE,
+			bootMode:    ifc.BootModeUEFI,
+			expectedReq: []byte{bootParamBootFlags, 0xa0, 0x04, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "disk persistent uefi secure boot",
+			device:      ifc.BootDeviceDisk,
+			persistent:  true,
+			bootMode:    ifc.BootModeUEFISecureBoot,
+			expectedReq: []byte{bootParamBootFlags, 0xe0, 0x08, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "cd once legacy",
+			device:      ifc.BootDeviceCD,
+			bootMode:    ifc.BootModeLegacy,
+			expectedReq: []byte{bootParamBootFlags, 0x80, 0x14, 0x00, 0x00, 0x00},
+		},
+		{
+			name:        "unknown device",
+			device:      "floppy",
+			expectedErr: ErrIPMIClient{Message: "unknown boot device 'floppy'"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			bmc := newSimulatedBMC(t, username, password)
+			defer bmc.Close()
+			c := newTestClient(t, bmc.URL())
+			ifc.SetBootMode(c, tt.bootMode)
+
+			err := c.SetBootDevice(context.Background(), tt.device, tt.persistent)
+			_, _, bootOptions, _ := bmc.state()
+			if tt.expectedErr != nil {
+				assert.Equal(t, tt.expectedErr, err)
+				assert.Nil(t, bootOptions)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedReq, bootOptions)
+		})
+	}
+}
+
+func TestWaitForPowerState(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password, poweredOn)
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+
+	assert.NoError(t, c.WaitForPowerState(context.Background(), power.StatusOn))
+	assert.Equal(t, ErrOperationRetriesExceeded{What: "reach desired power state OFF", Retries: systemActionRetries},
+		c.WaitForPowerState(context.Background(), power.StatusOff))
+	assert.Equal(t, ErrIPMIClient{Message: "unable to wait for power state 'UNKNOWN'"},
+		c.WaitForPowerState(context.Background(), power.StatusUnknown))
+}
+
+func TestSystemPowerOnRetriesExceeded(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password, func(b *simulatedBMC) {
+		b.ignorePower = true
+	})
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+
+	sleeps := 0
+	c.Sleep = func(_ time.Duration) { sleeps++ }
+
+	err := c.SystemPowerOn(context.Background())
+	assert.Equal(t, ErrOperationRetriesExceeded{What: "reach desired power state ON", Retries: systemActionRetries}, err)
+	assert.Equal(t, systemActionRetries+1, sleeps)
+}
+
+func TestSessionAuthentication(t *testing.T) {
+	tests := []struct {
+		name        string
+		username    string
+		password    string
+		expectedErr error
+	}{
+		{
+			name:        "unknown user",
+			username:    "operator",
+			password:    password,
+			expectedErr: ErrSessionStatus{Stage: "RAKP message 2", Status: statusUnauthorizedName},
+		},
+		{
+			name:        "wrong password",
+			username:    username,
+			password:    "wrong",
+			expectedErr: ErrIPMIClient{Message: "BMC authentication failed, check username and password"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			bmc := newSimulatedBMC(t, tt.username, tt.password)
+			defer bmc.Close()
+			c := newTestClient(t, bmc.URL())
+
+			_, err := c.SystemPowerStatus(context.Background())
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
+
+func TestSessionPacketRetry(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password, poweredOn, func(b *simulatedBMC) {
+		b.dropPackets = 2
+	})
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+	c.PacketTimeout = 20 * time.Millisecond
+
+	status, err := c.SystemPowerStatus(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, power.StatusOn, status)
+}
+
+func TestSessionNoResponse(t *testing.T) {
+	bmc := newSimulatedBMC(t, username, password, func(b *simulatedBMC) {
+		b.dropPackets = defaultPacketRetry
+	})
+	defer bmc.Close()
+	c := newTestClient(t, bmc.URL())
+	c.PacketTimeout = 20 * time.Millisecond
+
+	_, err := c.SystemPowerStatus(context.Background())
+	assert.Equal(t, ErrIPMIClient{Message: "no response received from BMC " + c.NodeID()}, err)
+}
@@ -0,0 +1,79 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package ipmi
+
+import (
+	"fmt"
+)
+
+// ErrIPMIClient describes an error encountered by the IPMI client.
+type ErrIPMIClient struct {
+	Message string
+}
+
+func (e ErrIPMIClient) Error() string {
+	return fmt.Sprintf("ipmi client encountered an error: %s", e.Message)
+}
+
+// ErrIPMIMissingConfig describes an error encountered due to a missing configuration option.
+type ErrIPMIMissingConfig struct {
+	What string
+}
+
+func (e ErrIPMIMissingConfig) Error() string {
+	return "missing configuration: " + e.What
+}
+
+// ErrSessionStatus is returned when BMC rejects RMCP+ session establishment
+type ErrSessionStatus struct {
+	Stage  string
+	Status byte
+}
+
+func (e ErrSessionStatus) Error() string {
+	return fmt.Sprintf("BMC rejected IPMI session at %s stage with status code 0x%02x", e.Stage, e.Status)
+}
+
+// ErrCompletionCode is returned when BMC responds to a command with non-zero completion code
+type ErrCompletionCode struct {
+	NetFn   byte
+	Command byte
+	Code    byte
+}
+
+func (e ErrCompletionCode) Error() string {
+	return fmt.Sprintf("IPMI command 0x%02x (netfn 0x%02x) failed with completion code 0x%02x",
+		e.Command, e.NetFn, e.Code)
+}
+
+// ErrOperationRetriesExceeded raised if number of operation retries exceeded
+type ErrOperationRetriesExceeded struct {
+	What    string
+	Retries int
+}
+
+func (e ErrOperationRetriesExceeded) Error() string {
+	return fmt.Sprintf("Unable to %s. Maximum retries (%d) exceeded.", e.What, e.Retries)
+}
+
+// ErrVirtualMediaNotSupported is returned for operations which require virtual media
+type ErrVirtualMediaNotSupported struct {
+	Operation string
+}
+
+func (e ErrVirtualMediaNotSupported) Error() string {
+	return fmt.Sprintf("Unable to %s: virtual media is not supported by %s management type, "+
+		"use a redfish management type instead.", e.Operation, ClientType)
+}
@@ -0,0 +1,426 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ipmi
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec
+	"encoding/binary"
+	"net"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+// RMCP+ session implementation, see chapter 13 of IPMI v2.0 specification. Only cipher suite 3 is
+// supported, which is RAKP-HMAC-SHA1 authentication, HMAC-SHA1-96 integrity and AES-CBC-128 confidentiality.
+// It is the cipher suite that is enabled by default on all BMCs supporting IPMI v2.0.
+
+const (
+	rmcpVersion     = 0x06
+	rmcpNoAckSeq    = 0xff
+	rmcpClassIPMI   = 0x07
+	authTypeRMCPP   = 0x06
+	payloadEncrypt  = 0x80
+	payloadAuth     = 0x40
+	payloadTypeMask = 0x3f
+
+	payloadIPMI                = 0x00
+	payloadOpenSessionRequest  = 0x10
+	payloadOpenSessionResponse = 0x11
+	payloadRAKP1               = 0x12
+	payloadRAKP2               = 0x13
+	payloadRAKP3               = 0x14
+	payloadRAKP4               = 0x15
+
+	algRAKPHMACSHA1  = 0x01
+	algHMACSHA196    = 0x01
+	algAESCBC128     = 0x01
+	privAdmin        = 0x04
+	nameOnlyLookup   = 0x10
+	authCodeLength   = 12
+	sha1Length       = 20
+	randomLength     = 16
+	guidLength       = 16
+	maxUsernameLen   = 16
+	sessionHeaderLen = 16
+
+	bmcSlaveAddress      = 0x20
+	consoleSoftwareID    = 0x81
+	completionCodeOK     = 0x00
+	defaultPacketRetry   = 3
+	defaultPacketTimeout = 5 * time.Second
+)
+
+// session is an established RMCP+ session with a BMC
+type session struct {
+	conn     net.Conn
+	timeout  time.Duration
+	retries  int
+	username []byte
+	password []byte
+
+	consoleID uint32
+	bmcID     uint32
+	seq       uint32
+	rqSeq     byte
+
+	k1     []byte
+	aesKey []byte
+}
+
+// newSession connects to BMC at address and performs RMCP+ session establishment, privilege level of
+// the session is raised to administrator
+func newSession(ctx context.Context, address, username, password string, timeout time.Duration) (*session, error) {
+	if len(username) > maxUsernameLen || len(password) > MaxPasswordLength {
+		return nil, ErrIPMIClient{Message: "username or password is too long for IPMI v2.0"}
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", address)
+	if err != nil {
+		return nil, ErrIPMIClient{Message: err.Error()}
+	}
+
+	s := &session{
+		conn:     conn,
+		timeout:  timeout,
+		retries:  defaultPacketRetry,
+		username: []byte(username),
+		password: []byte(password),
+	}
+	if err = s.open(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if _, err = s.command(ctx, netFnApp, cmdSetSessionPrivilege, []byte{privAdmin}); err != nil {
+		s.close(ctx)
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *session) open(ctx context.Context) error {
+	consoleID := make([]byte, 4)
+	if _, err := rand.Read(consoleID); err != nil {
+		return err
+	}
+	s.consoleID = binary.LittleEndian.Uint32(consoleID)
+
+	// Open Session Request
+	req := []byte{0x00, privAdmin, 0x00, 0x00}
+	req = append(req, consoleID...)
+	req = append(req,
+		0x00, 0x00, 0x00, 0x08, algRAKPHMACSHA1, 0x00, 0x00, 0x00,
+		0x01, 0x00, 0x00, 0x08, algHMACSHA196, 0x00, 0x00, 0x00,
+		0x02, 0x00, 0x00, 0x08, algAESCBC128, 0x00, 0x00, 0x00)
+	resp, err := s.exchangeUnauthenticated(ctx, payloadOpenSessionRequest, payloadOpenSessionResponse, req)
+	if err != nil {
+		return err
+	}
+	if len(resp) < 36 || resp[1] != 0 {
+		return sessionError("open session", resp)
+	}
+	if !bytes.Equal(resp[4:8], consoleID) {
+		return ErrIPMIClient{Message: "open session response has unexpected console session id"}
+	}
+	s.bmcID = binary.LittleEndian.Uint32(resp[8:12])
+	bmcID := resp[8:12]
+
+	// RAKP Message 1
+	consoleRand := make([]byte, randomLength)
+	if _, err = rand.Read(consoleRand); err != nil {
+		return err
+	}
+	role := byte(privAdmin | nameOnlyLookup)
+	req = []byte{0x00, 0x00, 0x00, 0x00}
+	req = append(req, bmcID...)
+	req = append(req, consoleRand...)
+	req = append(req, role, 0x00, 0x00, byte(len(s.username)))
+	req = append(req, s.username...)
+	resp, err = s.exchangeUnauthenticated(ctx, payloadRAKP1, payloadRAKP2, req)
+	if err != nil {
+		return err
+	}
+	if len(resp) < 40+sha1Length || resp[1] != 0 {
+		return sessionError("RAKP message 2", resp)
+	}
+	bmcRand := resp[8:24]
+	guid := resp[24:40]
+	expected := hmacSHA1(s.password, consoleID, bmcID, consoleRand, bmcRand, guid,
+		[]byte{role, byte(len(s.username))}, s.username)
+	if !hmac.Equal(expected, resp[40:40+sha1Length]) {
+		return ErrIPMIClient{Message: "BMC authentication failed, check username and password"}
+	}
+
+	sik := hmacSHA1(s.password, consoleRand, bmcRand, []byte{role, byte(len(s.username))}, s.username)
+
+	// RAKP Message 3
+	req = []byte{0x00, 0x00, 0x00, 0x00}
+	req = append(req, bmcID...)
+	req = append(req, hmacSHA1(s.password, bmcRand, consoleID, []byte{role, byte(len(s.username))}, s.username)...)
+	resp, err = s.exchangeUnauthenticated(ctx, payloadRAKP3, payloadRAKP4, req)
+	if err != nil {
+		return err
+	}
+	if len(resp) < 8+authCodeLength || resp[1] != 0 {
+		return sessionError("RAKP message 4", resp)
+	}
+	if !hmac.Equal(hmacSHA1(sik, consoleRand, bmcID, guid)[:authCodeLength], resp[8:8+authCodeLength]) {
+		return ErrIPMIClient{Message: "BMC integrity check of RAKP message 4 failed"}
+	}
+
+	s.k1 = hmacSHA1(sik, bytes.Repeat([]byte{0x01}, sha1Length))
+	s.aesKey = hmacSHA1(sik, bytes.Repeat([]byte{0x02}, sha1Length))[:aes.BlockSize]
+	s.seq = 0
+	log.Debugf("Established IPMI session '%x' with BMC '%s'.", s.bmcID, s.conn.RemoteAddr())
+	return nil
+}
+
+// command sends IPMI request within the session and returns the response data, if BMC responds with non-zero
+// completion code error is returned
+func (s *session) command(ctx context.Context, netFn, cmd byte, data []byte) ([]byte, error) {
+	s.rqSeq = (s.rqSeq + 1) & 0x3f
+	rqSeq := s.rqSeq
+	msg := encodeRequest(netFn, cmd, rqSeq, data)
+
+	var resp []byte
+	err := s.exchange(ctx, func() ([]byte, error) {
+		s.seq++
+		payload, err := encrypt(s.aesKey, msg)
+		if err != nil {
+			return nil, err
+		}
+		return encodePacket(payloadEncrypt|payloadAuth|payloadIPMI, s.bmcID, s.seq, payload, s.k1), nil
+	}, func(packet []byte) bool {
+		payloadType, sessionID, payload, ok := decodePacket(packet, s.k1)
+		if !ok || payloadType != payloadEncrypt|payloadAuth|payloadIPMI || sessionID != s.consoleID {
+			return false
+		}
+		plain, err := decrypt(s.aesKey, payload)
+		if err != nil {
+			return false
+		}
+		resp, ok = decodeResponse(plain, netFn, cmd, rqSeq)
+		return ok
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp[0] != completionCodeOK {
+		return nil, ErrCompletionCode{NetFn: netFn, Command: cmd, Code: resp[0]}
+	}
+	return resp[1:], nil
+}
+
+// close closes the session on BMC side and the connection
+func (s *session) close(ctx context.Context) {
+	sessionID := make([]byte, 4)
+	binary.LittleEndian.PutUint32(sessionID, s.bmcID)
+	if _, err := s.command(ctx, netFnApp, cmdCloseSession, sessionID); err != nil {
+		log.Debugf("Failed to close IPMI session '%x': %v", s.bmcID, err)
+	}
+	s.conn.Close()
+}
+
+func (s *session) exchangeUnauthenticated(ctx context.Context, reqType, respType byte, req []byte) ([]byte, error) {
+	var resp []byte
+	err := s.exchange(ctx, func() ([]byte, error) {
+		return encodePacket(reqType, 0, 0, req, nil), nil
+	}, func(packet []byte) bool {
+		payloadType, _, payload, ok := decodePacket(packet, nil)
+		if !ok || payloadType != respType {
+			return false
+		}
+		resp = payload
+		return true
+	})
+	return resp, err
+}
+
+// exchange sends the packet built by encode and reads packets until accept returns true, packet is resent
+// if no acceptable response is received within the timeout
+func (s *session) exchange(ctx context.Context, encode func() ([]byte, error), accept func([]byte) bool) error {
+	buf := make([]byte, 1024)
+	for attempt := 0; attempt < s.retries; attempt++ {
+		packet, err := encode()
+		if err != nil {
+			return err
+		}
+		if _, err = s.conn.Write(packet); err != nil {
+			return ErrIPMIClient{Message: err.Error()}
+		}
+
+		deadline := time.Now().Add(s.timeout)
+		if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+			deadline = ctxDeadline
+		}
+		if err = s.conn.SetReadDeadline(deadline); err != nil {
+			return ErrIPMIClient{Message: err.Error()}
+		}
+
+		for {
+			n, readErr := s.conn.Read(buf)
+			if readErr != nil {
+				if netErr, ok := readErr.(net.Error); ok && netErr.Timeout() {
+					break
+				}
+				return ErrIPMIClient{Message: readErr.Error()}
+			}
+			if accept(buf[:n]) {
+				return nil
+			}
+		}
+
+		if ctx.Err() != nil {
+			return ErrIPMIClient{Message: ctx.Err().Error()}
+		}
+		log.Debugf("No response from BMC '%s', attempt %d of %d.", s.conn.RemoteAddr(), attempt+1, s.retries)
+	}
+	return ErrIPMIClient{Message: "no response received from BMC " + s.conn.RemoteAddr().String()}
+}
+
+// encodePacket builds RMCP+ packet, if k1 is set the integrity trailer is added
+func encodePacket(payloadType byte, sessionID, seq uint32, payload []byte, k1 []byte) []byte {
+	packet := []byte{rmcpVersion, 0x00, rmcpNoAckSeq, rmcpClassIPMI, authTypeRMCPP, payloadType}
+	packet = appendUint32(packet, sessionID)
+	packet = appendUint32(packet, seq)
+	packet = append(packet, byte(len(payload)), byte(len(payload)>>8))
+	packet = append(packet, payload...)
+
+	if payloadType&payloadAuth == 0 {
+		return packet
+	}
+	// integrity pad makes the data covered by the auth code, including pad length
+	// and next header fields, multiple of 4 bytes
+	padLen := (4 - (len(packet)-4+2)%4) % 4
+	packet = append(packet, bytes.Repeat([]byte{0xff}, padLen)...)
+	packet = append(packet, byte(padLen), rmcpClassIPMI)
+	return append(packet, hmacSHA1(k1, packet[4:])[:authCodeLength]...)
+}
+
+// decodePacket parses RMCP+ packet and verifies its integrity if k1 is set
+func decodePacket(packet []byte, k1 []byte) (payloadType byte, sessionID uint32, payload []byte, ok bool) {
+	if len(packet) < sessionHeaderLen || packet[0] != rmcpVersion || packet[3] != rmcpClassIPMI ||
+		packet[4] != authTypeRMCPP {
+		return 0, 0, nil, false
+	}
+	payloadType = packet[5]
+	sessionID = binary.LittleEndian.Uint32(packet[6:10])
+	length := int(binary.LittleEndian.Uint16(packet[14:16]))
+	if len(packet) < sessionHeaderLen+length {
+		return 0, 0, nil, false
+	}
+	payload = packet[sessionHeaderLen : sessionHeaderLen+length]
+
+	if payloadType&payloadAuth != 0 {
+		if k1 == nil || len(packet) < sessionHeaderLen+length+2+authCodeLength {
+			return 0, 0, nil, false
+		}
+		authCodeStart := len(packet) - authCodeLength
+		if !hmac.Equal(hmacSHA1(k1, packet[4:authCodeStart])[:authCodeLength], packet[authCodeStart:]) {
+			return 0, 0, nil, false
+		}
+	}
+	return payloadType, sessionID, payload, true
+}
+
+// encodeRequest builds IPMI LAN request message
+func encodeRequest(netFn, cmd, rqSeq byte, data []byte) []byte {
+	msg := []byte{bmcSlaveAddress, netFn << 2}
+	msg = append(msg, checksum(msg), consoleSoftwareID, rqSeq<<2, cmd)
+	msg = append(msg, data...)
+	return append(msg, checksum(msg[3:]))
+}
+
+// decodeResponse parses IPMI LAN response message and returns completion code followed by response data
+func decodeResponse(msg []byte, netFn, cmd, rqSeq byte) ([]byte, bool) {
+	if len(msg) < 8 || checksum(msg[:2]) != msg[2] || checksum(msg[3:len(msg)-1]) != msg[len(msg)-1] {
+		return nil, false
+	}
+	if msg[0] != consoleSoftwareID || msg[1]>>2 != netFn|1 || msg[4]>>2 != rqSeq || msg[5] != cmd {
+		return nil, false
+	}
+	return msg[6 : len(msg)-1], true
+}
+
+func encrypt(key, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	padLen := (aes.BlockSize - (len(data)+1)%aes.BlockSize) % aes.BlockSize
+	plain := append([]byte{}, data...)
+	for i := 1; i <= padLen; i++ {
+		plain = append(plain, byte(i))
+	}
+	plain = append(plain, byte(padLen))
+
+	out := make([]byte, aes.BlockSize+len(plain))
+	if _, err = rand.Read(out[:aes.BlockSize]); err != nil {
+		return nil, err
+	}
+	cipher.NewCBCEncrypter(block, out[:aes.BlockSize]).CryptBlocks(out[aes.BlockSize:], plain)
+	return out, nil
+}
+
+func decrypt(key, data []byte) ([]byte, error) {
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil, ErrIPMIClient{Message: "malformed encrypted payload"}
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	plain := make([]byte, len(data)-aes.BlockSize)
+	cipher.NewCBCDecrypter(block, data[:aes.BlockSize]).CryptBlocks(plain, data[aes.BlockSize:])
+
+	padLen := int(plain[len(plain)-1])
+	if padLen >= aes.BlockSize || padLen+1 > len(plain) {
+		return nil, ErrIPMIClient{Message: "malformed encrypted payload padding"}
+	}
+	return plain[:len(plain)-1-padLen], nil
+}
+
+func hmacSHA1(key []byte, data ...[]byte) []byte {
+	mac := hmac.New(sha1.New, key)
+	for _, d := range data {
+		mac.Write(d) //nolint:errcheck
+	}
+	return mac.Sum(nil)
+}
+
+func checksum(data []byte) byte {
+	var sum byte
+	for _, b := range data {
+		sum += b
+	}
+	return -sum
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func sessionError(stage string, resp []byte) error {
+	if len(resp) < 2 {
+		return ErrIPMIClient{Message: "malformed " + stage + " response"}
+	}
+	return ErrSessionStatus{Stage: stage, Status: resp[1]}
+}
@@ -0,0 +1,56 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package isoserver
+
+import (
+	"fmt"
+)
+
+// ErrISONotFile is returned if ISO image path is not a regular file
+type ErrISONotFile struct {
+	Path string
+}
+
+func (e ErrISONotFile) Error() string {
+	return fmt.Sprintf("ISO image '%s' is not a file", e.Path)
+}
+
+// ErrAdvertisedAddressUnknown is returned if the address BMC can reach the ISO server at can't be determined
+type ErrAdvertisedAddressUnknown struct {
+	ListenAddress string
+}
+
+func (e ErrAdvertisedAddressUnknown) Error() string {
+	return fmt.Sprintf("unable to determine address of ISO server listening on '%s' that is reachable by BMC, "+
+		"specify the address of the interface to listen on", e.ListenAddress)
+}
+
+// ErrISOInUse is returned if the host didn't stop reading ISO image before the timeout
+type ErrISOInUse struct {
+	URL string
+}
+
+func (e ErrISOInUse) Error() string {
+	return fmt.Sprintf("timed out waiting for the host to finish reading ISO image at '%s'", e.URL)
+}
+
+// ErrISONotRequested is returned if the host didn't request ISO image before the timeout
+type ErrISONotRequested struct {
+	URL string
+}
+
+func (e ErrISONotRequested) Error() string {
+	return fmt.Sprintf("timed out waiting for the host to request ISO image at '%s'", e.URL)
+}
@@ -0,0 +1,202 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package isoserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	// DefaultListenAddress makes the server listen on all interfaces on a random port
+	DefaultListenAddress = ":0"
+
+	shutdownTimeout = 10 * time.Second
+	maxPollInterval = time.Second
+)
+
+// Server serves single ISO image over HTTP, so that BMC of the host can attach it as virtual media
+// without a separate web server. Range requests are supported, BMCs read virtual media in chunks.
+type Server struct {
+	isoPath  string
+	url      string
+	listener net.Listener
+	srv      *http.Server
+
+	mu           sync.Mutex
+	active       int
+	lastActivity time.Time
+}
+
+// Start starts serving the ISO image at isoPath on listenAddress. The URL of the image is built from
+// the host of listenAddress, if it listens on all interfaces the address of the interface used to reach
+// bmcAddress is used instead.
+func Start(isoPath, listenAddress, bmcAddress string) (*Server, error) {
+	info, err := os.Stat(isoPath)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, ErrISONotFile{Path: isoPath}
+	}
+
+	if listenAddress == "" {
+		listenAddress = DefaultListenAddress
+	}
+	listener, err := net.Listen("tcp", listenAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	host, err := advertisedHost(listener.Addr().(*net.TCPAddr), bmcAddress)
+	if err != nil {
+		listener.Close() //nolint:errcheck
+		return nil, err
+	}
+
+	name := filepath.Base(isoPath)
+	s := &Server{
+		isoPath:  isoPath,
+		listener: listener,
+		url: (&url.URL{
+			Scheme: "http",
+			Host:   net.JoinHostPort(host, fmt.Sprint(listener.Addr().(*net.TCPAddr).Port)),
+			Path:   "/" + name,
+		}).String(),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+name, s.serveISO)
+	s.srv = &http.Server{Handler: mux}
+	go func() {
+		if serveErr := s.srv.Serve(listener); serveErr != nil && serveErr != http.ErrServerClosed {
+			log.Printf("ISO server at '%s' failed: %v", s.url, serveErr)
+		}
+	}()
+
+	log.Debugf("Serving ISO image '%s' at '%s'", isoPath, s.url)
+	return s, nil
+}
+
+// URL returns URL the ISO image is served at
+func (s *Server) URL() string {
+	return s.url
+}
+
+// WaitIdle waits until the image was not requested for the idle period, counting from the moment
+// WaitIdle is called or the last request was served, whichever is later. The countdown doesn't start
+// until the first request is served, so WaitIdle waits for ctx if the image is never requested.
+// It is used to detect that the host has finished booting from the image.
+func (s *Server) WaitIdle(ctx context.Context, idle time.Duration) error {
+	waitStart := time.Now()
+	pollInterval := idle / 10
+	if pollInterval > maxPollInterval {
+		pollInterval = maxPollInterval
+	}
+
+	for {
+		s.mu.Lock()
+		active, lastActivity := s.active, s.lastActivity
+		s.mu.Unlock()
+
+		requested := !lastActivity.IsZero()
+		if lastActivity.Before(waitStart) {
+			lastActivity = waitStart
+		}
+		if requested && active == 0 && time.Since(lastActivity) >= idle {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if !requested {
+				return ErrISONotRequested{URL: s.url}
+			}
+			return ErrISOInUse{URL: s.url}
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// Close stops the server, requests being served are given some time to finish
+func (s *Server) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	log.Debugf("Stopping ISO server at '%s'", s.url)
+	return s.srv.Shutdown(ctx)
+}
+
+func (s *Server) serveISO(w http.ResponseWriter, r *http.Request) {
+	s.track(1)
+	defer s.track(-1)
+
+	log.Debugf("ISO server: %s %s range '%s' from '%s'", r.Method, r.URL.Path, r.Header.Get("Range"), r.RemoteAddr)
+	iso, err := os.Open(s.isoPath)
+	if err != nil {
+		log.Printf("ISO server failed to open '%s': %v", s.isoPath, err)
+		http.Error(w, "unable to open ISO image", http.StatusInternalServerError)
+		return
+	}
+	defer iso.Close()
+
+	info, err := iso.Stat()
+	if err != nil {
+		http.Error(w, "unable to open ISO image", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), iso)
+}
+
+func (s *Server) track(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active += delta
+	s.lastActivity = time.Now()
+}
+
+// advertisedHost returns host that BMC should use to reach the server, if the server listens on all
+// interfaces it is the local address of the interface that routes to the BMC
+func advertisedHost(listenAddr *net.TCPAddr, bmcAddress string) (string, error) {
+	if !listenAddr.IP.IsUnspecified() {
+		return listenAddr.IP.String(), nil
+	}
+
+	bmcHost := ""
+	if parsed, err := url.Parse(bmcAddress); err == nil {
+		bmcHost = parsed.Hostname()
+	}
+	if bmcHost == "" {
+		return "", ErrAdvertisedAddressUnknown{ListenAddress: listenAddr.String()}
+	}
+
+	// UDP dial doesn't send any packets, it only selects the route to the BMC
+	conn, err := net.Dial("udp", net.JoinHostPort(bmcHost, "443"))
+	if err != nil {
+		log.Debugf("Unable to find route to BMC '%s': %v", bmcHost, err)
+		return "", ErrAdvertisedAddressUnknown{ListenAddress: listenAddr.String()}
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).IP.String(), nil
+}
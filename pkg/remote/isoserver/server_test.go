@@ -0,0 +1,138 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package isoserver_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/isoserver"
+)
+
+const isoContent = "ephemeral ISO image content"
+
+func writeISO(t *testing.T) (string, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "airshipctl-isoserver-test-")
+	require.NoError(t, err)
+	isoPath := filepath.Join(dir, "ephemeral.iso")
+	require.NoError(t, ioutil.WriteFile(isoPath, []byte(isoContent), 0600))
+	return isoPath, func() { os.RemoveAll(dir) }
+}
+
+func TestServer(t *testing.T) {
+	isoPath, cleanup := writeISO(t)
+	defer cleanup()
+
+	srv, err := isoserver.Start(isoPath, "127.0.0.1:0", "")
+	require.NoError(t, err)
+	defer srv.Close()
+	assert.True(t, strings.HasPrefix(srv.URL(), "http://127.0.0.1:"))
+	assert.True(t, strings.HasSuffix(srv.URL(), "/ephemeral.iso"))
+
+	t.Run("full image", func(t *testing.T) {
+		resp, err := http.Get(srv.URL())
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, isoContent, string(body))
+	})
+
+	t.Run("range", func(t *testing.T) {
+		req, err := http.NewRequest(http.MethodGet, srv.URL(), nil)
+		require.NoError(t, err)
+		req.Header.Set("Range", "bytes=10-12")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusPartialContent, resp.StatusCode)
+		assert.Equal(t, "bytes 10-12/27", resp.Header.Get("Content-Range"))
+		assert.Equal(t, "ISO", string(body))
+	})
+
+	t.Run("unknown path", func(t *testing.T) {
+		resp, err := http.Get(strings.TrimSuffix(srv.URL(), "ephemeral.iso") + "other.iso")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestStartAdvertisedAddress(t *testing.T) {
+	isoPath, cleanup := writeISO(t)
+	defer cleanup()
+
+	srv, err := isoserver.Start(isoPath, ":0", "redfish+https://127.0.0.1/redfish/v1/Systems/1")
+	require.NoError(t, err)
+	defer srv.Close()
+	assert.True(t, strings.HasPrefix(srv.URL(), "http://127.0.0.1:"))
+
+	_, err = isoserver.Start(isoPath, ":0", "")
+	assert.IsType(t, isoserver.ErrAdvertisedAddressUnknown{}, err)
+}
+
+func TestStartError(t *testing.T) {
+	isoPath, cleanup := writeISO(t)
+	defer cleanup()
+
+	_, err := isoserver.Start(filepath.Join(filepath.Dir(isoPath), "missing.iso"), "127.0.0.1:0", "")
+	assert.True(t, os.IsNotExist(err))
+
+	_, err = isoserver.Start(filepath.Dir(isoPath), "127.0.0.1:0", "")
+	assert.Equal(t, isoserver.ErrISONotFile{Path: filepath.Dir(isoPath)}, err)
+}
+
+func TestWaitIdle(t *testing.T) {
+	isoPath, cleanup := writeISO(t)
+	defer cleanup()
+
+	srv, err := isoserver.Start(isoPath, "127.0.0.1:0", "")
+	require.NoError(t, err)
+	defer srv.Close()
+
+	// idle countdown doesn't start until the image is requested
+	notRequestedCtx, notRequestedCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer notRequestedCancel()
+	assert.Equal(t, isoserver.ErrISONotRequested{URL: srv.URL()}, srv.WaitIdle(notRequestedCtx, time.Millisecond))
+
+	start := time.Now()
+	go func() {
+		for i := 0; i < 3; i++ {
+			time.Sleep(20 * time.Millisecond)
+			if resp, getErr := http.Get(srv.URL()); getErr == nil {
+				resp.Body.Close()
+			}
+		}
+	}()
+	require.NoError(t, srv.WaitIdle(context.Background(), 50*time.Millisecond))
+	assert.True(t, time.Since(start) >= 110*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, isoserver.ErrISOInUse{URL: srv.URL()}, srv.WaitIdle(ctx, time.Second))
+}
@@ -0,0 +1,121 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	endpointAccounts = "/redfish/v1/AccountService/Accounts"
+)
+
+type managerAccount struct {
+	UserName string `json:"UserName"`
+}
+
+type managerAccountPatch struct {
+	Password string `json:"Password"`
+}
+
+// UpdatePassword changes password of the BMC account the client authenticates with through Redfish
+// AccountService, the client switches to the new password once it is accepted by the BMC
+func (c *Client) UpdatePassword(ctx context.Context, newPassword string) error {
+	if newPassword == "" {
+		return ErrRedfishMissingConfig{What: "new password"}
+	}
+
+	accountURI, err := c.accountURI(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Updating password of BMC account '%s' of node '%s'.", c.username, c.nodeID)
+	_, err = sendRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodPatch, accountURI,
+		managerAccountPatch{Password: newPassword})
+	if err != nil {
+		return err
+	}
+
+	c.password = newPassword
+	if c.session != nil {
+		c.session.setCredentials(c.username, newPassword)
+	}
+	return nil
+}
+
+// VerifyCredentials logs in to the BMC with given credentials and requests the system resource of the node,
+// the session created for the check is deleted afterwards
+func (c *Client) VerifyCredentials(ctx context.Context, username, password string) error {
+	if c.transport == nil {
+		return ErrRedfishMissingConfig{What: "HTTP transport"}
+	}
+
+	systemURL, err := url.Parse(c.redfishURL)
+	if err != nil {
+		return ErrRedfishClient{Message: fmt.Sprintf("Redfish URL malformed %s", err.Error())}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.RedfishCFG.BasePath+systemURL.Path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	verifier := newSessionTransport(c.transport, username, password)
+	defer func() {
+		if closeErr := verifier.close(); closeErr != nil {
+			log.Debugf("Failed to delete session of credentials check: %v", closeErr)
+		}
+	}()
+
+	resp, err := (&http.Client{Transport: verifier}).Do(req)
+	if err != nil {
+		return ErrRedfishClient{Message: fmt.Sprintf("Unable to verify credentials of node '%s'. %v", c.nodeID, err)}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return ErrCredentialsRejected{NodeID: c.nodeID, Username: username}
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return ErrRedfishClient{Message: fmt.Sprintf("Unable to verify credentials of node '%s'. "+
+			"BMC returned status '%s'.", c.nodeID, resp.Status)}
+	}
+	return nil
+}
+
+// accountURI returns URI of the AccountService account the client authenticates with
+func (c *Client) accountURI(ctx context.Context) (string, error) {
+	var accounts collection
+	if err := c.getResource(ctx, endpointAccounts, &accounts); err != nil {
+		return "", err
+	}
+
+	for _, member := range accounts.Members {
+		var account managerAccount
+		if err := c.getResource(ctx, member.OdataID, &account); err != nil {
+			return "", err
+		}
+		if account.UserName == c.username {
+			return member.OdataID, nil
+		}
+	}
+	return "", ErrRedfishClient{Message: fmt.Sprintf("BMC account '%s' of node '%s' not found in AccountService",
+		c.username, c.nodeID)}
+}
@@ -0,0 +1,127 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+// accountBMC is a fake BMC with Redfish AccountService and SessionService, all its accounts share the password
+type accountBMC struct {
+	mu       sync.Mutex
+	password string
+	patches  int
+	sessions map[string]bool
+}
+
+func (b *accountBMC) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if r.URL.Path == endpointSessions && r.Method == http.MethodPost {
+		req := sessionRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password != b.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		token := "token-" + req.Password
+		b.sessions[token] = true
+		w.Header().Set(headerAuthToken, token)
+		w.Header().Set("Location", endpointSessions+"/"+token)
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	if !b.sessions[r.Header.Get(headerAuthToken)] {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodDelete:
+		delete(b.sessions, r.Header.Get(headerAuthToken))
+	case r.Method == http.MethodGet && r.URL.Path == endpointAccounts:
+		_, _ = w.Write([]byte(`{"Members": [{"@odata.id": "/redfish/v1/AccountService/Accounts/1"},
+			{"@odata.id": "/redfish/v1/AccountService/Accounts/2"}]}`))
+	case r.Method == http.MethodGet && r.URL.Path == endpointAccounts+"/1":
+		_, _ = w.Write([]byte(`{"UserName": "operator"}`))
+	case r.Method == http.MethodGet && r.URL.Path == endpointAccounts+"/2":
+		_, _ = w.Write([]byte(`{"UserName": "admin"}`))
+	case r.Method == http.MethodPatch && r.URL.Path == endpointAccounts+"/2":
+		req := managerAccountPatch{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b.password = req.Password
+		b.patches++
+		// BMC terminates all sessions of the account once its password is changed
+		b.sessions = map[string]bool{}
+		w.WriteHeader(http.StatusNoContent)
+	case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/Systems/1":
+		_, _ = w.Write([]byte(`{"Id": "1"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	bmc := &accountBMC{password: "old", sessions: map[string]bool{}}
+	srv := httptest.NewServer(bmc)
+	defer srv.Close()
+
+	ctx := context.Background()
+	t.Run("success", func(t *testing.T) {
+		client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "admin", "old", 1, 1)
+		require.NoError(t, err)
+
+		require.NoError(t, ifc.UpdatePassword(ctx, client, "new"))
+		assert.Equal(t, "new", bmc.password)
+		assert.Equal(t, 1, bmc.patches)
+
+		require.NoError(t, ifc.VerifyCredentials(ctx, client, "admin", "new"))
+		assert.Equal(t, ErrCredentialsRejected{NodeID: "1", Username: "admin"},
+			ifc.VerifyCredentials(ctx, client, "admin", "old"))
+
+		// client keeps working after the BMC terminated its session
+		components := collection{}
+		require.NoError(t, client.getResource(ctx, endpointAccounts, &components))
+		assert.Len(t, components.Members, 2)
+		require.NoError(t, client.CloseSession(ctx))
+		assert.Empty(t, bmc.sessions)
+	})
+
+	t.Run("error account not found", func(t *testing.T) {
+		client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "root", "new", 1, 1)
+		require.NoError(t, err)
+		err = client.UpdatePassword(ctx, "newer")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "BMC account 'root' of node '1' not found")
+	})
+
+	t.Run("error empty password", func(t *testing.T) {
+		client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "admin", "new", 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, ErrRedfishMissingConfig{What: "new password"}, client.UpdatePassword(ctx, ""))
+	})
+}
@@ -0,0 +1,82 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redfish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	endpointBios = endpointSystem + "/Bios"
+
+	applyTimeOnReset = "OnReset"
+)
+
+type biosResource struct {
+	Attributes map[string]interface{} `json:"Attributes"`
+	Settings   struct {
+		SettingsObject      odataID  `json:"SettingsObject"`
+		SupportedApplyTimes []string `json:"SupportedApplyTimes"`
+	} `json:"@Redfish.Settings"`
+}
+
+type biosSettingsRequest struct {
+	Attributes map[string]interface{} `json:"Attributes"`
+	ApplyTime  *settingsApplyTime     `json:"@Redfish.SettingsApplyTime,omitempty"`
+}
+
+type settingsApplyTime struct {
+	ApplyTime string `json:"ApplyTime"`
+}
+
+// BIOSAttributes returns current BIOS attributes of the host from Redfish Bios resource
+func (c *Client) BIOSAttributes(ctx context.Context) (map[string]interface{}, error) {
+	var bios biosResource
+	if err := c.getResource(ctx, fmt.Sprintf(endpointBios, c.nodeID), &bios); err != nil {
+		return nil, err
+	}
+	return bios.Attributes, nil
+}
+
+// SetBIOSAttributes patches pending settings object of Redfish Bios resource with new attribute values,
+// the values are applied by the host firmware on the next reboot
+func (c *Client) SetBIOSAttributes(ctx context.Context, attributes map[string]interface{}) error {
+	biosURI := fmt.Sprintf(endpointBios, c.nodeID)
+	var bios biosResource
+	if err := c.getResource(ctx, biosURI, &bios); err != nil {
+		return err
+	}
+
+	settingsURI := bios.Settings.SettingsObject.OdataID
+	if settingsURI == "" {
+		settingsURI = biosURI + "/Settings"
+	}
+
+	req := biosSettingsRequest{Attributes: attributes}
+	// Some BMCs (e.g. iDRAC) discard pending settings unless they are explicitly scheduled
+	// to be applied on the next reset of the host
+	for _, applyTime := range bios.Settings.SupportedApplyTimes {
+		if applyTime == applyTimeOnReset {
+			req.ApplyTime = &settingsApplyTime{ApplyTime: applyTimeOnReset}
+			break
+		}
+	}
+
+	log.Debugf("Scheduling BIOS attributes %v of node '%s'", attributes, c.nodeID)
+	_, err := SendRawRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodPatch, settingsURI, req)
+	return err
+}
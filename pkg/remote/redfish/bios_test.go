@@ -0,0 +1,120 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBiosBMC returns BMC serving Bios resource with the given body and recording PATCH requests to
+// the settings objects
+func newBiosBMC(t *testing.T, bios []byte, patched map[string]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/redfish/v1/Systems/1/Bios":
+			_, err := w.Write(bios)
+			require.NoError(t, err)
+		case r.Method == http.MethodPatch:
+			body := map[string]interface{}{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			patched[r.URL.Path] = body
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestBIOSAttributes(t *testing.T) {
+	bios, err := ioutil.ReadFile("testdata/bios.json")
+	require.NoError(t, err)
+	srv := newBiosBMC(t, bios, map[string]map[string]interface{}{})
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "", "", 1, 1)
+	require.NoError(t, err)
+
+	attributes, err := client.BIOSAttributes(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, attributes, 7)
+	assert.Equal(t, "Enabled", attributes["ProcTurboMode"])
+	assert.Equal(t, float64(0), attributes["ProcCoreDisable"])
+}
+
+func TestBIOSAttributesNotFound(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "", "", 1, 1)
+	require.NoError(t, err)
+
+	_, err = client.BIOSAttributes(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "GET request to '/redfish/v1/Systems/1/Bios' failed")
+}
+
+func TestSetBIOSAttributes(t *testing.T) {
+	recorded, err := ioutil.ReadFile("testdata/bios.json")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		bios            string
+		expectedURI     string
+		expectedRequest map[string]interface{}
+	}{
+		{
+			name:        "settings object with apply time",
+			bios:        string(recorded),
+			expectedURI: "/redfish/v1/Systems/1/Bios/Pending",
+			expectedRequest: map[string]interface{}{
+				"Attributes":                 map[string]interface{}{"ProcTurboMode": "Disabled"},
+				"@Redfish.SettingsApplyTime": map[string]interface{}{"ApplyTime": "OnReset"},
+			},
+		},
+		{
+			name:        "default settings object",
+			bios:        `{"Attributes": {"ProcTurboMode": "Enabled"}}`,
+			expectedURI: "/redfish/v1/Systems/1/Bios/Settings",
+			expectedRequest: map[string]interface{}{
+				"Attributes": map[string]interface{}{"ProcTurboMode": "Disabled"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			patched := map[string]map[string]interface{}{}
+			srv := newBiosBMC(t, []byte(tt.bios), patched)
+			defer srv.Close()
+
+			client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "", "", 1, 1)
+			require.NoError(t, err)
+
+			err = client.SetBIOSAttributes(context.Background(), map[string]interface{}{"ProcTurboMode": "Disabled"})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]map[string]interface{}{tt.expectedURI: tt.expectedRequest}, patched)
+		})
+	}
+}
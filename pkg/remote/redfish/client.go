@@ -15,6 +15,7 @@ package redfish
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net/http"
 	"strings"
@@ -43,6 +44,8 @@ type Client struct {
 	RedfishCFG          *redfishClient.Configuration
 	systemActionRetries int
 	systemRebootDelay   int
+	session             *sessionTransport
+	transport           *http.Transport
 
 	// Sleep is meant to be mocked out for tests
 	Sleep func(d time.Duration)
@@ -273,6 +276,95 @@ func (c *Client) SystemPowerStatus(ctx context.Context) (power.Status, error) {
 	}
 }
 
+// SystemPowerCycle power cycles a host using single reset request and waits for it to power on.
+func (c *Client) SystemPowerCycle(ctx context.Context) error {
+	log.Debugf("Power cycling node '%s'.", c.nodeID)
+	ctx = SetAuth(ctx, c.username, c.password)
+	resetReq := redfishClient.ResetRequestBody{}
+	resetReq.ResetType = redfishClient.RESETTYPE_POWER_CYCLE
+
+	_, httpResp, err := c.RedfishAPI.ResetSystem(ctx, c.nodeID, resetReq)
+	if err = ScreenRedfishError(httpResp, err); err != nil {
+		log.Debugf("Failed to power cycle node '%s'.", c.nodeID)
+		return err
+	}
+
+	return c.waitForPowerState(ctx, redfishClient.POWERSTATE_ON)
+}
+
+// SetBootDevice sets the device host boots from, if persistent is false the host boots from
+// the device only once, after that boot order defined in host BIOS is used.
+func (c *Client) SetBootDevice(ctx context.Context, device ifc.BootDevice, persistent bool) error {
+	ctx = SetAuth(ctx, c.username, c.password)
+	bootSource, err := toBootSource(device)
+	if err != nil {
+		return err
+	}
+
+	system, httpResp, err := c.RedfishAPI.GetSystem(ctx, c.nodeID)
+	if err = ScreenRedfishError(httpResp, err); err != nil {
+		return err
+	}
+
+	allowableValues := system.Boot.BootSourceOverrideTargetRedfishAllowableValues
+	if len(allowableValues) != 0 && !isBootSourceAllowed(allowableValues, bootSource) {
+		return ErrRedfishClient{Message: fmt.Sprintf("boot device '%s' is not supported by system[%s]",
+			device, c.nodeID)}
+	}
+
+	enabled := redfishClient.BOOTSOURCEOVERRIDEENABLED_ONCE
+	if persistent {
+		enabled = redfishClient.BOOTSOURCEOVERRIDEENABLED_CONTINUOUS
+	}
+
+	log.Debugf("Setting boot device of node '%s' to '%s' (%s).", c.nodeID, bootSource, enabled)
+	systemReq := redfishClient.ComputerSystem{}
+	systemReq.Boot.BootSourceOverrideTarget = bootSource
+	systemReq.Boot.BootSourceOverrideEnabled = enabled
+	_, httpResp, err = c.RedfishAPI.SetSystem(ctx, c.nodeID, systemReq)
+	if err = ScreenRedfishError(httpResp, err); err != nil {
+		return err
+	}
+
+	log.Debug("Successfully set boot device.")
+	return nil
+}
+
+// WaitForPowerState waits until host reaches desired power state, only on and off states are supported.
+func (c *Client) WaitForPowerState(ctx context.Context, desiredState power.Status) error {
+	ctx = SetAuth(ctx, c.username, c.password)
+	switch desiredState {
+	case power.StatusOn:
+		return c.waitForPowerState(ctx, redfishClient.POWERSTATE_ON)
+	case power.StatusOff:
+		return c.waitForPowerState(ctx, redfishClient.POWERSTATE_OFF)
+	default:
+		return ErrRedfishClient{Message: fmt.Sprintf("unable to wait for power state '%s'", desiredState)}
+	}
+}
+
+// CloseSession deletes the Redfish session created by the client, it should be called once the operation
+// against the host is finished. The session is deleted with its own short timeout rather than the given context,
+// which may be already expired by the time the operation is finished.
+func (c *Client) CloseSession(_ context.Context) error {
+	if c.session == nil {
+		return nil
+	}
+	return c.session.close()
+}
+
+// SetRootCAs makes the client verify BMC certificate against certificate authorities from the pool instead
+// of the system ones. Certificate verification is enabled even if the client was created as insecure.
+func (c *Client) SetRootCAs(pool *x509.CertPool) error {
+	if c.transport == nil {
+		return ErrRedfishMissingConfig{What: "HTTP transport"}
+	}
+	c.transport.TLSClientConfig = &tls.Config{
+		RootCAs: pool,
+	}
+	return nil
+}
+
 // RemoteDirect implements remote direct interface
 func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
 	return RemoteDirect(ctx, isoURL, c.redfishURL, c)
@@ -361,8 +453,10 @@ func NewClient(redfishURL string,
 		transport.Proxy = nil
 	}
 
+	// Requests are authenticated with Redfish session token when BMC supports SessionService
+	session := newSessionTransport(transport, username, password)
 	cfg.HTTPClient = &http.Client{
-		Transport: transport,
+		Transport: session,
 	}
 
 	// Retrieve system ID from end of Redfish URL
@@ -380,6 +474,8 @@ func NewClient(redfishURL string,
 		password:            password,
 		username:            username,
 		redfishURL:          redfishURL,
+		session:             session,
+		transport:           transport,
 
 		Sleep: func(d time.Duration) {
 			time.Sleep(d)
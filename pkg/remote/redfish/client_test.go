@@ -16,7 +16,9 @@ package redfish
 
 import (
 	"context"
+	"crypto/x509"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -27,6 +29,7 @@ import (
 	redfishMocks "opendev.org/airship/go-redfish/api/mocks"
 	redfishClient "opendev.org/airship/go-redfish/client"
 
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
 	"opendev.org/airship/airshipctl/pkg/remote/power"
 	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
 )
@@ -80,6 +83,31 @@ func TestNewClientEmptyRedfishURL(t *testing.T) {
 	_, err := NewClient("", false, false, "", "", systemActionRetries, systemRebootDelay)
 	assert.Error(t, err)
 }
+
+func TestSetRootCAs(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	// client is created as insecure, custom certificate authorities must enable verification
+	c, err := NewClient(redfishURL, true, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+	httpClient := &http.Client{Transport: c.transport}
+
+	require.NoError(t, ifc.SetRootCAs(c, x509.NewCertPool()))
+	_, err = httpClient.Get(srv.URL) //nolint:bodyclose
+	require.Error(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(srv.Certificate())
+	require.NoError(t, ifc.SetRootCAs(c, pool))
+	resp, err := httpClient.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	assert.Error(t, (&Client{}).SetRootCAs(pool))
+}
+
 func TestEjectVirtualMedia(t *testing.T) {
 	m := &redfishMocks.RedfishAPI{}
 	defer m.AssertExpectations(t)
@@ -777,6 +805,172 @@ func TestSystemPowerStatusGetSystemError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestSystemPowerCycle(t *testing.T) {
+	m := &redfishMocks.RedfishAPI{}
+	defer m.AssertExpectations(t)
+
+	client, err := NewClient(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	client.nodeID = nodeID
+	ctx := SetAuth(context.Background(), "", "")
+
+	resetReq := redfishClient.ResetRequestBody{}
+	resetReq.ResetType = redfishClient.RESETTYPE_POWER_CYCLE
+	m.On("ResetSystem", ctx, client.nodeID, resetReq).Times(1).Return(
+		redfishClient.RedfishError{},
+		&http.Response{StatusCode: 200}, nil)
+
+	m.On("GetSystem", ctx, client.nodeID).Return(
+		redfishClient.ComputerSystem{PowerState: redfishClient.POWERSTATE_ON},
+		&http.Response{StatusCode: 200}, nil).Times(1)
+
+	// Replace normal API client with mocked API client
+	client.RedfishAPI = m
+
+	// Mock out the Sleep function so we don't have to wait on it
+	client.Sleep = func(_ time.Duration) {}
+
+	err = client.SystemPowerCycle(ctx)
+	assert.NoError(t, err)
+}
+
+func TestSystemPowerCycleResetSystemError(t *testing.T) {
+	m := &redfishMocks.RedfishAPI{}
+	defer m.AssertExpectations(t)
+
+	client, err := NewClient(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	client.nodeID = nodeID
+	ctx := SetAuth(context.Background(), "", "")
+
+	m.On("ResetSystem", ctx, client.nodeID, mock.Anything).Return(
+		redfishClient.RedfishError{},
+		&http.Response{StatusCode: 500}, redfishClient.GenericOpenAPIError{})
+
+	// Replace normal API client with mocked API client
+	client.RedfishAPI = m
+
+	err = client.SystemPowerCycle(ctx)
+	assert.Error(t, err)
+}
+
+func TestSetBootDevice(t *testing.T) {
+	tests := []struct {
+		name            string
+		device          ifc.BootDevice
+		persistent      bool
+		allowableValues []redfishClient.BootSource
+		expectedTarget  redfishClient.BootSource
+		expectedEnabled redfishClient.BootSourceOverrideEnabled
+		expectGet       bool
+		expectSet       bool
+		expectedErr     string
+	}{
+		{
+			name:            "success pxe once",
+			device:          ifc.BootDevicePXE,
+			expectedTarget:  redfishClient.BOOTSOURCE_PXE,
+			expectedEnabled: redfishClient.BOOTSOURCEOVERRIDEENABLED_ONCE,
+			expectGet:       true,
+			expectSet:       true,
+		},
+		{
+			name:            "success disk persistent",
+			device:          ifc.BootDeviceDisk,
+			persistent:      true,
+			expectedTarget:  redfishClient.BOOTSOURCE_HDD,
+			expectedEnabled: redfishClient.BOOTSOURCEOVERRIDEENABLED_CONTINUOUS,
+			expectGet:       true,
+			expectSet:       true,
+		},
+		{
+			name:            "error boot device not allowed",
+			device:          ifc.BootDeviceCD,
+			allowableValues: []redfishClient.BootSource{redfishClient.BOOTSOURCE_PXE},
+			expectGet:       true,
+			expectedErr:     "is not supported by system",
+		},
+		{
+			name:        "error unknown boot device",
+			device:      ifc.BootDevice("floppy"),
+			expectedErr: "unknown boot device",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := &redfishMocks.RedfishAPI{}
+			defer m.AssertExpectations(t)
+
+			client, err := NewClient(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+			require.NoError(t, err)
+
+			client.nodeID = nodeID
+			ctx := SetAuth(context.Background(), "", "")
+			httpResp := &http.Response{StatusCode: 200}
+
+			system := testutil.GetTestSystem()
+			if tt.allowableValues != nil {
+				system.Boot.BootSourceOverrideTargetRedfishAllowableValues = tt.allowableValues
+			}
+			if tt.expectGet {
+				m.On("GetSystem", ctx, client.nodeID).Times(1).Return(system, httpResp, nil)
+			}
+			if tt.expectSet {
+				systemReq := redfishClient.ComputerSystem{}
+				systemReq.Boot.BootSourceOverrideTarget = tt.expectedTarget
+				systemReq.Boot.BootSourceOverrideEnabled = tt.expectedEnabled
+				m.On("SetSystem", ctx, client.nodeID, systemReq).Times(1).Return(
+					redfishClient.ComputerSystem{}, httpResp, nil)
+			}
+
+			// Replace normal API client with mocked API client
+			client.RedfishAPI = m
+
+			err = client.SetBootDevice(ctx, tt.device, tt.persistent)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWaitForPowerStatePublic(t *testing.T) {
+	m := &redfishMocks.RedfishAPI{}
+	defer m.AssertExpectations(t)
+
+	client, err := NewClient(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	client.nodeID = nodeID
+	ctx := SetAuth(context.Background(), "", "")
+
+	m.On("GetSystem", ctx, client.nodeID).Return(
+		redfishClient.ComputerSystem{PowerState: redfishClient.POWERSTATE_ON},
+		&http.Response{StatusCode: 200}, nil).Times(1)
+	m.On("GetSystem", ctx, client.nodeID).Return(
+		redfishClient.ComputerSystem{PowerState: redfishClient.POWERSTATE_OFF},
+		&http.Response{StatusCode: 200}, nil).Times(1)
+
+	// Replace normal API client with mocked API client
+	client.RedfishAPI = m
+
+	// Mock out the Sleep function so we don't have to wait on it
+	client.Sleep = func(_ time.Duration) {}
+
+	err = client.WaitForPowerState(ctx, power.StatusOff)
+	assert.NoError(t, err)
+
+	err = client.WaitForPowerState(ctx, power.StatusPoweringOn)
+	assert.Error(t, err)
+}
+
 func TestWaitForPowerStateGetSystemFailed(t *testing.T) {
 	m := &redfishMocks.RedfishAPI{}
 	defer m.AssertExpectations(t)
@@ -54,3 +54,29 @@ type ErrUnrecognizedRedfishResponse struct {
 func (e ErrUnrecognizedRedfishResponse) Error() string {
 	return fmt.Sprintf("Unable to decode Redfish response. Key '%s' is missing or has unknown format.", e.Key)
 }
+
+// ErrTaskFailed is returned if Redfish task started by an action didn't complete successfully.
+type ErrTaskFailed struct {
+	NodeID   string
+	Task     string
+	State    string
+	Messages string
+}
+
+func (e ErrTaskFailed) Error() string {
+	msg := fmt.Sprintf("task '%s' of node '%s' failed with state '%s'", e.Task, e.NodeID, e.State)
+	if e.Messages != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Messages)
+	}
+	return msg
+}
+
+// ErrCredentialsRejected is returned if BMC doesn't accept the credentials.
+type ErrCredentialsRejected struct {
+	NodeID   string
+	Username string
+}
+
+func (e ErrCredentialsRejected) Error() string {
+	return fmt.Sprintf("BMC of node '%s' rejected credentials of user '%s'", e.NodeID, e.Username)
+}
@@ -0,0 +1,175 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+const (
+	endpointFirmwareInventory = "/redfish/v1/UpdateService/FirmwareInventory"
+	endpointSimpleUpdate      = "/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate"
+
+	taskStateCompleted = "Completed"
+	taskStateException = "Exception"
+	taskStateKilled    = "Killed"
+	taskStateCancelled = "Cancelled"
+	taskStatusCritical = "Critical"
+
+	taskPollInterval = 10 * time.Second
+)
+
+type softwareInventory struct {
+	ID         string         `json:"Id"`
+	Name       string         `json:"Name"`
+	Version    string         `json:"Version"`
+	Updateable bool           `json:"Updateable"`
+	Status     resourceStatus `json:"Status"`
+}
+
+type simpleUpdateRequest struct {
+	ImageURI string `json:"ImageURI"`
+}
+
+type task struct {
+	OdataID         string `json:"@odata.id"`
+	TaskState       string `json:"TaskState"`
+	TaskStatus      string `json:"TaskStatus"`
+	PercentComplete *int   `json:"PercentComplete"`
+	Messages        []struct {
+		Message string `json:"Message"`
+	} `json:"Messages"`
+}
+
+func (t task) messages() string {
+	messages := make([]string, 0, len(t.Messages))
+	for _, msg := range t.Messages {
+		messages = append(messages, msg.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// FirmwareInventory returns firmware components of the host from Redfish UpdateService FirmwareInventory,
+// components that are not present on the host are skipped
+func (c *Client) FirmwareInventory(ctx context.Context) ([]ifc.FirmwareComponent, error) {
+	var inventory collection
+	if err := c.getResource(ctx, endpointFirmwareInventory, &inventory); err != nil {
+		return nil, err
+	}
+
+	components := []ifc.FirmwareComponent{}
+	for _, member := range inventory.Members {
+		var software softwareInventory
+		if err := c.getResource(ctx, member.OdataID, &software); err != nil {
+			return nil, err
+		}
+		if software.Status.State == stateAbsent {
+			continue
+		}
+		components = append(components, ifc.FirmwareComponent{
+			ID:         software.ID,
+			Name:       software.Name,
+			Version:    software.Version,
+			Updateable: software.Updateable,
+		})
+	}
+	return components, nil
+}
+
+// UpdateFirmware starts UpdateService.SimpleUpdate action with the image URL and follows the task monitor
+// returned by the BMC until the update is finished
+func (c *Client) UpdateFirmware(ctx context.Context, imageURL string) error {
+	if imageURL == "" {
+		return ErrRedfishMissingConfig{What: "firmware image URL"}
+	}
+
+	log.Debugf("Updating firmware of node '%s' with image '%s'.", c.nodeID, imageURL)
+	resp, err := sendRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodPost, endpointSimpleUpdate,
+		simpleUpdateRequest{ImageURI: imageURL})
+	if err != nil {
+		return err
+	}
+
+	monitorURI := taskMonitorURI(resp)
+	if monitorURI == "" {
+		log.Debugf("BMC of node '%s' didn't start a task, firmware update is finished.", c.nodeID)
+		return nil
+	}
+	return c.waitForTask(ctx, monitorURI)
+}
+
+// taskMonitorURI returns URI of the task monitor from Location header of the action response, or URI of
+// the task resource returned in the response body. Empty string means that the action is already finished.
+func taskMonitorURI(resp *rawResponse) string {
+	if location := resp.Header.Get("Location"); location != "" {
+		// Location header may hold either absolute URL or the path
+		if parsed, err := url.Parse(location); err == nil {
+			return parsed.Path
+		}
+		return location
+	}
+
+	var t task
+	if err := json.Unmarshal(resp.Body, &t); err == nil && t.TaskState != "" {
+		return t.OdataID
+	}
+	return ""
+}
+
+// waitForTask polls Redfish task monitor until the task is finished or the context is done. Task monitor
+// responds with 202 status code while the task is running, task resources report the state explicitly.
+func (c *Client) waitForTask(ctx context.Context, monitorURI string) error {
+	log.Debugf("Waiting for task '%s' of node '%s' to finish.", monitorURI, c.nodeID)
+	for {
+		resp, err := sendRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodGet, monitorURI, nil)
+		if err != nil {
+			return err
+		}
+
+		var t task
+		if len(resp.Body) != 0 {
+			if err = json.Unmarshal(resp.Body, &t); err != nil {
+				log.Debugf("Malformed Redfish response: %s", resp.Body)
+				return ErrRedfishClient{Message: fmt.Sprintf("Unable to decode task '%s'. %v", monitorURI, err)}
+			}
+		}
+
+		switch {
+		case t.TaskState == taskStateException || t.TaskState == taskStateKilled ||
+			t.TaskState == taskStateCancelled || t.TaskStatus == taskStatusCritical:
+			return ErrTaskFailed{NodeID: c.nodeID, Task: monitorURI, State: t.TaskState, Messages: t.messages()}
+		case t.TaskState == taskStateCompleted,
+			t.TaskState == "" && resp.StatusCode != http.StatusAccepted:
+			log.Debugf("Task '%s' of node '%s' is finished.", monitorURI, c.nodeID)
+			return nil
+		}
+
+		if t.PercentComplete != nil {
+			log.Debugf("Task '%s' of node '%s' is %d%% complete.", monitorURI, c.nodeID, *t.PercentComplete)
+		}
+
+		if ctx.Err() != nil {
+			return ErrRedfishClient{Message: fmt.Sprintf("task '%s' didn't finish in time. %v", monitorURI, ctx.Err())}
+		}
+		c.Sleep(taskPollInterval)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+type bmcResponse struct {
+	status   int
+	location string
+	body     string
+}
+
+// newUpdateBMC returns BMC responding to SimpleUpdate action with the action response and to any GET request
+// with the next of the task responses
+func newUpdateBMC(t *testing.T, action bmcResponse, tasks []bmcResponse, imageURI *string) *httptest.Server {
+	write := func(w http.ResponseWriter, resp bmcResponse) {
+		if resp.location != "" {
+			w.Header().Set("Location", resp.location)
+		}
+		w.WriteHeader(resp.status)
+		_, err := w.Write([]byte(resp.body))
+		require.NoError(t, err)
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == endpointSimpleUpdate:
+			req := simpleUpdateRequest{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			*imageURI = req.ImageURI
+			write(w, action)
+		case r.Method == http.MethodGet && len(tasks) != 0:
+			write(w, tasks[0])
+			tasks = tasks[1:]
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestFirmwareInventory(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/firmware.json")
+	require.NoError(t, err)
+	resources := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal(data, &resources))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, ok := resources[r.URL.Path]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err = w.Write(resource)
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "", "", 1, 1)
+	require.NoError(t, err)
+
+	components, err := client.FirmwareInventory(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []ifc.FirmwareComponent{
+		{ID: "BMC", Name: "Contoso BMC Firmware", Version: "1.45.455b66-rev4", Updateable: true},
+		{ID: "BIOS", Name: "Contoso BIOS Firmware", Version: "P79 v1.45 (12/06/2017)", Updateable: true},
+		{ID: "NIC.Slot.2", Name: "Contoso 25GbE Adapter", Version: "20.5.13"},
+	}, components)
+}
+
+func TestUpdateFirmware(t *testing.T) {
+	tests := []struct {
+		name          string
+		imageURL      string
+		action        bmcResponse
+		tasks         []bmcResponse
+		expectedSleep int
+		expectedErr   string
+	}{
+		{
+			name:     "task monitor",
+			imageURL: "http://images.example.com/bmc.bin",
+			action:   bmcResponse{status: http.StatusAccepted, location: "/redfish/v1/TaskMonitors/1"},
+			tasks: []bmcResponse{
+				{status: http.StatusAccepted, body: `{"TaskState": "Running", "PercentComplete": 10}`},
+				{status: http.StatusAccepted},
+				{status: http.StatusNoContent},
+			},
+			expectedSleep: 2,
+		},
+		{
+			name:     "task resource",
+			imageURL: "http://images.example.com/bios.bin",
+			action: bmcResponse{
+				status: http.StatusAccepted,
+				body:   `{"@odata.id": "/redfish/v1/TaskService/Tasks/2", "TaskState": "New"}`,
+			},
+			tasks: []bmcResponse{
+				{status: http.StatusOK, body: `{"TaskState": "Running"}`},
+				{status: http.StatusOK, body: `{"TaskState": "Completed", "TaskStatus": "OK"}`},
+			},
+			expectedSleep: 1,
+		},
+		{
+			name:     "finished without task",
+			imageURL: "http://images.example.com/nic.bin",
+			action:   bmcResponse{status: http.StatusNoContent},
+		},
+		{
+			name:     "task exception",
+			imageURL: "http://images.example.com/bmc.bin",
+			action:   bmcResponse{status: http.StatusAccepted, location: "/redfish/v1/TaskMonitors/3"},
+			tasks: []bmcResponse{
+				{
+					status: http.StatusOK,
+					body:   `{"TaskState": "Exception", "Messages": [{"Message": "Image signature is invalid."}]}`,
+				},
+			},
+			expectedErr: "task '/redfish/v1/TaskMonitors/3' of node '1' failed with state 'Exception': " +
+				"Image signature is invalid.",
+		},
+		{
+			name:     "action rejected",
+			imageURL: "http://images.example.com/bmc.bin",
+			action:   bmcResponse{status: http.StatusBadRequest},
+			expectedErr: "POST request to '/redfish/v1/UpdateService/Actions/UpdateService.SimpleUpdate' " +
+				"failed",
+		},
+		{
+			name:        "missing image url",
+			expectedErr: "missing configuration: firmware image URL",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var imageURI string
+			srv := newUpdateBMC(t, tt.action, tt.tasks, &imageURI)
+			defer srv.Close()
+
+			client, err := NewClient(srv.URL+"/redfish/v1/Systems/1", false, false, "", "", 1, 1)
+			require.NoError(t, err)
+			sleeps := 0
+			client.Sleep = func(time.Duration) { sleeps++ }
+
+			err = client.UpdateFirmware(context.Background(), tt.imageURL)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.imageURL, imageURI)
+			assert.Equal(t, tt.expectedSleep, sleeps)
+		})
+	}
+}
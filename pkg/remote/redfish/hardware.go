@@ -0,0 +1,337 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+const (
+	endpointSystem = "/redfish/v1/Systems/%s"
+
+	stateAbsent      = "Absent"
+	processorTypeCPU = "CPU"
+	mediaTypeHDD     = "HDD"
+	mbpsInGbps       = 1000
+	mebibytesInGiB   = 1024
+)
+
+// instructionSets maps Redfish processor instruction sets to architecture names used by metal3
+var instructionSets = map[string]string{
+	"x86":      "i686",
+	"x86-64":   "x86_64",
+	"IA-64":    "ia64",
+	"ARM-A32":  "armv7l",
+	"ARM-A64":  "aarch64",
+	"MIPS32":   "mips",
+	"MIPS64":   "mips64",
+	"PowerISA": "ppc64le",
+}
+
+type odataID struct {
+	OdataID string `json:"@odata.id"`
+}
+
+type resourceStatus struct {
+	State string `json:"State"`
+}
+
+type collection struct {
+	Members []odataID `json:"Members"`
+}
+
+type systemResource struct {
+	Manufacturer     string `json:"Manufacturer"`
+	Model            string `json:"Model"`
+	SerialNumber     string `json:"SerialNumber"`
+	BiosVersion      string `json:"BiosVersion"`
+	HostName         string `json:"HostName"`
+	ProcessorSummary struct {
+		Count                 int    `json:"Count"`
+		LogicalProcessorCount int    `json:"LogicalProcessorCount"`
+		Model                 string `json:"Model"`
+	} `json:"ProcessorSummary"`
+	MemorySummary struct {
+		TotalSystemMemoryGiB float64 `json:"TotalSystemMemoryGiB"`
+	} `json:"MemorySummary"`
+	Processors         odataID `json:"Processors"`
+	Memory             odataID `json:"Memory"`
+	EthernetInterfaces odataID `json:"EthernetInterfaces"`
+	Storage            odataID `json:"Storage"`
+	SimpleStorage      odataID `json:"SimpleStorage"`
+}
+
+type processor struct {
+	ProcessorType  string         `json:"ProcessorType"`
+	InstructionSet string         `json:"InstructionSet"`
+	Model          string         `json:"Model"`
+	MaxSpeedMHz    float64        `json:"MaxSpeedMHz"`
+	TotalCores     int            `json:"TotalCores"`
+	TotalThreads   int            `json:"TotalThreads"`
+	Status         resourceStatus `json:"Status"`
+}
+
+type memory struct {
+	CapacityMiB int            `json:"CapacityMiB"`
+	Status      resourceStatus `json:"Status"`
+}
+
+type ethernetInterface struct {
+	ID                  string `json:"Id"`
+	Name                string `json:"Name"`
+	MACAddress          string `json:"MACAddress"`
+	PermanentMACAddress string `json:"PermanentMACAddress"`
+	SpeedMbps           int    `json:"SpeedMbps"`
+	IPv4Addresses       []struct {
+		Address string `json:"Address"`
+	} `json:"IPv4Addresses"`
+	Status resourceStatus `json:"Status"`
+}
+
+type storage struct {
+	Drives []odataID `json:"Drives"`
+}
+
+type drive struct {
+	ID            string         `json:"Id"`
+	Name          string         `json:"Name"`
+	CapacityBytes int64          `json:"CapacityBytes"`
+	MediaType     string         `json:"MediaType"`
+	Manufacturer  string         `json:"Manufacturer"`
+	Model         string         `json:"Model"`
+	SerialNumber  string         `json:"SerialNumber"`
+	Status        resourceStatus `json:"Status"`
+}
+
+type simpleStorage struct {
+	Devices []drive `json:"Devices"`
+}
+
+// HardwareDetails collects hardware inventory of the host from Systems, Processors, Memory, EthernetInterfaces
+// and Storage resources of the BMC. Collections that are not exposed by the BMC are skipped.
+func (c *Client) HardwareDetails(ctx context.Context) (*ifc.HardwareDetails, error) {
+	var sys systemResource
+	if err := c.getResource(ctx, fmt.Sprintf(endpointSystem, c.nodeID), &sys); err != nil {
+		return nil, err
+	}
+
+	details := &ifc.HardwareDetails{
+		SystemVendor: ifc.HardwareSystemVendor{
+			Manufacturer: sys.Manufacturer,
+			ProductName:  sys.Model,
+			SerialNumber: sys.SerialNumber,
+		},
+		Firmware: ifc.HardwareFirmware{BIOS: ifc.HardwareBIOS{Version: sys.BiosVersion}},
+		Hostname: sys.HostName,
+		CPU: ifc.HardwareCPU{
+			Model: sys.ProcessorSummary.Model,
+			Count: sys.ProcessorSummary.LogicalProcessorCount,
+		},
+		RAMMebibytes: int(sys.MemorySummary.TotalSystemMemoryGiB * mebibytesInGiB),
+	}
+	if details.CPU.Count == 0 {
+		details.CPU.Count = sys.ProcessorSummary.Count
+	}
+
+	steps := []struct {
+		collection odataID
+		collect    func(context.Context, []odataID, *ifc.HardwareDetails) error
+	}{
+		{sys.Processors, c.collectProcessors},
+		{sys.Memory, c.collectMemory},
+		{sys.EthernetInterfaces, c.collectNICs},
+		{sys.Storage, c.collectStorage},
+	}
+	for _, step := range steps {
+		if step.collection.OdataID == "" {
+			continue
+		}
+		var members collection
+		if err := c.getResource(ctx, step.collection.OdataID, &members); err != nil {
+			return nil, err
+		}
+		if err := step.collect(ctx, members.Members, details); err != nil {
+			return nil, err
+		}
+	}
+
+	// Older BMCs expose disks through SimpleStorage only
+	if len(details.Storage) == 0 && sys.SimpleStorage.OdataID != "" {
+		if err := c.collectSimpleStorage(ctx, sys.SimpleStorage.OdataID, details); err != nil {
+			return nil, err
+		}
+	}
+
+	return details, nil
+}
+
+func (c *Client) collectProcessors(ctx context.Context, members []odataID, details *ifc.HardwareDetails) error {
+	count := 0
+	for _, member := range members {
+		var cpu processor
+		if err := c.getResource(ctx, member.OdataID, &cpu); err != nil {
+			return err
+		}
+		if cpu.Status.State == stateAbsent || (cpu.ProcessorType != "" && cpu.ProcessorType != processorTypeCPU) {
+			continue
+		}
+
+		if cpu.TotalThreads > 0 {
+			count += cpu.TotalThreads
+		} else {
+			count += cpu.TotalCores
+		}
+		if details.CPU.Arch == "" {
+			details.CPU.Arch = cpu.InstructionSet
+			if arch, ok := instructionSets[cpu.InstructionSet]; ok {
+				details.CPU.Arch = arch
+			}
+		}
+		if cpu.Model != "" {
+			details.CPU.Model = strings.TrimSpace(cpu.Model)
+		}
+		if cpu.MaxSpeedMHz > details.CPU.ClockMegahertz {
+			details.CPU.ClockMegahertz = cpu.MaxSpeedMHz
+		}
+	}
+
+	if count > 0 {
+		details.CPU.Count = count
+	}
+	return nil
+}
+
+func (c *Client) collectMemory(ctx context.Context, members []odataID, details *ifc.HardwareDetails) error {
+	total := 0
+	for _, member := range members {
+		var dimm memory
+		if err := c.getResource(ctx, member.OdataID, &dimm); err != nil {
+			return err
+		}
+		if dimm.Status.State == stateAbsent {
+			continue
+		}
+		total += dimm.CapacityMiB
+	}
+
+	if total > 0 {
+		details.RAMMebibytes = total
+	}
+	return nil
+}
+
+func (c *Client) collectNICs(ctx context.Context, members []odataID, details *ifc.HardwareDetails) error {
+	for _, member := range members {
+		var iface ethernetInterface
+		if err := c.getResource(ctx, member.OdataID, &iface); err != nil {
+			return err
+		}
+		if iface.Status.State == stateAbsent {
+			continue
+		}
+
+		nic := ifc.HardwareNIC{
+			Name:      iface.ID,
+			Model:     iface.Name,
+			MAC:       strings.ToLower(iface.MACAddress),
+			SpeedGbps: iface.SpeedMbps / mbpsInGbps,
+		}
+		if nic.MAC == "" {
+			nic.MAC = strings.ToLower(iface.PermanentMACAddress)
+		}
+		if len(iface.IPv4Addresses) > 0 {
+			nic.IP = iface.IPv4Addresses[0].Address
+		}
+		details.NIC = append(details.NIC, nic)
+	}
+	return nil
+}
+
+func (c *Client) collectStorage(ctx context.Context, members []odataID, details *ifc.HardwareDetails) error {
+	for _, member := range members {
+		var controller storage
+		if err := c.getResource(ctx, member.OdataID, &controller); err != nil {
+			return err
+		}
+
+		for _, driveRef := range controller.Drives {
+			var disk drive
+			if err := c.getResource(ctx, driveRef.OdataID, &disk); err != nil {
+				return err
+			}
+			if disk.Status.State == stateAbsent {
+				continue
+			}
+			details.Storage = append(details.Storage, toHardwareStorage(disk))
+		}
+	}
+	return nil
+}
+
+func (c *Client) collectSimpleStorage(ctx context.Context, uri string, details *ifc.HardwareDetails) error {
+	var members collection
+	if err := c.getResource(ctx, uri, &members); err != nil {
+		return err
+	}
+
+	for _, member := range members.Members {
+		var controller simpleStorage
+		if err := c.getResource(ctx, member.OdataID, &controller); err != nil {
+			return err
+		}
+
+		for _, disk := range controller.Devices {
+			if disk.Status.State == stateAbsent {
+				continue
+			}
+			details.Storage = append(details.Storage, toHardwareStorage(disk))
+		}
+	}
+	return nil
+}
+
+func toHardwareStorage(disk drive) ifc.HardwareStorage {
+	name := disk.ID
+	if name == "" {
+		name = disk.Name
+	}
+	return ifc.HardwareStorage{
+		Name:         name,
+		Rotational:   disk.MediaType == mediaTypeHDD,
+		SizeBytes:    disk.CapacityBytes,
+		Vendor:       disk.Manufacturer,
+		Model:        disk.Model,
+		SerialNumber: disk.SerialNumber,
+	}
+}
+
+// getResource retrieves Redfish resource by its odata id and decodes it into v
+func (c *Client) getResource(ctx context.Context, uri string, v interface{}) error {
+	body, err := SendRawRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(body, v); err != nil {
+		log.Debugf("Malformed Redfish response: %s", body)
+		return ErrRedfishClient{Message: fmt.Sprintf("Unable to decode resource '%s'. %v", uri, err)}
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+)
+
+// newHardwareBMC returns BMC serving Redfish resources recorded in testdata/hardware.json
+func newHardwareBMC(t *testing.T) *httptest.Server {
+	data, err := ioutil.ReadFile("testdata/hardware.json")
+	require.NoError(t, err)
+	resources := map[string]json.RawMessage{}
+	require.NoError(t, json.Unmarshal(data, &resources))
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resource, ok := resources[r.URL.Path]
+		if r.Method != http.MethodGet || !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, err := w.Write(resource)
+		require.NoError(t, err)
+	}))
+}
+
+func TestHardwareDetails(t *testing.T) {
+	srv := newHardwareBMC(t)
+	defer srv.Close()
+
+	tests := []struct {
+		name        string
+		systemID    string
+		expected    *ifc.HardwareDetails
+		expectedErr string
+	}{
+		{
+			name:     "all collections",
+			systemID: "1",
+			expected: &ifc.HardwareDetails{
+				SystemVendor: ifc.HardwareSystemVendor{
+					Manufacturer: "Contoso",
+					ProductName:  "3500",
+					SerialNumber: "437XR1138R2",
+				},
+				Firmware:     ifc.HardwareFirmware{BIOS: ifc.HardwareBIOS{Version: "P79 v1.45 (12/06/2017)"}},
+				RAMMebibytes: 65536,
+				NIC: []ifc.HardwareNIC{
+					{
+						Name:      "NIC.1",
+						Model:     "Ethernet Interface 1",
+						MAC:       "52:54:00:b6:ed:31",
+						IP:        "10.23.25.101",
+						SpeedGbps: 25,
+					},
+					{
+						Name:      "NIC.2",
+						Model:     "Ethernet Interface 2",
+						MAC:       "52:54:00:b6:ed:32",
+						SpeedGbps: 1,
+					},
+				},
+				Storage: []ifc.HardwareStorage{
+					{
+						Name:         "Disk.0",
+						SizeBytes:    480103981056,
+						Vendor:       "Contoso",
+						Model:        "SSD-480",
+						SerialNumber: "S0001",
+					},
+					{
+						Name:         "Disk.1",
+						Rotational:   true,
+						SizeBytes:    1999844147200,
+						Vendor:       "Contoso",
+						Model:        "HDD-2000",
+						SerialNumber: "H0001",
+					},
+				},
+				Hostname: "node-1",
+				CPU: ifc.HardwareCPU{
+					Arch:           "x86_64",
+					Model:          "Intel(R) Xeon(R) CPU E5-2667 v4 @ 3.20GHz",
+					ClockMegahertz: 3700,
+					Count:          32,
+				},
+			},
+		},
+		{
+			name:     "summary and simple storage",
+			systemID: "2",
+			expected: &ifc.HardwareDetails{
+				SystemVendor: ifc.HardwareSystemVendor{
+					Manufacturer: "Contoso",
+					ProductName:  "1500",
+				},
+				RAMMebibytes: 16384,
+				Storage: []ifc.HardwareStorage{
+					{
+						Name:      "SATA Disk 0",
+						SizeBytes: 256060514304,
+						Vendor:    "Contoso",
+						Model:     "SATA-256",
+					},
+				},
+				CPU: ifc.HardwareCPU{
+					Model: "Intel(R) Xeon(R) CPU E3-1230 v6",
+					Count: 8,
+				},
+			},
+		},
+		{
+			name:        "missing collection",
+			systemID:    "3",
+			expectedErr: "GET request to '/redfish/v1/Systems/3/Processors' failed. BMC returned status '404 Not Found'.",
+		},
+		{
+			name:        "missing system",
+			systemID:    "4",
+			expectedErr: "GET request to '/redfish/v1/Systems/4' failed. BMC returned status '404 Not Found'.",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClient(srv.URL+"/redfish/v1/Systems/"+tt.systemID, false, false, "", "", 1, 1)
+			require.NoError(t, err)
+
+			details, err := client.HardwareDetails(context.Background())
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, details)
+		})
+	}
+}
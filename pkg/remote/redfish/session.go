@@ -0,0 +1,253 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redfish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+)
+
+const (
+	endpointSessions = "/redfish/v1/SessionService/Sessions"
+	headerAuthToken  = "X-Auth-Token"
+	headerAuth       = "Authorization"
+
+	// sessionCloseTimeout limits the time spent deleting the session
+	sessionCloseTimeout = 10 * time.Second
+)
+
+// sessionTransport is an http.RoundTripper that authenticates Redfish requests with a session token instead of
+// sending basic auth credentials with every request. The session is created through SessionService on the first
+// request and is recreated if BMC responds with 401, e.g. when the session has expired. If BMC doesn't support
+// SessionService, requests are sent with basic auth.
+type sessionTransport struct {
+	base     http.RoundTripper
+	username string
+	password string
+
+	mu sync.Mutex
+	// token is the X-Auth-Token of the current session
+	token string
+	// location is the URI of the current session, used to delete it
+	location string
+	// basicAuth is set when BMC doesn't support sessions
+	basicAuth bool
+}
+
+type sessionRequest struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+}
+
+type sessionResponse struct {
+	ODataID string `json:"@odata.id"`
+}
+
+func newSessionTransport(base http.RoundTripper, username, password string) *sessionTransport {
+	return &sessionTransport{
+		base:     base,
+		username: username,
+		password: password,
+	}
+}
+
+// setCredentials makes the transport authenticate with new credentials, the current session is kept
+// until it expires
+func (t *sessionTransport) setCredentials(username, password string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.username, t.password = username, password
+}
+
+// RoundTrip implements http.RoundTripper interface
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if len(t.username+t.password) == 0 || req.URL.Path == endpointSessions {
+		return t.base.RoundTrip(req)
+	}
+
+	token, err := t.sessionToken(req)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		basicAuthReq := req.Clone(req.Context())
+		basicAuthReq.SetBasicAuth(t.username, t.password)
+		return t.base.RoundTrip(basicAuthReq)
+	}
+
+	resp, err := t.base.RoundTrip(withToken(req, token))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Request body has already been consumed, the request can be resent only if body can be rewound
+	retryReq := req.Clone(req.Context())
+	if req.Body != nil {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		if retryReq.Body, err = req.GetBody(); err != nil {
+			return resp, nil
+		}
+	}
+
+	log.Debugf("Redfish session expired, creating a new session with '%s'.", req.URL.Host)
+	if token, err = t.renewSession(req, token); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if token == "" {
+		retryReq.SetBasicAuth(t.username, t.password)
+		return t.base.RoundTrip(retryReq)
+	}
+	return t.base.RoundTrip(withToken(retryReq, token))
+}
+
+// sessionToken returns token of the current session and creates a new session if there is none, empty token is
+// returned if BMC doesn't support sessions
+func (t *sessionTransport) sessionToken(req *http.Request) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == "" && !t.basicAuth {
+		if err := t.createSession(req); err != nil {
+			return "", err
+		}
+	}
+	return t.token, nil
+}
+
+// renewSession creates a new session unless it was already renewed by concurrent request
+func (t *sessionTransport) renewSession(req *http.Request, expiredToken string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token != expiredToken {
+		return t.token, nil
+	}
+	t.token, t.location = "", ""
+	if err := t.createSession(req); err != nil {
+		return "", err
+	}
+	return t.token, nil
+}
+
+func (t *sessionTransport) createSession(req *http.Request) error {
+	sessionsURL := &url.URL{Scheme: req.URL.Scheme, Host: req.URL.Host, Path: endpointSessions}
+	body, err := json.Marshal(sessionRequest{UserName: t.username, Password: t.password})
+	if err != nil {
+		return err
+	}
+
+	sessionReq, err := http.NewRequestWithContext(req.Context(), http.MethodPost, sessionsURL.String(),
+		bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	sessionReq.Header.Set("Content-Type", "application/json")
+	sessionReq.Header.Set("Accept", "application/json")
+	if userAgent := req.Header.Get("User-Agent"); userAgent != "" {
+		sessionReq.Header.Set("User-Agent", userAgent)
+	}
+
+	resp, err := t.base.RoundTrip(sessionReq)
+	if err != nil {
+		return ErrRedfishClient{Message: fmt.Sprintf("Unable to create Redfish session. %v", err)}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return ErrRedfishClient{Message: fmt.Sprintf("Unable to read Redfish session response. %v", err)}
+	}
+
+	token := resp.Header.Get(headerAuthToken)
+	if (resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK) || token == "" {
+		log.Debugf("Unable to create Redfish session with '%s' (%s), falling back to basic auth.",
+			req.URL.Host, resp.Status)
+		t.basicAuth = true
+		return nil
+	}
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		var session sessionResponse
+		if err = json.Unmarshal(respBody, &session); err == nil {
+			location = session.ODataID
+		}
+	}
+	if location != "" {
+		sessionURL, parseErr := sessionsURL.Parse(location)
+		if parseErr != nil {
+			return ErrRedfishClient{Message: fmt.Sprintf("Malformed Redfish session location %s", location)}
+		}
+		location = sessionURL.String()
+	}
+
+	log.Debugf("Created Redfish session with '%s'.", req.URL.Host)
+	t.token, t.location = token, location
+	return nil
+}
+
+// close deletes the current session, the request has its own timeout, so that the session is deleted
+// even if the context of the operation is already cancelled or expired
+func (t *sessionTransport) close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.token == "" {
+		return nil
+	}
+	token, location := t.token, t.location
+	t.token, t.location = "", ""
+	if location == "" {
+		log.Debug("Redfish session location is unknown, session is left to expire.")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionCloseTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, location, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(headerAuthToken, token)
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return ErrRedfishClient{Message: fmt.Sprintf("Unable to delete Redfish session. %v", err)}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent &&
+		resp.StatusCode != http.StatusNotFound {
+		return ErrRedfishClient{Message: fmt.Sprintf("Unable to delete Redfish session. BMC returned status '%s'.",
+			resp.Status)}
+	}
+
+	log.Debug("Deleted Redfish session.")
+	return nil
+}
+
+func withToken(req *http.Request, token string) *http.Request {
+	tokenReq := req.Clone(req.Context())
+	tokenReq.Header.Del(headerAuth)
+	tokenReq.Header.Set(headerAuthToken, token)
+	return tokenReq
+}
@@ -0,0 +1,197 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package redfish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sessionUsername = "admin"
+	sessionPassword = "secret"
+)
+
+// sessionBMC is a Redfish BMC serving SessionService and recording how requests to other resources are authenticated
+type sessionBMC struct {
+	*httptest.Server
+	noSessionService bool
+
+	mu       sync.Mutex
+	tokens   map[string]bool
+	sessions int
+	deleted  []string
+	auth     []string
+	bodies   []string
+}
+
+func newSessionBMC(t *testing.T, noSessionService bool) *sessionBMC {
+	b := &sessionBMC{noSessionService: noSessionService, tokens: map[string]bool{}}
+	b.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		switch {
+		case r.URL.Path == endpointSessions && r.Method == http.MethodPost:
+			if b.noSessionService {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			var req sessionRequest
+			require.NoError(t, json.Unmarshal(body, &req))
+			if req.UserName != sessionUsername || req.Password != sessionPassword {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			b.sessions++
+			token := fmt.Sprintf("token-%d", b.sessions)
+			b.tokens[token] = true
+			w.Header().Set(headerAuthToken, token)
+			w.Header().Set("Location", fmt.Sprintf("%s/%d", endpointSessions, b.sessions))
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasPrefix(r.URL.Path, endpointSessions+"/") && r.Method == http.MethodDelete:
+			token := r.Header.Get(headerAuthToken)
+			if !b.tokens[token] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			delete(b.tokens, token)
+			b.deleted = append(b.deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			username, password, basicAuth := r.BasicAuth()
+			token := r.Header.Get(headerAuthToken)
+			switch {
+			case token != "" && basicAuth:
+				b.auth = append(b.auth, "token and basic")
+			case b.tokens[token]:
+				b.auth = append(b.auth, token)
+			case token == "" && username == sessionUsername && password == sessionPassword:
+				b.auth = append(b.auth, "basic")
+			default:
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			b.bodies = append(b.bodies, string(body))
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	return b
+}
+
+// expireSessions invalidates all sessions as BMC does when session timeout is reached
+func (b *sessionBMC) expireSessions() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = map[string]bool{}
+}
+
+func newSessionTestClient(t *testing.T, bmc *sessionBMC, username, password string) *Client {
+	client, err := NewClient("redfish+"+bmc.URL+"/redfish/v1/Systems/1", false, false, username, password,
+		systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+	return client
+}
+
+func sendTestRequest(client *Client, body interface{}) error {
+	_, err := SendRawRequest(context.Background(), client.RedfishCFG, client.username, client.password,
+		http.MethodPatch, "/redfish/v1/Systems/1", body)
+	return err
+}
+
+func TestSessionAuth(t *testing.T) {
+	bmc := newSessionBMC(t, false)
+	defer bmc.Close()
+	client := newSessionTestClient(t, bmc, sessionUsername, sessionPassword)
+
+	require.NoError(t, sendTestRequest(client, nil))
+	require.NoError(t, sendTestRequest(client, nil))
+	require.NoError(t, client.CloseSession(context.Background()))
+
+	assert.Equal(t, 1, bmc.sessions)
+	assert.Equal(t, []string{"token-1", "token-1"}, bmc.auth)
+	assert.Equal(t, []string{endpointSessions + "/1"}, bmc.deleted)
+
+	// closing the session again is no-op, next request creates new session
+	require.NoError(t, client.CloseSession(context.Background()))
+	require.NoError(t, sendTestRequest(client, nil))
+	assert.Equal(t, 2, bmc.sessions)
+}
+
+func TestCloseSessionExpiredContext(t *testing.T) {
+	bmc := newSessionBMC(t, false)
+	defer bmc.Close()
+	client := newSessionTestClient(t, bmc, sessionUsername, sessionPassword)
+	require.NoError(t, sendTestRequest(client, nil))
+
+	// session is deleted even if the context of the operation is already expired
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.NoError(t, client.CloseSession(ctx))
+	assert.Equal(t, []string{endpointSessions + "/1"}, bmc.deleted)
+}
+
+func TestSessionAuthRefresh(t *testing.T) {
+	bmc := newSessionBMC(t, false)
+	defer bmc.Close()
+	client := newSessionTestClient(t, bmc, sessionUsername, sessionPassword)
+
+	require.NoError(t, sendTestRequest(client, map[string]int{"Attempt": 1}))
+	bmc.expireSessions()
+	require.NoError(t, sendTestRequest(client, map[string]int{"Attempt": 2}))
+
+	assert.Equal(t, 2, bmc.sessions)
+	assert.Equal(t, []string{"token-1", "token-2"}, bmc.auth)
+	// body of the request rejected with 401 is resent
+	assert.Equal(t, []string{`{"Attempt":1}`, `{"Attempt":2}`}, bmc.bodies)
+}
+
+func TestSessionAuthFallbackToBasicAuth(t *testing.T) {
+	bmc := newSessionBMC(t, true)
+	defer bmc.Close()
+	client := newSessionTestClient(t, bmc, sessionUsername, sessionPassword)
+
+	require.NoError(t, sendTestRequest(client, nil))
+	require.NoError(t, sendTestRequest(client, nil))
+	require.NoError(t, client.CloseSession(context.Background()))
+
+	assert.Equal(t, 0, bmc.sessions)
+	assert.Equal(t, []string{"basic", "basic"}, bmc.auth)
+	assert.Empty(t, bmc.deleted)
+}
+
+func TestSessionAuthInvalidCredentials(t *testing.T) {
+	bmc := newSessionBMC(t, false)
+	defer bmc.Close()
+	client := newSessionTestClient(t, bmc, sessionUsername, "wrong")
+
+	err := sendTestRequest(client, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401 Unauthorized")
+	assert.Equal(t, 0, bmc.sessions)
+}
@@ -13,9 +13,12 @@
 package redfish
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strings"
@@ -25,6 +28,7 @@ import (
 	redfishClient "opendev.org/airship/go-redfish/client"
 
 	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
 )
 
 // URLSchemeSeparator holds the separator for URL scheme
@@ -235,6 +239,71 @@ func ScreenRedfishError(httpResp *http.Response, clientErr error) error {
 	return finalError
 }
 
+// SendRawRequest sends a JSON request to a Redfish endpoint which is not covered by the go-redfish API, e.g. vendor
+// OEM actions. The uri is relative to the BMC base path. The same HTTP client used by the Redfish API is used to send
+// the request, response body is returned if BMC responds with 2xx status code.
+func SendRawRequest(ctx context.Context, cfg *redfishClient.Configuration, username, password, method, uri string,
+	body interface{}) ([]byte, error) {
+	resp, err := sendRequest(ctx, cfg, username, password, method, uri, body)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// rawResponse holds successful response of the BMC to the raw request
+type rawResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func sendRequest(ctx context.Context, cfg *redfishClient.Configuration, username, password, method, uri string,
+	body interface{}) (*rawResponse, error) {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, cfg.BasePath+uri, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("Accept", "application/json")
+	if len(password+username) != 0 {
+		req.SetBasicAuth(username, password)
+	}
+
+	httpResp, err := cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, ErrRedfishClient{Message: fmt.Sprintf("%s request to '%s' failed. %v", method, uri, err)}
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, ErrRedfishClient{Message: fmt.Sprintf("Unable to read response of '%s'. %v", uri, err)}
+	}
+
+	if httpResp.StatusCode < http.StatusOK || httpResp.StatusCode >= http.StatusMultipleChoices {
+		message := fmt.Sprintf("%s request to '%s' failed. BMC returned status '%s'.", method, uri, httpResp.Status)
+		if bmcResponse, decodeErr := DecodeRawError(respBody); decodeErr == nil {
+			message = fmt.Sprintf("%s\nBMC responded: '%s'", message, strings.TrimSpace(bmcResponse))
+		} else {
+			log.Debugf("Unable to decode BMC response. %q", decodeErr)
+		}
+		return nil, ErrRedfishClient{Message: message}
+	}
+
+	return &rawResponse{StatusCode: httpResp.StatusCode, Header: httpResp.Header, Body: respBody}, nil
+}
+
 // SetAuth allows to set username and password to given context so that redfish client can
 // authenticate against redfish server
 func SetAuth(ctx context.Context, username string, password string) context.Context {
@@ -249,6 +318,28 @@ func SetAuth(ctx context.Context, username string, password string) context.Cont
 	)
 }
 
+func toBootSource(device ifc.BootDevice) (redfishClient.BootSource, error) {
+	switch device {
+	case ifc.BootDevicePXE:
+		return redfishClient.BOOTSOURCE_PXE, nil
+	case ifc.BootDeviceDisk:
+		return redfishClient.BOOTSOURCE_HDD, nil
+	case ifc.BootDeviceCD:
+		return redfishClient.BOOTSOURCE_CD, nil
+	default:
+		return "", ErrRedfishClient{Message: fmt.Sprintf("unknown boot device '%s'", device)}
+	}
+}
+
+func isBootSourceAllowed(allowableValues []redfishClient.BootSource, bootSource redfishClient.BootSource) bool {
+	for _, value := range allowableValues {
+		if value == bootSource {
+			return true
+		}
+	}
+	return false
+}
+
 func getManagerID(ctx context.Context, api redfishAPI.RedfishAPI, systemID string) (string, error) {
 	system, _, err := api.GetSystem(ctx, systemID)
 	if err != nil {
@@ -16,11 +16,14 @@ package redfish_test
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	redfishMocks "opendev.org/airship/go-redfish/api/mocks"
 	redfishClient "opendev.org/airship/go-redfish/client"
@@ -248,3 +251,64 @@ func TestGetVirtualMediaIDUnacceptableMediaTypes(t *testing.T) {
 	assert.Empty(t, mediaType)
 	assert.Error(t, err)
 }
+
+func TestSendRawRequest(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		response     string
+		expectedResp string
+		expectedErr  string
+	}{
+		{
+			name:         "success",
+			status:       http.StatusOK,
+			response:     `{"Id": "1"}`,
+			expectedResp: `{"Id": "1"}`,
+		},
+		{
+			name:        "error with extended info",
+			status:      http.StatusBadRequest,
+			response:    redfishHTTPErrOther,
+			expectedErr: "BMC responded: 'Extended error message. Resolution message.'",
+		},
+		{
+			name:        "error without body",
+			status:      http.StatusInternalServerError,
+			expectedErr: "POST request to '/redfish/v1/Systems/1' failed. BMC returned status '500 Internal Server Error'.",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var reqBody string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				reqBody = string(data)
+				username, password, ok := r.BasicAuth()
+				assert.True(t, ok)
+				assert.Equal(t, "username", username)
+				assert.Equal(t, "password", password)
+				assert.Equal(t, "/redfish/v1/Systems/1", r.URL.Path)
+				w.WriteHeader(tt.status)
+				_, err = w.Write([]byte(tt.response))
+				require.NoError(t, err)
+			}))
+			defer srv.Close()
+
+			cfg := &redfishClient.Configuration{BasePath: srv.URL, HTTPClient: srv.Client()}
+			resp, err := redfish.SendRawRequest(context.Background(), cfg, "username", "password",
+				http.MethodPost, "/redfish/v1/Systems/1", map[string]string{"Key": "Value"})
+			assert.Equal(t, `{"Key":"Value"}`, reqBody)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResp, string(resp))
+		})
+	}
+}
@@ -41,7 +41,7 @@ const (
 	    "ShutdownType": "NoReboot",
 	    "ImportBuffer": "<SystemConfiguration>
 	                       <Component FQDD=\"iDRAC.Embedded.1\">
-	                         <Attribute Name=\"ServerBoot.1#BootOnce\">Enabled</Attribute>
+	                         <Attribute Name=\"ServerBoot.1#BootOnce\">%s</Attribute>
 	                         <Attribute Name=\"ServerBoot.1#FirstBootDevice\">VCD-DVD</Attribute>
 	                       </Component>
 	                     </SystemConfiguration>"
@@ -77,6 +77,21 @@ type iDRACAPIExtendedInfo struct {
 // SetBootSourceByType sets the boot source of the ephemeral node to a virtual CD, "VCD-DVD".
 func (c *Client) SetBootSourceByType(ctx context.Context) error {
 	log.Debug("Setting boot device to 'VCD-DVD'.")
+	return c.setVCDBootDevice(ctx, true)
+}
+
+// SetBootDevice sets the device host boots from. Virtual CD is set using iDRAC actions API, other devices
+// are set using the standard Redfish API.
+func (c *Client) SetBootDevice(ctx context.Context, device ifc.BootDevice, persistent bool) error {
+	if device != ifc.BootDeviceCD {
+		return c.Client.SetBootDevice(ctx, device, persistent)
+	}
+
+	log.Debugf("Setting boot device to 'VCD-DVD', persistent: %t.", persistent)
+	return c.setVCDBootDevice(ctx, !persistent)
+}
+
+func (c *Client) setVCDBootDevice(ctx context.Context, bootOnce bool) error {
 	managerID, err := redfish.GetManagerID(
 		redfish.SetAuth(ctx, c.username, c.password),
 		c.RedfishAPI, c.NodeID())
@@ -85,11 +100,17 @@ func (c *Client) SetBootSourceByType(ctx context.Context) error {
 		return err
 	}
 
+	bootOnceValue := "Disabled"
+	if bootOnce {
+		bootOnceValue = "Enabled"
+	}
+
 	// NOTE(drewwalters96): Setting the boot device to a virtual media type requires an API request to the iDRAC
 	// actions API. The request is made below using the same HTTP client used by the Redfish API and exposed by the
 	// standard airshipctl Redfish client. Only iDRAC 9 >= 3.3 is supports this endpoint.
 	url := fmt.Sprintf(endpointImportSysCFG, c.RedfishCFG.BasePath, managerID)
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBufferString(vCDBootRequestBody))
+	req, err := http.NewRequest(http.MethodPost, url,
+		bytes.NewBufferString(fmt.Sprintf(vCDBootRequestBody, bootOnceValue)))
 	if err != nil {
 		return err
 	}
@@ -101,6 +122,11 @@ func (c *Client) SetBootSourceByType(ctx context.Context) error {
 	}
 
 	httpResp, err := c.RedfishCFG.HTTPClient.Do(req)
+	if err != nil {
+		return redfish.ErrRedfishClient{Message: fmt.Sprintf("Unable to set boot device. %v", err)}
+	}
+	defer httpResp.Body.Close()
+
 	if httpResp.StatusCode != http.StatusAccepted {
 		body, ok := ioutil.ReadAll(httpResp.Body)
 		if ok != nil {
@@ -110,7 +136,7 @@ func (c *Client) SetBootSourceByType(ctx context.Context) error {
 
 		var iDRACResp iDRACAPIRespErr
 		ok = json.Unmarshal(body, &iDRACResp)
-		if ok != nil {
+		if ok != nil || len(iDRACResp.Err.ExtendedInfo) == 0 {
 			log.Debugf("Malformed iDRAC response: %s", body)
 			return redfish.ErrRedfishClient{Message: "Unable to set boot device. Malformed iDrac response."}
 		}
@@ -118,13 +144,18 @@ func (c *Client) SetBootSourceByType(ctx context.Context) error {
 		return redfish.ErrRedfishClient{
 			Message: fmt.Sprintf("Unable to set boot device. %s", iDRACResp.Err.ExtendedInfo[0]),
 		}
-	} else if err != nil {
-		return redfish.ErrRedfishClient{Message: fmt.Sprintf("Unable to set boot device. %v", err)}
 	}
 
 	log.Debug("Successfully set boot device.")
-	defer httpResp.Body.Close()
+	return nil
+}
 
+// UpdatePassword changes password of the BMC account and updates credentials of vendor specific requests
+func (c *Client) UpdatePassword(ctx context.Context, newPassword string) error {
+	if err := c.Client.UpdatePassword(ctx, newPassword); err != nil {
+		return err
+	}
+	c.password = newPassword
 	return nil
 }
 
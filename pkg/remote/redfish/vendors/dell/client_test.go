@@ -16,15 +16,20 @@ package dell
 
 import (
 	"context"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	redfishMocks "opendev.org/airship/go-redfish/api/mocks"
 	redfishClient "opendev.org/airship/go-redfish/client"
 
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
 )
 
 const (
@@ -71,3 +76,53 @@ func TestSetBootSourceByTypeGetSystemError(t *testing.T) {
 	err = client.SetBootSourceByType(ctx)
 	assert.Error(t, err)
 }
+
+func TestSetBootDeviceVirtualCD(t *testing.T) {
+	tests := []struct {
+		name             string
+		persistent       bool
+		expectedBootOnce string
+	}{
+		{
+			name:             "one time boot",
+			expectedBootOnce: `ServerBoot.1#BootOnce\">Enabled<`,
+		},
+		{
+			name:             "persistent boot",
+			persistent:       true,
+			expectedBootOnce: `ServerBoot.1#BootOnce\">Disabled<`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var body string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				data, err := ioutil.ReadAll(r.Body)
+				require.NoError(t, err)
+				body = string(data)
+				assert.Contains(t, r.URL.Path, testutil.ManagerID)
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer srv.Close()
+
+			m := &redfishMocks.RedfishAPI{}
+			defer m.AssertExpectations(t)
+
+			client, err := newClient(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+			require.NoError(t, err)
+
+			ctx := redfish.SetAuth(context.Background(), "", "")
+			m.On("GetSystem", ctx, client.NodeID()).Times(1).Return(testutil.GetTestSystem(),
+				&http.Response{StatusCode: 200}, nil)
+
+			client.RedfishAPI = m
+			client.RedfishCFG.BasePath = srv.URL
+
+			err = client.SetBootDevice(ctx, ifc.BootDeviceCD, tt.persistent)
+			require.NoError(t, err)
+			assert.Contains(t, body, tt.expectedBootOnce)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package hpe wraps the standard Redfish client in order to provide additional functionality required to perform
+// actions on HPE iLO servers.
+package hpe
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	redfishAPI "opendev.org/airship/go-redfish/api"
+	redfishClient "opendev.org/airship/go-redfish/client"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+)
+
+const (
+	// ClientType is used by other packages as the identifier of the Redfish client.
+	ClientType           = "redfish-hpe"
+	endpointVirtualMedia = "/redfish/v1/Managers/%s/VirtualMedia/%s"
+	endpointInsertMedia  = endpointVirtualMedia + "/Actions/VirtualMedia.InsertMedia"
+)
+
+// Client is a wrapper around the standard airshipctl Redfish client. This allows vendor specific Redfish clients to
+// override methods without duplicating the entire client.
+type Client struct {
+	username   string
+	password   string
+	redfishURL string
+	redfish.Client
+	RedfishAPI redfishAPI.RedfishAPI
+	RedfishCFG *redfishClient.Configuration
+}
+
+type insertMediaRequest struct {
+	Image string `json:"Image"`
+}
+
+type virtualMediaOEMRequest struct {
+	Oem virtualMediaOEM `json:"Oem"`
+}
+
+type virtualMediaOEM struct {
+	Hpe virtualMediaHpe `json:"Hpe"`
+}
+
+type virtualMediaHpe struct {
+	BootOnNextServerReset bool `json:"BootOnNextServerReset"`
+}
+
+// SetVirtualMedia injects a virtual media device to an established virtual media ID. iLO rejects the standard insert
+// media request containing "Inserted" and "WriteProtected" properties, so only the image is sent.
+func (c *Client) SetVirtualMedia(ctx context.Context, isoPath string) error {
+	ctx = redfish.SetAuth(ctx, c.username, c.password)
+	log.Debugf("Inserting virtual media '%s'.", isoPath)
+	// Eject all previously-inserted media
+	if err := c.EjectVirtualMedia(ctx); err != nil {
+		return err
+	}
+
+	managerID, mediaID, err := c.virtualMediaID(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = redfish.SendRawRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodPost,
+		fmt.Sprintf(endpointInsertMedia, managerID, mediaID), insertMediaRequest{Image: isoPath})
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Successfully set virtual media.")
+	return nil
+}
+
+// SetBootSourceByType sets the ephemeral node to boot once from the inserted virtual media.
+func (c *Client) SetBootSourceByType(ctx context.Context) error {
+	log.Debug("Setting boot device to virtual media on next server reset.")
+	return c.setBootOnNextServerReset(redfish.SetAuth(ctx, c.username, c.password))
+}
+
+// SetBootDevice sets the device host boots from. One time boot from virtual CD is set using iLO virtual media OEM
+// extension, other devices are set using the standard Redfish API.
+func (c *Client) SetBootDevice(ctx context.Context, device ifc.BootDevice, persistent bool) error {
+	if device != ifc.BootDeviceCD || persistent {
+		return c.Client.SetBootDevice(ctx, device, persistent)
+	}
+
+	log.Debug("Setting boot device to virtual media on next server reset.")
+	return c.setBootOnNextServerReset(redfish.SetAuth(ctx, c.username, c.password))
+}
+
+// UpdatePassword changes password of the BMC account and updates credentials of vendor specific requests
+func (c *Client) UpdatePassword(ctx context.Context, newPassword string) error {
+	if err := c.Client.UpdatePassword(ctx, newPassword); err != nil {
+		return err
+	}
+	c.password = newPassword
+	return nil
+}
+
+// RemoteDirect implements remote direct interface
+func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
+	return redfish.RemoteDirect(ctx, isoURL, c.redfishURL, c)
+}
+
+// NOTE: iLO boots from virtual media once if BootOnNextServerReset is set, the flag is cleared by iLO
+// after the server is reset. This is the only way to boot from virtual media once which is supported
+// by all iLO 4 and iLO 5 firmware versions.
+func (c *Client) setBootOnNextServerReset(ctx context.Context) error {
+	managerID, mediaID, err := c.virtualMediaID(ctx)
+	if err != nil {
+		return err
+	}
+
+	req := virtualMediaOEMRequest{Oem: virtualMediaOEM{Hpe: virtualMediaHpe{BootOnNextServerReset: true}}}
+	_, err = redfish.SendRawRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodPatch,
+		fmt.Sprintf(endpointVirtualMedia, managerID, mediaID), req)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Successfully set boot device.")
+	return nil
+}
+
+func (c *Client) virtualMediaID(ctx context.Context) (string, string, error) {
+	managerID, err := redfish.GetManagerID(ctx, c.RedfishAPI, c.NodeID())
+	if err != nil {
+		log.Debugf("Failed to retrieve manager ID for node '%s'.", c.NodeID())
+		return "", "", err
+	}
+
+	mediaID, _, err := redfish.GetVirtualMediaID(ctx, c.RedfishAPI, c.NodeID())
+	if err != nil {
+		return "", "", err
+	}
+
+	return managerID, mediaID, nil
+}
+
+// newClient returns a client with the capability to make Redfish requests.
+func newClient(redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (*Client, error) {
+	genericClient, err := redfish.NewClient(redfishURL, insecure, useProxy, username, password,
+		systemActionRetries, systemRebootDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{username, password, redfishURL, *genericClient, genericClient.RedfishAPI, genericClient.RedfishCFG}
+
+	return c, nil
+}
+
+// ClientFactory is a constructor for redfish ifc.Client implementation
+var ClientFactory ifc.ClientFactory = func(redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (ifc.Client, error) {
+	return newClient(redfishURL, insecure, useProxy,
+		username, password, systemActionRetries, systemRebootDelay)
+}
@@ -0,0 +1,232 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package hpe
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	redfishMocks "opendev.org/airship/go-redfish/api/mocks"
+	redfishClient "opendev.org/airship/go-redfish/client"
+
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+const (
+	redfishURL          = "redfish+https://localhost/redfish/v1/Systems/1"
+	isoPath             = "http://localhost:8099/ephemeral.iso"
+	systemActionRetries = 0
+	systemRebootDelay   = 0
+
+	virtualMediaURI = "/redfish/v1/Managers/" + testutil.ManagerID + "/VirtualMedia/Cd"
+	insertMediaURI  = virtualMediaURI + "/Actions/VirtualMedia.InsertMedia"
+)
+
+// recordedResponse is a response recorded from iLO 5 BMC
+type recordedResponse struct {
+	status int
+	file   string
+}
+
+// newILOServer serves recorded iLO responses keyed by request method and path, bodies of
+// the received requests are stored in requests
+func newILOServer(t *testing.T, responses map[string]recordedResponse, requests map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests[key] = string(body)
+
+		resp, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data, err := ioutil.ReadFile(filepath.Join("testdata", resp.file))
+		require.NoError(t, err)
+		w.WriteHeader(resp.status)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}))
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server) (*Client, *redfishMocks.RedfishAPI) {
+	client, err := newClient(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+
+	ctx := redfish.SetAuth(context.Background(), "", "")
+	httpResp := &http.Response{StatusCode: 200}
+
+	m := &redfishMocks.RedfishAPI{}
+	m.On("GetSystem", ctx, client.NodeID()).Return(testutil.GetTestSystem(), httpResp, nil)
+	m.On("ListManagerVirtualMedia", ctx, testutil.ManagerID).
+		Return(testutil.GetMediaCollection([]string{"Cd"}), httpResp, nil)
+	m.On("GetManagerVirtualMedia", ctx, testutil.ManagerID, "Cd").
+		Return(testutil.GetVirtualMedia([]string{"CD", "DVD"}), httpResp, nil)
+
+	client.RedfishAPI = m
+	client.Client.RedfishAPI = m
+	client.RedfishCFG.BasePath = srv.URL
+	return client, m
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := newClient(redfishURL, false, false, "username", "password", systemActionRetries, systemRebootDelay)
+	assert.NoError(t, err)
+}
+
+func TestNewClientInterface(t *testing.T) {
+	c, err := ClientFactory(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestSetVirtualMedia(t *testing.T) {
+	tests := []struct {
+		name        string
+		response    recordedResponse
+		expectedErr string
+	}{
+		{
+			name:     "success",
+			response: recordedResponse{status: http.StatusOK, file: "insert-media.json"},
+		},
+		{
+			name:        "parameter not supported",
+			response:    recordedResponse{status: http.StatusBadRequest, file: "insert-media-error.json"},
+			expectedErr: "BMC returned status '400 Bad Request'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			requests := map[string]string{}
+			srv := newILOServer(t, map[string]recordedResponse{"POST " + insertMediaURI: tt.response}, requests)
+			defer srv.Close()
+
+			client, m := newTestClient(t, srv)
+			defer m.AssertExpectations(t)
+
+			err := client.SetVirtualMedia(context.Background(), isoPath)
+			assert.JSONEq(t, `{"Image": "`+isoPath+`"}`, requests["POST "+insertMediaURI])
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestSetBootSourceByType(t *testing.T) {
+	requests := map[string]string{}
+	srv := newILOServer(t, map[string]recordedResponse{
+		"PATCH " + virtualMediaURI: {status: http.StatusOK, file: "patch-virtual-media.json"},
+	}, requests)
+	defer srv.Close()
+
+	client, _ := newTestClient(t, srv)
+
+	require.NoError(t, client.SetBootSourceByType(context.Background()))
+	assert.JSONEq(t, `{"Oem": {"Hpe": {"BootOnNextServerReset": true}}}`, requests["PATCH "+virtualMediaURI])
+}
+
+func TestSetBootDevice(t *testing.T) {
+	tests := []struct {
+		name            string
+		device          ifc.BootDevice
+		persistent      bool
+		expectedOEMBoot bool
+	}{
+		{
+			name:            "virtual cd once",
+			device:          ifc.BootDeviceCD,
+			expectedOEMBoot: true,
+		},
+		{
+			name:       "virtual cd persistent",
+			device:     ifc.BootDeviceCD,
+			persistent: true,
+		},
+		{
+			name:   "pxe once",
+			device: ifc.BootDevicePXE,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			requests := map[string]string{}
+			srv := newILOServer(t, map[string]recordedResponse{
+				"PATCH " + virtualMediaURI: {status: http.StatusOK, file: "patch-virtual-media.json"},
+			}, requests)
+			defer srv.Close()
+
+			client, m := newTestClient(t, srv)
+			ctx := redfish.SetAuth(context.Background(), "", "")
+			if !tt.expectedOEMBoot {
+				m.On("SetSystem", ctx, client.NodeID(), mock.Anything).Times(1).Return(
+					redfishClient.ComputerSystem{}, &http.Response{StatusCode: 200}, nil)
+			}
+
+			require.NoError(t, client.SetBootDevice(ctx, tt.device, tt.persistent))
+			_, patched := requests["PATCH "+virtualMediaURI]
+			assert.Equal(t, tt.expectedOEMBoot, patched)
+		})
+	}
+}
+
+func TestUpdatePassword(t *testing.T) {
+	const accountURI = "/redfish/v1/AccountService/Accounts/1"
+	var patched string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /redfish/v1/AccountService/Accounts":
+			fmt.Fprintf(w, `{"Members": [{"@odata.id": "%s"}]}`, accountURI)
+		case "GET " + accountURI:
+			fmt.Fprint(w, `{"UserName": "admin"}`)
+		case "PATCH " + accountURI:
+			body, err := ioutil.ReadAll(r.Body)
+			require.NoError(t, err)
+			patched = string(body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := newClient(redfishURL, false, false, "admin", "password", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+	client.RedfishCFG.BasePath = srv.URL
+
+	require.NoError(t, client.UpdatePassword(context.Background(), "new-password"))
+	assert.JSONEq(t, `{"Password": "new-password"}`, patched)
+	// vendor specific requests use the new password as well
+	assert.Equal(t, "new-password", client.password)
+}
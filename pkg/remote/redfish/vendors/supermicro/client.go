@@ -0,0 +1,225 @@
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package supermicro wraps the standard Redfish client in order to provide additional functionality required to
+// perform actions on Supermicro servers.
+package supermicro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	redfishAPI "opendev.org/airship/go-redfish/api"
+	redfishClient "opendev.org/airship/go-redfish/client"
+
+	"opendev.org/airship/airshipctl/pkg/log"
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+)
+
+const (
+	// ClientType is used by other packages as the identifier of the Redfish client.
+	ClientType          = "redfish-supermicro"
+	endpointSystem      = "/redfish/v1/Systems/%s"
+	endpointInsertMedia = "/redfish/v1/Managers/%s/VirtualMedia/%s/Actions/VirtualMedia.InsertMedia"
+
+	bootSourceUsbCd     = "UsbCd"
+	bootSourcePxe       = "Pxe"
+	bootSourceHdd       = "Hdd"
+	bootEnabledOnce     = "Once"
+	bootEnabledContinue = "Continuous"
+	bootModeUEFI        = "UEFI"
+)
+
+// Client is a wrapper around the standard airshipctl Redfish client. This allows vendor specific Redfish clients to
+// override methods without duplicating the entire client.
+type Client struct {
+	username   string
+	password   string
+	redfishURL string
+	redfish.Client
+	RedfishAPI redfishAPI.RedfishAPI
+	RedfishCFG *redfishClient.Configuration
+}
+
+type insertMediaRequest struct {
+	Image                string `json:"Image"`
+	Inserted             bool   `json:"Inserted"`
+	WriteProtected       bool   `json:"WriteProtected"`
+	TransferProtocolType string `json:"TransferProtocolType"`
+}
+
+type systemBoot struct {
+	Boot boot `json:"Boot"`
+}
+
+type boot struct {
+	BootSourceOverrideEnabled string `json:"BootSourceOverrideEnabled,omitempty"`
+	BootSourceOverrideTarget  string `json:"BootSourceOverrideTarget,omitempty"`
+	BootSourceOverrideMode    string `json:"BootSourceOverrideMode,omitempty"`
+}
+
+// SetVirtualMedia injects a virtual media device to an established virtual media ID. Supermicro BMCs require
+// transfer protocol of the image to be set explicitly in the insert media request.
+func (c *Client) SetVirtualMedia(ctx context.Context, isoPath string) error {
+	ctx = redfish.SetAuth(ctx, c.username, c.password)
+	log.Debugf("Inserting virtual media '%s'.", isoPath)
+	// Eject all previously-inserted media
+	if err := c.EjectVirtualMedia(ctx); err != nil {
+		return err
+	}
+
+	isoURL, err := url.Parse(isoPath)
+	if err != nil {
+		return redfish.ErrRedfishClient{Message: fmt.Sprintf("Virtual media URL malformed %s", err.Error())}
+	}
+
+	managerID, err := redfish.GetManagerID(ctx, c.RedfishAPI, c.NodeID())
+	if err != nil {
+		log.Debugf("Failed to retrieve manager ID for node '%s'.", c.NodeID())
+		return err
+	}
+
+	mediaID, _, err := redfish.GetVirtualMediaID(ctx, c.RedfishAPI, c.NodeID())
+	if err != nil {
+		return err
+	}
+
+	req := insertMediaRequest{
+		Image:                isoPath,
+		Inserted:             true,
+		WriteProtected:       true,
+		TransferProtocolType: strings.ToUpper(isoURL.Scheme),
+	}
+	_, err = redfish.SendRawRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodPost,
+		fmt.Sprintf(endpointInsertMedia, managerID, mediaID), req)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Successfully set virtual media.")
+	return nil
+}
+
+// SetBootSourceByType sets the ephemeral node to boot once from the virtual CD, "UsbCd".
+func (c *Client) SetBootSourceByType(ctx context.Context) error {
+	log.Debugf("Setting boot device to '%s'.", bootSourceUsbCd)
+	return c.setBootSource(redfish.SetAuth(ctx, c.username, c.password), bootSourceUsbCd, bootEnabledOnce)
+}
+
+// SetBootDevice sets the device host boots from, if persistent is false the host boots from
+// the device only once, after that boot order defined in host BIOS is used.
+func (c *Client) SetBootDevice(ctx context.Context, device ifc.BootDevice, persistent bool) error {
+	var bootSource string
+	switch device {
+	case ifc.BootDevicePXE:
+		bootSource = bootSourcePxe
+	case ifc.BootDeviceDisk:
+		bootSource = bootSourceHdd
+	case ifc.BootDeviceCD:
+		bootSource = bootSourceUsbCd
+	default:
+		return redfish.ErrRedfishClient{Message: fmt.Sprintf("unknown boot device '%s'", device)}
+	}
+
+	enabled := bootEnabledOnce
+	if persistent {
+		enabled = bootEnabledContinue
+	}
+
+	log.Debugf("Setting boot device of node '%s' to '%s' (%s).", c.NodeID(), bootSource, enabled)
+	return c.setBootSource(redfish.SetAuth(ctx, c.username, c.password), bootSource, enabled)
+}
+
+// UpdatePassword changes password of the BMC account and updates credentials of vendor specific requests
+func (c *Client) UpdatePassword(ctx context.Context, newPassword string) error {
+	if err := c.Client.UpdatePassword(ctx, newPassword); err != nil {
+		return err
+	}
+	c.password = newPassword
+	return nil
+}
+
+// RemoteDirect implements remote direct interface
+func (c *Client) RemoteDirect(ctx context.Context, isoURL string) error {
+	return redfish.RemoteDirect(ctx, isoURL, c.redfishURL, c)
+}
+
+// NOTE: Supermicro BMCs expose virtual media as "UsbCd" boot source instead of "Cd" and reset the boot mode
+// to legacy BIOS unless it is sent within the same request as the boot source override. The current boot mode
+// is therefore read from the system and sent back along with the override.
+func (c *Client) setBootSource(ctx context.Context, bootSource, enabled string) error {
+	uri := fmt.Sprintf(endpointSystem, c.NodeID())
+	body, err := redfish.SendRawRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodGet, uri, nil)
+	if err != nil {
+		return err
+	}
+
+	var system systemBoot
+	if err = json.Unmarshal(body, &system); err != nil {
+		log.Debugf("Malformed Supermicro response: %s", body)
+		return redfish.ErrRedfishClient{Message: "Unable to set boot device. Malformed Supermicro response."}
+	}
+
+	mode := system.Boot.BootSourceOverrideMode
+	if mode == "" {
+		mode = bootModeUEFI
+	}
+
+	req := systemBoot{Boot: boot{
+		BootSourceOverrideEnabled: enabled,
+		BootSourceOverrideTarget:  bootSource,
+		BootSourceOverrideMode:    mode,
+	}}
+	if _, err = redfish.SendRawRequest(ctx, c.RedfishCFG, c.username, c.password, http.MethodPatch, uri,
+		req); err != nil {
+		return err
+	}
+
+	log.Debug("Successfully set boot device.")
+	return nil
+}
+
+// newClient returns a client with the capability to make Redfish requests.
+func newClient(redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (*Client, error) {
+	genericClient, err := redfish.NewClient(redfishURL, insecure, useProxy, username, password,
+		systemActionRetries, systemRebootDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{username, password, redfishURL, *genericClient, genericClient.RedfishAPI, genericClient.RedfishCFG}
+
+	return c, nil
+}
+
+// ClientFactory is a constructor for redfish ifc.Client implementation
+var ClientFactory ifc.ClientFactory = func(redfishURL string,
+	insecure bool,
+	useProxy bool,
+	username string,
+	password string,
+	systemActionRetries int,
+	systemRebootDelay int) (ifc.Client, error) {
+	return newClient(redfishURL, insecure, useProxy,
+		username, password, systemActionRetries, systemRebootDelay)
+}
@@ -0,0 +1,212 @@
+/*
+ Licensed under the Apache License, Version 2.0 (the "License");
+ you may not use this file except in compliance with the License.
+ You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+ Unless required by applicable law or agreed to in writing, software
+ distributed under the License is distributed on an "AS IS" BASIS,
+ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ See the License for the specific language governing permissions and
+ limitations under the License.
+*/
+
+package supermicro
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	redfishMocks "opendev.org/airship/go-redfish/api/mocks"
+
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
+	"opendev.org/airship/airshipctl/pkg/remote/redfish"
+	testutil "opendev.org/airship/airshipctl/testutil/redfishutils/helpers"
+)
+
+const (
+	redfishURL          = "redfish+https://localhost/redfish/v1/Systems/1"
+	isoPath             = "https://localhost:8099/ephemeral.iso"
+	systemActionRetries = 0
+	systemRebootDelay   = 0
+
+	systemURI      = "/redfish/v1/Systems/1"
+	insertMediaURI = "/redfish/v1/Managers/" + testutil.ManagerID + "/VirtualMedia/CD1/Actions/VirtualMedia.InsertMedia"
+)
+
+// recordedResponse is a response recorded from Supermicro X11 BMC
+type recordedResponse struct {
+	status int
+	file   string
+}
+
+// newBMCServer serves recorded Supermicro responses keyed by request method and path, bodies of
+// the received requests are stored in requests
+func newBMCServer(t *testing.T, responses map[string]recordedResponse, requests map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Method + " " + r.URL.Path
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests[key] = string(body)
+
+		resp, ok := responses[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data, err := ioutil.ReadFile(filepath.Join("testdata", resp.file))
+		require.NoError(t, err)
+		w.WriteHeader(resp.status)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+	}))
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server) *Client {
+	client, err := newClient(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	require.NoError(t, err)
+	client.RedfishCFG.BasePath = srv.URL
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := newClient(redfishURL, false, false, "username", "password", systemActionRetries, systemRebootDelay)
+	assert.NoError(t, err)
+}
+
+func TestNewClientInterface(t *testing.T) {
+	c, err := ClientFactory(redfishURL, false, false, "", "", systemActionRetries, systemRebootDelay)
+	assert.NoError(t, err)
+	assert.NotNil(t, c)
+}
+
+func TestSetVirtualMedia(t *testing.T) {
+	requests := map[string]string{}
+	srv := newBMCServer(t, map[string]recordedResponse{
+		"POST " + insertMediaURI: {status: http.StatusOK, file: "success.json"},
+	}, requests)
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+
+	ctx := redfish.SetAuth(context.Background(), "", "")
+	httpResp := &http.Response{StatusCode: 200}
+	m := &redfishMocks.RedfishAPI{}
+	defer m.AssertExpectations(t)
+	m.On("GetSystem", ctx, client.NodeID()).Return(testutil.GetTestSystem(), httpResp, nil)
+	m.On("ListManagerVirtualMedia", ctx, testutil.ManagerID).
+		Return(testutil.GetMediaCollection([]string{"CD1"}), httpResp, nil)
+	m.On("GetManagerVirtualMedia", ctx, testutil.ManagerID, "CD1").
+		Return(testutil.GetVirtualMedia([]string{"CD", "DVD"}), httpResp, nil)
+	client.RedfishAPI = m
+	client.Client.RedfishAPI = m
+
+	require.NoError(t, client.SetVirtualMedia(ctx, isoPath))
+	assert.JSONEq(t, `{
+		"Image": "`+isoPath+`",
+		"Inserted": true,
+		"WriteProtected": true,
+		"TransferProtocolType": "HTTPS"
+	}`, requests["POST "+insertMediaURI])
+}
+
+func TestSetBootSourceByType(t *testing.T) {
+	requests := map[string]string{}
+	srv := newBMCServer(t, map[string]recordedResponse{
+		"GET " + systemURI:   {status: http.StatusOK, file: "system.json"},
+		"PATCH " + systemURI: {status: http.StatusOK, file: "success.json"},
+	}, requests)
+	defer srv.Close()
+
+	client := newTestClient(t, srv)
+
+	require.NoError(t, client.SetBootSourceByType(context.Background()))
+	assert.JSONEq(t, `{
+		"Boot": {
+			"BootSourceOverrideEnabled": "Once",
+			"BootSourceOverrideTarget": "UsbCd",
+			"BootSourceOverrideMode": "Legacy"
+		}
+	}`, requests["PATCH "+systemURI])
+}
+
+func TestSetBootDevice(t *testing.T) {
+	tests := []struct {
+		name            string
+		device          ifc.BootDevice
+		persistent      bool
+		patchResponse   recordedResponse
+		expectedTarget  string
+		expectedEnabled string
+		expectedErr     string
+	}{
+		{
+			name:            "pxe once",
+			device:          ifc.BootDevicePXE,
+			patchResponse:   recordedResponse{status: http.StatusOK, file: "success.json"},
+			expectedTarget:  "Pxe",
+			expectedEnabled: "Once",
+		},
+		{
+			name:            "disk persistent",
+			device:          ifc.BootDeviceDisk,
+			persistent:      true,
+			patchResponse:   recordedResponse{status: http.StatusOK, file: "success.json"},
+			expectedTarget:  "Hdd",
+			expectedEnabled: "Continuous",
+		},
+		{
+			name:            "virtual cd not in allowable values",
+			device:          ifc.BootDeviceCD,
+			patchResponse:   recordedResponse{status: http.StatusBadRequest, file: "patch-boot-error.json"},
+			expectedTarget:  "UsbCd",
+			expectedEnabled: "Once",
+			expectedErr: "The value UsbCd for the property BootSourceOverrideTarget is not in the list " +
+				"of acceptable values.",
+		},
+		{
+			name:        "unknown device",
+			device:      "floppy",
+			expectedErr: "unknown boot device 'floppy'",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			requests := map[string]string{}
+			srv := newBMCServer(t, map[string]recordedResponse{
+				"GET " + systemURI:   {status: http.StatusOK, file: "system.json"},
+				"PATCH " + systemURI: tt.patchResponse,
+			}, requests)
+			defer srv.Close()
+
+			client := newTestClient(t, srv)
+
+			err := client.SetBootDevice(context.Background(), tt.device, tt.persistent)
+			if tt.expectedTarget != "" {
+				assert.JSONEq(t, `{
+					"Boot": {
+						"BootSourceOverrideEnabled": "`+tt.expectedEnabled+`",
+						"BootSourceOverrideTarget": "`+tt.expectedTarget+`",
+						"BootSourceOverrideMode": "Legacy"
+					}
+				}`, requests["PATCH "+systemURI])
+			}
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
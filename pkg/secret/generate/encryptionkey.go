@@ -32,6 +32,15 @@ var (
 	// pool is the complete collection of characters that can be used for a
 	// passphrase
 	pool []byte
+
+	// charSets are the sets of characters every passphrase contains at least
+	// one character of
+	charSets = []string{
+		asciiLowers,
+		asciiUppers,
+		asciiNumbers,
+		asciiSymbols,
+	}
 )
 
 func init() {
@@ -79,8 +88,23 @@ func (e *EncryptionKeyEngine) GenerateEncryptionKeyN(length int) string {
 	if length < defaultLength {
 		length = defaultLength
 	}
+	return e.generate(length)
+}
+
+// GeneratePassword returns a secure random string of the given length for
+// systems that limit the length of passwords, it contains at least one of
+// each from the sets listed for GenerateEncryptionKeyN. Its length will be
+// max(length, 4)
+func (e *EncryptionKeyEngine) GeneratePassword(length int) string {
+	if length < len(charSets) {
+		length = len(charSets)
+	}
+	return e.generate(length)
+}
+
+func (e *EncryptionKeyEngine) generate(length int) string {
 	var encryptionkey string
-	for !e.isValidEncryptionKey(encryptionkey) {
+	for !e.isValidEncryptionKey(encryptionkey, length) {
 		var sb strings.Builder
 		for i := 0; i < length; i++ {
 			randIndex := e.rng.Intn(len(e.pool))
@@ -92,17 +116,11 @@ func (e *EncryptionKeyEngine) GenerateEncryptionKeyN(length int) string {
 	return encryptionkey
 }
 
-func (e *EncryptionKeyEngine) isValidEncryptionKey(encryptionkey string) bool {
-	if len(encryptionkey) < defaultLength {
+func (e *EncryptionKeyEngine) isValidEncryptionKey(encryptionkey string, length int) bool {
+	if len(encryptionkey) < length {
 		return false
 	}
 
-	charSets := []string{
-		asciiLowers,
-		asciiUppers,
-		asciiNumbers,
-		asciiSymbols,
-	}
 	for _, charSet := range charSets {
 		if !strings.ContainsAny(encryptionkey, charSet) {
 			return false
@@ -113,3 +113,38 @@ func TestGenerateValidEncryptionKeyN(t *testing.T) {
 		assert.Len(passphrase, tt.expectedLength)
 	}
 }
+
+func TestGeneratePassword(t *testing.T) {
+	assert := assert.New(t)
+	engine := generate.NewEncryptionKeyEngine(rand.NewSource(42))
+	tests := []struct {
+		inputLength    int
+		expectedLength int
+	}{
+		{
+			inputLength:    20,
+			expectedLength: 20,
+		},
+		{
+			inputLength:    8,
+			expectedLength: 8,
+		},
+		{
+			inputLength:    2,
+			expectedLength: 4,
+		},
+		{
+			inputLength:    30,
+			expectedLength: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		password := engine.GeneratePassword(tt.inputLength)
+		assert.Len(password, tt.expectedLength)
+		for _, charSet := range []string{asciiLowers, asciiUppers, asciiNumbers, asciiSymbols} {
+			assert.Truef(strings.ContainsAny(password, charSet),
+				"%s does not contain any characters from [%s]", password, charSet)
+		}
+	}
+}
@@ -28,6 +28,7 @@ type MockContainer struct {
 	MockRmContainer       func() error
 	MockGetID             func() string
 	MockWaitUntilFinished func() error
+	MockKillContainer     func() error
 	MockInspectContainer  func() (container.State, error)
 }
 
@@ -63,6 +64,11 @@ func (mc *MockContainer) WaitUntilFinished() error {
 	return mc.MockWaitUntilFinished()
 }
 
+// KillContainer Container interface implementation for unit test purposes
+func (mc *MockContainer) KillContainer() error {
+	return mc.MockKillContainer()
+}
+
 // InspectContainer Container interface implementation for unit test purposes
 func (mc *MockContainer) InspectContainer() (container.State, error) {
 	return mc.MockInspectContainer()
@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"opendev.org/airship/airshipctl/pkg/document"
 	"opendev.org/airship/airshipctl/pkg/inventory/ifc"
 	remoteifc "opendev.org/airship/airshipctl/pkg/remote/ifc"
 )
@@ -41,6 +42,17 @@ func (i *MockInventory) BaremetalInventory() (ifc.BaremetalInventory, error) {
 	return bmhInv, err
 }
 
+// UpdateDocuments mock
+func (i *MockInventory) UpdateDocuments(bundle document.Bundle, encrypter string) error {
+	return i.Called(bundle, encrypter).Error(0)
+}
+
+// ResolveEncrypter mock
+func (i *MockInventory) ResolveEncrypter(bundle document.Bundle, encrypter string) (string, error) {
+	args := i.Called(bundle, encrypter)
+	return args.String(0), args.Error(1)
+}
+
 var _ ifc.BaremetalInventory = &MockBMHInventory{}
 
 // MockBMHInventory mocks ifc.BaremetalInventory
@@ -75,6 +87,52 @@ func (i *MockBMHInventory) RunOperation(
 	context.Context,
 	ifc.BaremetalOperation,
 	ifc.BaremetalHostSelector,
-	ifc.BaremetalBatchRunOptions) error {
-	return i.Called().Error(0)
+	ifc.BaremetalBatchRunOptions) (ifc.BaremetalBatchResult, error) {
+	args := i.Called()
+	err := args.Error(1)
+	result, ok := args.Get(0).(ifc.BaremetalBatchResult)
+	if !ok {
+		return ifc.BaremetalBatchResult{}, err
+	}
+	return result, err
+}
+
+// RenderHosts mock
+func (i *MockBMHInventory) RenderHosts(ifc.BaremetalHostSelector) (document.Bundle, error) {
+	args := i.Called()
+	err := args.Error(1)
+	bundle, ok := args.Get(0).(document.Bundle)
+	if !ok {
+		return nil, err
+	}
+	return bundle, err
+}
+
+// RotateCredentials mock
+func (i *MockBMHInventory) RotateCredentials(
+	context.Context,
+	ifc.BaremetalHostSelector,
+	ifc.BaremetalBatchRunOptions) (ifc.BaremetalBatchResult, document.Bundle, error) {
+	args := i.Called()
+	err := args.Error(2)
+	result, ok := args.Get(0).(ifc.BaremetalBatchResult)
+	if !ok {
+		result = ifc.BaremetalBatchResult{}
+	}
+	bundle, ok := args.Get(1).(document.Bundle)
+	if !ok {
+		return result, nil, err
+	}
+	return result, bundle, err
+}
+
+// CredentialsSecrets mock
+func (i *MockBMHInventory) CredentialsSecrets(ifc.BaremetalHostSelector) (document.Bundle, error) {
+	args := i.Called()
+	err := args.Error(1)
+	bundle, ok := args.Get(0).(document.Bundle)
+	if !ok {
+		return nil, err
+	}
+	return bundle, err
 }
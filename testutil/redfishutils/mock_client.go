@@ -17,6 +17,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"opendev.org/airship/airshipctl/pkg/remote/ifc"
 	"opendev.org/airship/airshipctl/pkg/remote/power"
 	"opendev.org/airship/airshipctl/pkg/remote/redfish"
 )
@@ -137,6 +138,148 @@ func (m *MockClient) SystemPowerStatus(ctx context.Context) (power.Status, error
 	return powerStatus, args.Error(1)
 }
 
+// SystemPowerCycle provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("SystemPowerCycle").Return(<return values>)
+//
+//         err := client.SystemPowerCycle(<args>)
+func (m *MockClient) SystemPowerCycle(ctx context.Context) error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// SetBootDevice provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("SetBootDevice", ifc.BootDevicePXE, false).Return(<return values>)
+//
+//         err := client.SetBootDevice(<args>)
+func (m *MockClient) SetBootDevice(ctx context.Context, device ifc.BootDevice, persistent bool) error {
+	args := m.Called(device, persistent)
+	return args.Error(0)
+}
+
+// WaitForPowerState provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("WaitForPowerState", power.StatusOn).Return(<return values>)
+//
+//         err := client.WaitForPowerState(<args>)
+func (m *MockClient) WaitForPowerState(ctx context.Context, desiredState power.Status) error {
+	args := m.Called(desiredState)
+	return args.Error(0)
+}
+
+// HardwareDetails provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("HardwareDetails").Return(<return values>)
+//
+//         details, err := client.HardwareDetails(<args>)
+func (m *MockClient) HardwareDetails(ctx context.Context) (*ifc.HardwareDetails, error) {
+	args := m.Called()
+	details, ok := args.Get(0).(*ifc.HardwareDetails)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return details, args.Error(1)
+}
+
+// BIOSAttributes provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("BIOSAttributes").Return(<return values>)
+//
+//         attributes, err := client.BIOSAttributes(<args>)
+func (m *MockClient) BIOSAttributes(ctx context.Context) (map[string]interface{}, error) {
+	args := m.Called()
+	attributes, ok := args.Get(0).(map[string]interface{})
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return attributes, args.Error(1)
+}
+
+// SetBIOSAttributes provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("SetBIOSAttributes", map[string]interface{}{"ProcTurboMode": "Disabled"}).Return(<return values>)
+//
+//         err := client.SetBIOSAttributes(<args>)
+func (m *MockClient) SetBIOSAttributes(ctx context.Context, attributes map[string]interface{}) error {
+	args := m.Called(attributes)
+	return args.Error(0)
+}
+
+// FirmwareInventory provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("FirmwareInventory").Return(<return values>)
+//
+//         components, err := client.FirmwareInventory(<args>)
+func (m *MockClient) FirmwareInventory(ctx context.Context) ([]ifc.FirmwareComponent, error) {
+	args := m.Called()
+	components, ok := args.Get(0).([]ifc.FirmwareComponent)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return components, args.Error(1)
+}
+
+// UpdateFirmware provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("UpdateFirmware", "http://images.example.com/bmc.bin").Return(<return values>)
+//
+//         err := client.UpdateFirmware(<args>)
+func (m *MockClient) UpdateFirmware(ctx context.Context, imageURL string) error {
+	args := m.Called(imageURL)
+	return args.Error(0)
+}
+
+// UpdatePassword provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("UpdatePassword", mock.Anything).Return(<return values>)
+//
+//         err := client.UpdatePassword(<args>)
+func (m *MockClient) UpdatePassword(ctx context.Context, newPassword string) error {
+	args := m.Called(newPassword)
+	return args.Error(0)
+}
+
+// VerifyCredentials provides a stubbed method that can be mocked to test functions that use the Redfish client
+// without making any Redfish API calls or requiring the appropriate Redfish client settings.
+//
+//     Example usage:
+//         client := redfishutils.NewClient()
+//         client.On("VerifyCredentials", "admin", mock.Anything).Return(<return values>)
+//
+//         err := client.VerifyCredentials(<args>)
+func (m *MockClient) VerifyCredentials(ctx context.Context, username, password string) error {
+	args := m.Called(username, password)
+	return args.Error(0)
+}
+
 // RemoteDirect mocks remote client interface
 func (m *MockClient) RemoteDirect(ctx context.Context, isoURL string) error {
 	if isoURL == "" {